@@ -0,0 +1,189 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package appraisal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/veraison/corim/comid"
+)
+
+// ClaimType identifies how a Claim entered the Accepted Claims Set.
+type ClaimType int
+
+const (
+	// EvidenceClaim is a claim taken from the attester's evidence.
+	EvidenceClaim ClaimType = iota
+	// ReferenceValueClaim is a claim asserted by a reference value triple
+	// that has been corroborated by evidence.
+	ReferenceValueClaim
+	// EndorsedValueClaim is a claim added by an (optionally conditional)
+	// endorsement.
+	EndorsedValueClaim
+)
+
+func (o ClaimType) String() string {
+	switch o {
+	case EvidenceClaim:
+		return "evidence"
+	case ReferenceValueClaim:
+		return "reference-value"
+	case EndorsedValueClaim:
+		return "endorsed-value"
+	default:
+		return fmt.Sprintf("ClaimType(%d)", int(o))
+	}
+}
+
+// Claim is an entry of the Accepted Claims Set: a set of measurements about an
+// environment, together with the authority that asserted them.
+type Claim struct {
+	Type         ClaimType
+	Environment  comid.Environment
+	Measurements comid.Measurements
+	// Authority holds the key(s) of the entity that asserted the claim. It
+	// is nil if the authority is unknown.
+	Authority *comid.CryptoKeys
+	// Source identifies where the claim came from. It is "evidence" for
+	// evidence claims, and the tag-id of the asserting CoMID otherwise.
+	Source string
+}
+
+// AcceptedClaimsSet is the set of claims accepted by the verifier during
+// appraisal. See
+// https://ietf-rats-wg.github.io/draft-ietf-rats-corim/draft-ietf-rats-corim.html#section-8.2.1
+type AcceptedClaimsSet struct {
+	Claims []*Claim
+}
+
+// Add appends the supplied claim to the Accepted Claims Set.
+func (o *AcceptedClaimsSet) Add(claim *Claim) *AcceptedClaimsSet {
+	if o != nil {
+		o.Claims = append(o.Claims, claim)
+	}
+	return o
+}
+
+// ClaimsOfType returns the claims of the specified type.
+func (o AcceptedClaimsSet) ClaimsOfType(typ ClaimType) []*Claim {
+	var ret []*Claim
+
+	for _, c := range o.Claims {
+		if c.Type == typ {
+			ret = append(ret, c)
+		}
+	}
+
+	return ret
+}
+
+// errNoMatch is returned when no claim in the ACS satisfies a condition.
+var errNoMatch = errors.New("no matching claim")
+
+// MatchMeasurement looks for a claim in the Accepted Claims Set that satisfies
+// the supplied reference measurement within the reference environment. If
+// types are specified, only claims of those types are considered. The first
+// matching claim is returned. If there is no match, the returned error
+// explains why the closest candidate failed.
+func (o AcceptedClaimsSet) MatchMeasurement(
+	env comid.Environment,
+	ref comid.Measurement,
+	types ...ClaimType,
+) (*Claim, error) {
+	reason := fmt.Errorf("%w: no claim for environment", errNoMatch)
+
+	for _, c := range o.Claims {
+		if len(types) != 0 && !hasType(types, c.Type) {
+			continue
+		}
+
+		if err := c.Environment.Match(env); err != nil {
+			continue
+		}
+
+		for _, m := range c.claimedMeasurements() {
+			err := m.MatchReference(ref)
+			if err == nil {
+				return c, nil
+			}
+
+			// a different key means this is not the measurement we
+			// are looking for, rather than a mismatch worth reporting
+			if !errors.Is(err, comid.ErrMeasurementKeyMismatch) {
+				reason = fmt.Errorf("%w: %w", errNoMatch, err)
+			}
+		}
+	}
+
+	return nil, reason
+}
+
+// MatchMeasurements is like MatchMeasurement, but requires every one of the
+// supplied reference measurements to be matched.
+func (o AcceptedClaimsSet) MatchMeasurements(
+	env comid.Environment,
+	refs comid.Measurements,
+	types ...ClaimType,
+) error {
+	for i, ref := range refs.Values {
+		if _, err := o.MatchMeasurement(env, ref, types...); err != nil {
+			return fmt.Errorf("measurement at index %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// MatchEnvironment returns the first claim whose environment is covered by the
+// supplied reference environment.
+func (o AcceptedClaimsSet) MatchEnvironment(env comid.Environment) (*Claim, error) {
+	for _, c := range o.Claims {
+		if c.Environment.Match(env) == nil {
+			return c, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no claim for environment", errNoMatch)
+}
+
+func hasType(types []ClaimType, typ ClaimType) bool {
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+
+	return false
+}
+
+// claimedMeasurements returns the claim's measurements, with their
+// authorized-by set to the claim's authority, unless they specify their own.
+func (o Claim) claimedMeasurements() []comid.Measurement {
+	ret := make([]comid.Measurement, 0, len(o.Measurements.Values))
+
+	for _, m := range o.Measurements.Values {
+		if m.AuthorizedBy == nil {
+			m.AuthorizedBy = o.Authority
+		}
+		ret = append(ret, m)
+	}
+
+	return ret
+}
+
+// valueTriples returns the claims in the Accepted Claims Set as value
+// triples, with the measurements' authorized-by set as per
+// claimedMeasurements.
+func (o AcceptedClaimsSet) valueTriples() []comid.ValueTriple {
+	ret := make([]comid.ValueTriple, 0, len(o.Claims))
+
+	for _, c := range o.Claims {
+		vt := comid.ValueTriple{Environment: c.Environment}
+		vt.Measurements.Values = c.claimedMeasurements()
+		ret = append(ret, vt)
+	}
+
+	return ret
+}
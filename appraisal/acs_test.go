@@ -0,0 +1,69 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package appraisal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/comid"
+)
+
+func testACS() AcceptedClaimsSet {
+	var acs AcceptedClaimsSet
+
+	for _, vt := range testEvidence().Values {
+		acs.Add(&Claim{
+			Type:         EvidenceClaim,
+			Environment:  vt.Environment,
+			Measurements: vt.Measurements,
+			Source:       "evidence",
+		})
+	}
+
+	return acs
+}
+
+func TestAcceptedClaimsSet_MatchMeasurement_OK(t *testing.T) {
+	acs := testACS()
+
+	claim, err := acs.MatchMeasurement(testVendorEnvironment(),
+		*comid.MustNewUintMeasurement(uint64(1)).SetMinSVN(3))
+	require.NoError(t, err)
+	assert.Equal(t, acs.Claims[0], claim)
+
+	// a reference without a key matches any measurement
+	ref := comid.Measurement{}
+	ref.Val.SVN = comid.MustNewTaggedSVN(3)
+	_, err = acs.MatchMeasurement(testVendorEnvironment(), ref, EvidenceClaim)
+	assert.NoError(t, err)
+}
+
+func TestAcceptedClaimsSet_MatchMeasurement_NOK(t *testing.T) {
+	acs := testACS()
+
+	_, err := acs.MatchMeasurement(testVendorEnvironment(),
+		*comid.MustNewUintMeasurement(uint64(2)).SetMinSVN(3))
+	assert.EqualError(t, err, "no matching claim: no claim for environment")
+
+	_, err = acs.MatchMeasurement(testVendorEnvironment(),
+		*comid.MustNewUintMeasurement(uint64(1)).SetMinSVN(4))
+	assert.EqualError(t, err,
+		"no matching claim: measurement values mismatch: svn: claimed svn 3 is lower than reference min-svn 4")
+
+	_, err = acs.MatchMeasurement(testVendorEnvironment(),
+		*comid.MustNewUintMeasurement(uint64(1)).SetMinSVN(3), EndorsedValueClaim)
+	assert.EqualError(t, err, "no matching claim: no claim for environment")
+
+	_, err = acs.MatchEnvironment(comid.Environment{Class: comid.NewClassOID(comid.TestOID)})
+	assert.EqualError(t, err, "no matching claim: no claim for environment")
+}
+
+func TestClaimType_String(t *testing.T) {
+	assert.Equal(t, "evidence", EvidenceClaim.String())
+	assert.Equal(t, "reference-value", ReferenceValueClaim.String())
+	assert.Equal(t, "endorsed-value", EndorsedValueClaim.String())
+	assert.Equal(t, "ClaimType(7)", ClaimType(7).String())
+}
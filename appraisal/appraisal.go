@@ -0,0 +1,356 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package appraisal implements the verifier reconciliation of evidence against
+// reference values and endorsements, as described in the "Verifier Processing"
+// section of the CoRIM spec. The result of an appraisal is an Accepted Claims
+// Set, together with an account of which triples matched and why others did
+// not.
+//
+// See https://ietf-rats-wg.github.io/draft-ietf-rats-corim/draft-ietf-rats-corim.html#section-8
+package appraisal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/veraison/corim/coev"
+	"github.com/veraison/corim/comid"
+	"github.com/veraison/corim/corim"
+)
+
+// Rim is a verified CoRIM that takes part in an appraisal, together with the
+// authority (typically, the key that signed it) on whose behalf its triples
+// are asserted.
+type Rim struct {
+	Corim     *corim.UnsignedCorim
+	Authority *comid.CryptoKeys
+}
+
+// TripleKind identifies the kind of a CoMID triple taking part in appraisal.
+type TripleKind int
+
+const (
+	ReferenceValueTriple TripleKind = iota
+	EndorsedValueTriple
+	CondEndorseTriple
+	CondEndorseSeriesTriple
+)
+
+func (o TripleKind) String() string {
+	switch o {
+	case ReferenceValueTriple:
+		return "reference-value"
+	case EndorsedValueTriple:
+		return "endorsed-value"
+	case CondEndorseTriple:
+		return "conditional-endorsement"
+	case CondEndorseSeriesTriple:
+		return "conditional-endorsement-series"
+	default:
+		return fmt.Sprintf("TripleKind(%d)", int(o))
+	}
+}
+
+// TripleResult records the outcome of evaluating a single CoMID triple.
+type TripleResult struct {
+	Kind TripleKind
+	// TagID is the tag-id of the CoMID containing the triple.
+	TagID string
+	// Index is the position of the triple among the triples of the same kind
+	// within the CoMID.
+	Index int
+	// Matched is true if the triple's conditions were satisfied.
+	Matched bool
+	// Reason explains why the triple did not match. It is empty for
+	// matching triples.
+	Reason string
+	// Added lists the claims that were added to the ACS as a result of the
+	// triple matching.
+	Added []*Claim
+	// Authority is the authority on whose behalf the triple was asserted.
+	Authority *comid.CryptoKeys
+}
+
+// Result is the outcome of an appraisal.
+type Result struct {
+	ACS     AcceptedClaimsSet
+	Triples []TripleResult
+}
+
+// Matched returns the results of the triples of the specified kind that
+// matched.
+func (o Result) Matched(kind TripleKind) []TripleResult {
+	return o.filter(kind, true)
+}
+
+// Unmatched returns the results of the triples of the specified kind that did
+// not match.
+func (o Result) Unmatched(kind TripleKind) []TripleResult {
+	return o.filter(kind, false)
+}
+
+func (o Result) filter(kind TripleKind, matched bool) []TripleResult {
+	var ret []TripleResult
+
+	for _, t := range o.Triples {
+		if t.Kind == kind && t.Matched == matched {
+			ret = append(ret, t)
+		}
+	}
+
+	return ret
+}
+
+// Appraise appraises the evidence triples of the supplied ConciseEvidence
+// against the supplied CoRIMs. attester is the authority of the evidence and
+// may be nil. See AppraiseTriples.
+func Appraise(ev *coev.ConciseEvidence, attester *comid.CryptoKeys, rims ...Rim) (*Result, error) {
+	if ev == nil {
+		return nil, errors.New("no evidence")
+	}
+
+	var triples comid.ValueTriples
+	if ev.EvTriples.EvidenceTriples != nil {
+		triples = *ev.EvTriples.EvidenceTriples
+	}
+
+	return AppraiseTriples(triples, attester, rims...)
+}
+
+// AppraiseTriples runs the appraisal phases over the supplied evidence triples
+// and CoRIMs:
+//
+//  1. the evidence is added to the Accepted Claims Set (ACS);
+//  2. reference value triples are corroborated against the evidence, and the
+//     ones that match are added to the ACS;
+//  3. endorsed value triples whose environment is present in the ACS are added
+//     to the ACS;
+//  4. conditional endorsement and conditional endorsement series triples are
+//     evaluated against the ACS, and their endorsements added, until no more
+//     triples match.
+//
+// The CoMIDs inside each CoRIM are decoded using the extensions registered for
+// the CoRIM's profile, if any. Tags other than CoMIDs are ignored.
+func AppraiseTriples(
+	evidence comid.ValueTriples,
+	attester *comid.CryptoKeys,
+	rims ...Rim,
+) (*Result, error) {
+	var res Result
+
+	for i, vt := range evidence.Values {
+		if err := vt.Valid(); err != nil {
+			return nil, fmt.Errorf("evidence triple at index %d: %w", i, err)
+		}
+
+		res.ACS.Add(&Claim{
+			Type:         EvidenceClaim,
+			Environment:  vt.Environment,
+			Measurements: vt.Measurements,
+			Authority:    attester,
+			Source:       "evidence",
+		})
+	}
+
+	sources, err := decodeRims(rims)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range sources {
+		if s.comid.Triples.ReferenceValues == nil {
+			continue
+		}
+
+		for i, rv := range s.comid.Triples.ReferenceValues.Values {
+			res.Triples = append(res.Triples, res.corroborate(s, i, rv))
+		}
+	}
+
+	for _, s := range sources {
+		if s.comid.Triples.EndorsedValues == nil {
+			continue
+		}
+
+		for i, ev := range s.comid.Triples.EndorsedValues.Values {
+			res.Triples = append(res.Triples, res.endorse(s, i, ev))
+		}
+	}
+
+	res.evaluateConditional(sources)
+
+	return &res, nil
+}
+
+// source is a CoMID decoded from a Rim.
+type source struct {
+	comid     *comid.Comid
+	tagID     string
+	authority *comid.CryptoKeys
+}
+
+func decodeRims(rims []Rim) ([]source, error) {
+	var ret []source
+
+	for i, rim := range rims {
+		if rim.Corim == nil {
+			return nil, fmt.Errorf("rim at index %d: no CoRIM", i)
+		}
+
+		for j, tag := range rim.Corim.Tags {
+			if tag.Number != corim.ComidTag {
+				continue
+			}
+
+			cm, err := corim.UnmarshalComidFromCBOR(tag.Content, rim.Corim.Profile)
+			if err != nil {
+				return nil, fmt.Errorf("rim at index %d: CoMID tag at index %d: %w", i, j, err)
+			}
+
+			ret = append(ret, source{
+				comid:     cm,
+				tagID:     cm.TagIdentity.TagID.String(),
+				authority: rim.Authority,
+			})
+		}
+	}
+
+	return ret, nil
+}
+
+func (o *Result) newTripleResult(kind TripleKind, s source, index int) TripleResult {
+	return TripleResult{
+		Kind:      kind,
+		TagID:     s.tagID,
+		Index:     index,
+		Authority: s.authority,
+	}
+}
+
+func (o *Result) addClaim(tr *TripleResult, typ ClaimType, env comid.Environment, ms comid.Measurements) {
+	claim := &Claim{
+		Type:         typ,
+		Environment:  env,
+		Measurements: ms,
+		Authority:    tr.Authority,
+		Source:       tr.TagID,
+	}
+
+	o.ACS.Add(claim)
+	tr.Added = append(tr.Added, claim)
+	tr.Matched = true
+	tr.Reason = ""
+}
+
+// corroborate matches a reference value triple against the evidence claims.
+func (o *Result) corroborate(s source, index int, rv comid.ValueTriple) TripleResult {
+	tr := o.newTripleResult(ReferenceValueTriple, s, index)
+
+	if err := o.ACS.MatchMeasurements(rv.Environment, rv.Measurements, EvidenceClaim); err != nil {
+		tr.Reason = err.Error()
+		return tr
+	}
+
+	o.addClaim(&tr, ReferenceValueClaim, rv.Environment, rv.Measurements)
+
+	return tr
+}
+
+// endorse adds an endorsed value triple to the ACS if its environment is
+// present in the ACS.
+func (o *Result) endorse(s source, index int, ev comid.ValueTriple) TripleResult {
+	tr := o.newTripleResult(EndorsedValueTriple, s, index)
+
+	if _, err := o.ACS.MatchEnvironment(ev.Environment); err != nil {
+		tr.Reason = err.Error()
+		return tr
+	}
+
+	o.addClaim(&tr, EndorsedValueClaim, ev.Environment, ev.Measurements)
+
+	return tr
+}
+
+// conditional is a pending conditional endorsement (series) triple.
+type conditional struct {
+	tr     TripleResult
+	eval   func(tr *TripleResult) bool
+	active bool
+}
+
+// evaluateConditional repeatedly evaluates the conditional endorsement
+// triples until none of the remaining ones match, since endorsements added
+// by one triple may satisfy the conditions of another.
+func (o *Result) evaluateConditional(sources []source) {
+	var pending []*conditional
+
+	for _, s := range sources {
+		if s.comid.Triples.CondEndorsements != nil {
+			for i, ce := range s.comid.Triples.CondEndorsements.Values {
+				pending = append(pending, &conditional{
+					tr:     o.newTripleResult(CondEndorseTriple, s, i),
+					eval:   func(tr *TripleResult) bool { return o.condEndorse(tr, ce) },
+					active: true,
+				})
+			}
+		}
+
+		if s.comid.Triples.CondEndorseSeries != nil {
+			for i, ces := range s.comid.Triples.CondEndorseSeries.Values {
+				pending = append(pending, &conditional{
+					tr:     o.newTripleResult(CondEndorseSeriesTriple, s, i),
+					eval:   func(tr *TripleResult) bool { return o.condEndorseSeries(tr, ces) },
+					active: true,
+				})
+			}
+		}
+	}
+
+	for progress := true; progress; {
+		progress = false
+
+		for _, c := range pending {
+			if c.active && c.eval(&c.tr) {
+				c.active = false
+				progress = true
+			}
+		}
+	}
+
+	for _, c := range pending {
+		o.Triples = append(o.Triples, c.tr)
+	}
+}
+
+// condEndorse evaluates a conditional endorsement triple: if every condition
+// is satisfied by the ACS, the endorsements are added to it.
+func (o *Result) condEndorse(tr *TripleResult, ce comid.CondEndorseTriple) bool {
+	for i, cond := range ce.Conditions.Values {
+		if err := o.ACS.MatchMeasurements(cond.Environment, cond.Measurements); err != nil {
+			tr.Reason = fmt.Sprintf("condition at index %d: %v", i, err)
+			return false
+		}
+	}
+
+	for _, e := range ce.Endorsements.Values {
+		o.addClaim(tr, EndorsedValueClaim, e.Environment, e.Measurements)
+	}
+
+	return true
+}
+
+// condEndorseSeries evaluates a conditional endorsement series triple
+// against the ACS, and adds the additions of the selected series record (if
+// any) to the ACS. See comid.CondEndorseSeriesTriple.Evaluate.
+func (o *Result) condEndorseSeries(tr *TripleResult, ces comid.CondEndorseSeriesTriple) bool {
+	additions, _, err := ces.Evaluate(o.ACS.valueTriples())
+	if err != nil {
+		tr.Reason = err.Error()
+		return false
+	}
+
+	o.addClaim(tr, EndorsedValueClaim, ces.Condition.Environment, *additions)
+
+	return true
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package appraisal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/coev"
+	"github.com/veraison/corim/comid"
+	"github.com/veraison/corim/corim"
+)
+
+var (
+	testDigest      = []byte{0xde, 0xad, 0xbe, 0xef}
+	testOtherDigest = []byte{0xba, 0xdb, 0xad, 0x00}
+)
+
+func testEnvironment() comid.Environment {
+	return comid.Environment{
+		Class: comid.NewClassUUID(comid.TestUUID).
+			SetVendor("ACME Ltd.").
+			SetModel("RoadRunner"),
+	}
+}
+
+func testVendorEnvironment() comid.Environment {
+	return comid.Environment{
+		Class: (&comid.Class{}).SetVendor("ACME Ltd."),
+	}
+}
+
+func testEvidence() comid.ValueTriples {
+	return *comid.NewValueTriples().Add(&comid.ValueTriple{
+		Environment: testEnvironment(),
+		Measurements: *comid.NewMeasurements().Add(
+			comid.MustNewUintMeasurement(uint64(1)).
+				AddDigest(comid.Sha256_32, testDigest).
+				SetSVN(3),
+		),
+	})
+}
+
+func testAuthority() *comid.CryptoKeys {
+	return comid.NewCryptoKeys().Add(comid.MustNewPKIXBase64Key(comid.TestECPubKey))
+}
+
+func testComid(tagID string) *comid.Comid {
+	return comid.NewComid().SetTagIdentity(tagID, 0)
+}
+
+func testRim(t *testing.T, comids ...*comid.Comid) Rim {
+	uc := corim.NewUnsignedCorim().SetID("test-corim")
+	for _, c := range comids {
+		uc = uc.AddComid(c)
+		require.NotNil(t, uc)
+	}
+
+	return Rim{Corim: uc, Authority: testAuthority()}
+}
+
+func testSeriesTriple() *comid.CondEndorseSeriesTriple {
+	return &comid.CondEndorseSeriesTriple{
+		Condition: comid.CondEndorseSeriesCondition{
+			Environment: testVendorEnvironment(),
+			Measurements: *comid.NewMeasurements().Add(
+				comid.MustNewUintMeasurement(uint64(3)).SetFlagsTrue(comid.FlagIsSecure),
+			),
+		},
+		Series: *comid.NewCondEndorseSeriesRecords().
+			Add(&comid.CondEndorseSeriesRecord{
+				Selection: *comid.NewMeasurements().Add(
+					comid.MustNewUintMeasurement(uint64(1)).SetSVN(5),
+				),
+				Addition: *comid.NewMeasurements().Add(
+					comid.MustNewUintMeasurement(uint64(4)).SetName("latest"),
+				),
+			}).
+			Add(&comid.CondEndorseSeriesRecord{
+				Selection: *comid.NewMeasurements().Add(
+					comid.MustNewUintMeasurement(uint64(1)).SetMinSVN(3),
+				),
+				Addition: *comid.NewMeasurements().Add(
+					comid.MustNewUintMeasurement(uint64(4)).SetName("patched"),
+				),
+			}),
+	}
+}
+
+func testCondEndorseTriple() *comid.CondEndorseTriple {
+	return &comid.CondEndorseTriple{
+		Conditions: *comid.NewStatefulEnvironments().Add(&comid.StatefulEnvironment{
+			Environment: testVendorEnvironment(),
+			Measurements: *comid.NewMeasurements().Add(
+				comid.MustNewUintMeasurement(uint64(2)).SetName("firmware"),
+			),
+		}),
+		Endorsements: *comid.NewValueTriples().Add(&comid.ValueTriple{
+			Environment: testEnvironment(),
+			Measurements: *comid.NewMeasurements().Add(
+				comid.MustNewUintMeasurement(uint64(3)).SetFlagsTrue(comid.FlagIsSecure),
+			),
+		}),
+	}
+}
+
+func TestAppraiseTriples_OK(t *testing.T) {
+	// the series triple can only match after the conditional endorsement
+	// (which appears later) has been added to the ACS
+	seriesComid := testComid("series-tag").AddCondEndorseSeries(testSeriesTriple())
+	require.NotNil(t, seriesComid)
+
+	mainComid := testComid("main-tag").
+		AddReferenceValue(&comid.ValueTriple{
+			Environment: testVendorEnvironment(),
+			Measurements: *comid.NewMeasurements().Add(
+				comid.MustNewUintMeasurement(uint64(1)).
+					AddDigest(comid.Sha256_32, testDigest).
+					SetMinSVN(2),
+			),
+		}).
+		AddReferenceValue(&comid.ValueTriple{
+			Environment: testVendorEnvironment(),
+			Measurements: *comid.NewMeasurements().Add(
+				comid.MustNewUintMeasurement(uint64(1)).
+					AddDigest(comid.Sha256_32, testOtherDigest),
+			),
+		}).
+		AddEndorsedValue(&comid.ValueTriple{
+			Environment: testEnvironment(),
+			Measurements: *comid.NewMeasurements().Add(
+				comid.MustNewUintMeasurement(uint64(2)).SetName("firmware"),
+			),
+		})
+	require.NotNil(t, mainComid)
+	mainComid.Triples.AddCondEndorsement(testCondEndorseTriple())
+
+	res, err := AppraiseTriples(testEvidence(), nil, testRim(t, seriesComid, mainComid))
+	require.NoError(t, err)
+
+	rvs := res.Matched(ReferenceValueTriple)
+	require.Len(t, rvs, 1)
+	assert.Equal(t, "main-tag", rvs[0].TagID)
+	assert.Equal(t, 0, rvs[0].Index)
+	assert.Equal(t, testAuthority(), rvs[0].Authority)
+	require.Len(t, rvs[0].Added, 1)
+	assert.Equal(t, ReferenceValueClaim, rvs[0].Added[0].Type)
+
+	unmatched := res.Unmatched(ReferenceValueTriple)
+	require.Len(t, unmatched, 1)
+	assert.Equal(t, 1, unmatched[0].Index)
+	assert.Contains(t, unmatched[0].Reason, "digests")
+
+	assert.Len(t, res.Matched(EndorsedValueTriple), 1)
+	assert.Len(t, res.Matched(CondEndorseTriple), 1)
+
+	series := res.Matched(CondEndorseSeriesTriple)
+	require.Len(t, series, 1)
+	assert.Equal(t, "series-tag", series[0].TagID)
+	require.Len(t, series[0].Added, 1)
+	assert.Equal(t, "patched", *series[0].Added[0].Measurements.Values[0].Val.Name)
+
+	assert.Len(t, res.ACS.ClaimsOfType(EvidenceClaim), 1)
+	assert.Len(t, res.ACS.ClaimsOfType(ReferenceValueClaim), 1)
+	assert.Len(t, res.ACS.ClaimsOfType(EndorsedValueClaim), 3)
+
+	for _, c := range res.ACS.ClaimsOfType(EndorsedValueClaim) {
+		assert.Equal(t, testAuthority(), c.Authority)
+	}
+}
+
+func TestAppraiseTriples_unmatched_conditions(t *testing.T) {
+	c := testComid("series-tag").AddCondEndorseSeries(testSeriesTriple())
+	require.NotNil(t, c)
+	c.Triples.AddCondEndorsement(testCondEndorseTriple())
+
+	res, err := AppraiseTriples(testEvidence(), nil, testRim(t, c))
+	require.NoError(t, err)
+
+	assert.Empty(t, res.ACS.ClaimsOfType(EndorsedValueClaim))
+
+	ce := res.Unmatched(CondEndorseTriple)
+	require.Len(t, ce, 1)
+	assert.Contains(t, ce[0].Reason, "condition at index 0")
+
+	ces := res.Unmatched(CondEndorseSeriesTriple)
+	require.Len(t, ces, 1)
+	assert.Contains(t, ces[0].Reason, "series condition not met")
+}
+
+func TestAppraiseTriples_authorized_by(t *testing.T) {
+	triple := testSeriesTriple()
+	triple.Condition.Measurements = *comid.NewMeasurements().Add(
+		comid.MustNewUintMeasurement(uint64(1)).AddDigest(comid.Sha256_32, testDigest),
+	)
+	triple.Condition.AuthorizedBy = testAuthority()
+
+	c := testComid("series-tag").AddCondEndorseSeries(triple)
+	require.NotNil(t, c)
+
+	res, err := AppraiseTriples(testEvidence(), nil, testRim(t, c))
+	require.NoError(t, err)
+	ces := res.Unmatched(CondEndorseSeriesTriple)
+	require.Len(t, ces, 1)
+	assert.Contains(t, ces[0].Reason, "not asserted by a required authority")
+
+	res, err = AppraiseTriples(testEvidence(), testAuthority(), testRim(t, c))
+	require.NoError(t, err)
+	assert.Len(t, res.Matched(CondEndorseSeriesTriple), 1)
+}
+
+func TestAppraise_OK(t *testing.T) {
+	evidence := testEvidence()
+	ev := coev.NewConciseEvidence()
+	require.NoError(t, ev.AddTriples(&coev.EvTriples{EvidenceTriples: &evidence}))
+
+	c := testComid("main-tag").AddEndorsedValue(&comid.ValueTriple{
+		Environment: testVendorEnvironment(),
+		Measurements: *comid.NewMeasurements().Add(
+			comid.MustNewUintMeasurement(uint64(2)).SetName("firmware"),
+		),
+	})
+	require.NotNil(t, c)
+
+	res, err := Appraise(ev, nil, testRim(t, c))
+	require.NoError(t, err)
+	assert.Len(t, res.Matched(EndorsedValueTriple), 1)
+	assert.Len(t, res.ACS.Claims, 2)
+}
+
+func TestAppraise_NOK(t *testing.T) {
+	_, err := Appraise(nil, nil)
+	assert.EqualError(t, err, "no evidence")
+
+	_, err = AppraiseTriples(testEvidence(), nil, Rim{})
+	assert.EqualError(t, err, "rim at index 0: no CoRIM")
+
+	_, err = AppraiseTriples(*comid.NewValueTriples().Add(&comid.ValueTriple{}), nil)
+	assert.ErrorContains(t, err, "evidence triple at index 0")
+}
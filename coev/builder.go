@@ -0,0 +1,96 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package coev
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/veraison/corim/comid"
+)
+
+// ConciseEvidenceBuilder builds a ConciseEvidence, including its evidence
+// triples, in a single chain of calls. Instead of stopping at the first
+// failure, it records the reason and carries on. Errors are reported by Err
+// and Build.
+type ConciseEvidenceBuilder struct {
+	comid.BuildErrors
+	ev *ConciseEvidence
+}
+
+// NewConciseEvidenceBuilder instantiates a builder for an empty
+// ConciseEvidence
+func NewConciseEvidenceBuilder() *ConciseEvidenceBuilder {
+	return &ConciseEvidenceBuilder{ev: NewConciseEvidence()}
+}
+
+// Build returns the built ConciseEvidence, or the errors recorded by the
+// builder. If there are none, the ConciseEvidence is validated.
+func (o *ConciseEvidenceBuilder) Build() (*ConciseEvidence, error) {
+	if err := o.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := o.ev.Valid(); err != nil {
+		return nil, fmt.Errorf("invalid concise evidence: %w", err)
+	}
+
+	return o.ev, nil
+}
+
+// AddTriples is like [ConciseEvidence.AddTriples]. It replaces any evidence
+// triples added so far.
+func (o *ConciseEvidenceBuilder) AddTriples(evTriples *EvTriples) *ConciseEvidenceBuilder {
+	o.Record("AddTriples", o.ev.AddTriples(evTriples))
+
+	return o
+}
+
+// AddEvidenceID is like [ConciseEvidence.AddEvidenceID]
+func (o *ConciseEvidenceBuilder) AddEvidenceID(evidenceID *EvidenceID) *ConciseEvidenceBuilder {
+	o.Record("AddEvidenceID", o.ev.AddEvidenceID(evidenceID))
+
+	return o
+}
+
+// AddProfile is like [ConciseEvidence.AddProfile]
+func (o *ConciseEvidenceBuilder) AddProfile(urlOrOID string) *ConciseEvidenceBuilder {
+	o.Record("AddProfile", o.ev.AddProfile(urlOrOID))
+
+	return o
+}
+
+// AddEvidenceTriple is like [EvTriples.AddEvidenceTriple], but also rejects a
+// nil triple
+func (o *ConciseEvidenceBuilder) AddEvidenceTriple(val *comid.ValueTriple) *ConciseEvidenceBuilder {
+	if val == nil {
+		o.Record("AddEvidenceTriple", errors.New("nil evidence triple"))
+		return o
+	}
+
+	o.Record("AddEvidenceTriple", o.ev.EvTriples.addEvidenceTriple(val))
+
+	return o
+}
+
+// AddCoSWIDTriple is like [EvTriples.AddCoSWIDTriple]
+func (o *ConciseEvidenceBuilder) AddCoSWIDTriple(val *CoSWIDTriple) *ConciseEvidenceBuilder {
+	o.Record("AddCoSWIDTriple", o.ev.EvTriples.addCoSWIDTriple(val))
+
+	return o
+}
+
+// AddIdentityTriple is like [EvTriples.AddIdentityTriple]
+func (o *ConciseEvidenceBuilder) AddIdentityTriple(val *comid.KeyTriple) *ConciseEvidenceBuilder {
+	o.Record("AddIdentityTriple", o.ev.EvTriples.addIdentityTriple(val))
+
+	return o
+}
+
+// AddAttestKeyTriple is like [EvTriples.AddAttestKeyTriple]
+func (o *ConciseEvidenceBuilder) AddAttestKeyTriple(val *comid.KeyTriple) *ConciseEvidenceBuilder {
+	o.Record("AddAttestKeyTriple", o.ev.EvTriples.addAttestKeyTriple(val))
+
+	return o
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package coev
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/comid"
+)
+
+func testEvidenceTriple() *comid.ValueTriple {
+	return &comid.ValueTriple{
+		Environment: comid.Environment{
+			Instance: comid.MustNewUUIDInstance(TestUUID),
+		},
+		Measurements: *comid.NewMeasurements().Add(&comid.Measurement{
+			Val: comid.Mval{
+				RawValue: comid.NewRawValueFromBytes([]byte{0xde, 0xad, 0xbe, 0xef}),
+			},
+		}),
+	}
+}
+
+func TestConciseEvidenceBuilder_Build_OK(t *testing.T) {
+	ev, err := NewConciseEvidenceBuilder().
+		AddEvidenceTriple(testEvidenceTriple()).
+		AddEvidenceID(MustNewUUIDEvidenceID(TestUUID)).
+		AddProfile(TestProfile).
+		Build()
+	require.NoError(t, err)
+
+	require.NotNil(t, ev.EvTriples.EvidenceTriples)
+	assert.Len(t, ev.EvTriples.EvidenceTriples.Values, 1)
+	assert.NotNil(t, ev.EvidenceID)
+	assert.NotNil(t, ev.Profile)
+}
+
+func TestConciseEvidenceBuilder_Build_accumulates_errors(t *testing.T) {
+	_, err := NewConciseEvidenceBuilder().
+		AddEvidenceTriple(nil).
+		AddProfile("not").
+		AddEvidenceTriple(testEvidenceTriple()).
+		AddIdentityTriple(nil).
+		Build()
+	require.Error(t, err)
+
+	assert.ErrorContains(t, err, "AddEvidenceTriple (call 1): nil evidence triple")
+	assert.ErrorContains(t, err, "AddProfile (call 2): profile string must be an absolute URL or an ASN.1 OID")
+	assert.ErrorContains(t, err, "AddIdentityTriple (call 4): nil identity triple")
+	assert.NotContains(t, err.Error(), "call 3")
+}
+
+func TestConciseEvidenceBuilder_Build_no_triples(t *testing.T) {
+	_, err := NewConciseEvidenceBuilder().
+		AddProfile(TestProfile).
+		Build()
+	assert.EqualError(t, err, "invalid concise evidence: invalid EvTriples: no Triples set inside EvTriples")
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/veraison/corim/comid"
 	"github.com/veraison/corim/encoding"
 	"github.com/veraison/corim/extensions"
 	"github.com/veraison/eat"
@@ -78,6 +79,21 @@ func (o ConciseEvidence) Valid() error {
 	return nil
 }
 
+// ValidateAll is like Valid, except that, rather than stopping at the first
+// violation, it returns all of them as a comid.ValidationErrors.
+// nolint:gocritic
+func (o ConciseEvidence) ValidateAll() error {
+	v := comid.NewValidator()
+
+	v.Field("ev-triples", 0).Check(o.EvTriples.ValidateAll())
+
+	if o.EvidenceID != nil {
+		v.Field("evidence-id", 1).Check(o.EvidenceID.Valid())
+	}
+
+	return v.Err()
+}
+
 // RegisterExtensions registers a struct as a collections of extensions
 func (o *ConciseEvidence) RegisterExtensions(exts extensions.Map) error {
 	evTriplesMap := extensions.NewMap()
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/comid"
 	"github.com/veraison/corim/extensions"
 )
 
@@ -107,3 +108,22 @@ func Test_ConciseEvidence_RegisterExtensions_NOK(t *testing.T) {
 	err := c.RegisterExtensions(extMap)
 	assert.EqualError(t, err, expectedErr)
 }
+
+func TestConciseEvidence_ValidateAll(t *testing.T) {
+	tv := NewConciseEvidence()
+	tv.EvTriples.AddEvidenceTriple(&comid.ValueTriple{
+		Environment: comid.Environment{
+			Instance: comid.MustNewUUIDInstance(TestUUID),
+		},
+		Measurements: *comid.NewMeasurements().Add(&comid.Measurement{}),
+	})
+	tv.EvidenceID = &EvidenceID{}
+
+	err := tv.ValidateAll()
+	assert.EqualError(t, err,
+		"/ev-triples/evidence-triples/0/measurements/0/value: no measurement value set\n"+
+			"/evidence-id: no EvidenceID")
+
+	tv = NewConciseEvidence()
+	assert.EqualError(t, tv.ValidateAll(), "/ev-triples: no Triples set inside EvTriples")
+}
@@ -66,49 +66,131 @@ func (o EvTriples) Valid() error {
 	return nil
 }
 
-func (o *EvTriples) AddEvidenceTriple(val *comid.ValueTriple) *EvTriples {
-	if o != nil {
-		if o.EvidenceTriples == nil {
-			o.EvidenceTriples = comid.NewValueTriples()
+// ValidateAll is like Valid, except that, rather than stopping at the first
+// violation, it returns all of them as a comid.ValidationErrors.
+func (o EvTriples) ValidateAll() error {
+	v := comid.NewValidator()
+
+	if o.EvidenceTriples == nil &&
+		o.IdentityTriples == nil &&
+		o.CoSWIDTriples == nil &&
+		o.AttestKeysTriples == nil {
+		v.Check(errors.New("no Triples set inside EvTriples"))
+	}
+
+	if o.EvidenceTriples != nil {
+		tv := v.Field("evidence-triples", 0)
+		for i, t := range o.EvidenceTriples.Values {
+			tv.Index(i).Check(t.ValidateAll())
+		}
+	}
+
+	if o.IdentityTriples != nil {
+		tv := v.Field("identity-triples", 1)
+		for i := range *o.IdentityTriples {
+			tv.Index(i).Check((*o.IdentityTriples)[i].ValidateAll())
+		}
+	}
+
+	if o.CoSWIDTriples != nil {
+		tv := v.Field("coswid-triples", 4)
+		for i, t := range *o.CoSWIDTriples {
+			tv.Index(i).Check(t.Valid())
+		}
+	}
+
+	if o.AttestKeysTriples != nil {
+		tv := v.Field("attestkey-triples", 5)
+		for i := range *o.AttestKeysTriples {
+			tv.Index(i).Check((*o.AttestKeysTriples)[i].ValidateAll())
 		}
-		o.EvidenceTriples.Add(val)
+	}
+
+	return v.Err()
+}
+
+func (o *EvTriples) AddEvidenceTriple(val *comid.ValueTriple) *EvTriples {
+	if o != nil && o.addEvidenceTriple(val) != nil {
+		return nil
 	}
 
 	return o
 }
 
+// unlike the other add*Triple helpers, addEvidenceTriple ignores a nil val, as
+// AddEvidenceTriple always has. The content of the triples is checked by Valid
+func (o *EvTriples) addEvidenceTriple(val *comid.ValueTriple) error {
+	if o.EvidenceTriples == nil {
+		o.EvidenceTriples = comid.NewValueTriples()
+	}
+	o.EvidenceTriples.Add(val)
+
+	return nil
+}
+
 func (o *EvTriples) AddCoSWIDTriple(val *CoSWIDTriple) *EvTriples {
-	if o != nil {
-		if o.CoSWIDTriples == nil {
-			o.CoSWIDTriples = NewCoSWIDTriples()
-		}
-		*o.CoSWIDTriples = append(*o.CoSWIDTriples, *val)
+	if o != nil && o.addCoSWIDTriple(val) != nil {
+		return nil
 	}
+
 	return o
 }
 
+func (o *EvTriples) addCoSWIDTriple(val *CoSWIDTriple) error {
+	if val == nil {
+		return errors.New("nil CoSWID triple")
+	}
+
+	if o.CoSWIDTriples == nil {
+		o.CoSWIDTriples = NewCoSWIDTriples()
+	}
+	*o.CoSWIDTriples = append(*o.CoSWIDTriples, *val)
+
+	return nil
+}
+
 func (o *EvTriples) AddIdentityTriple(val *comid.KeyTriple) *EvTriples {
-	if o != nil {
-		if o.IdentityTriples == nil {
-			o.IdentityTriples = comid.NewKeyTriples()
-		}
-		*o.IdentityTriples = append(*o.IdentityTriples, *val)
+	if o != nil && o.addIdentityTriple(val) != nil {
+		return nil
 	}
 
 	return o
 }
 
+func (o *EvTriples) addIdentityTriple(val *comid.KeyTriple) error {
+	if val == nil {
+		return errors.New("nil identity triple")
+	}
+
+	if o.IdentityTriples == nil {
+		o.IdentityTriples = comid.NewKeyTriples()
+	}
+	*o.IdentityTriples = append(*o.IdentityTriples, *val)
+
+	return nil
+}
+
 func (o *EvTriples) AddAttestKeyTriple(val *comid.KeyTriple) *EvTriples {
-	if o != nil {
-		if o.AttestKeysTriples == nil {
-			o.AttestKeysTriples = comid.NewKeyTriples()
-		}
-		*o.AttestKeysTriples = append(*o.AttestKeysTriples, *val)
+	if o != nil && o.addAttestKeyTriple(val) != nil {
+		return nil
 	}
 
 	return o
 }
 
+func (o *EvTriples) addAttestKeyTriple(val *comid.KeyTriple) error {
+	if val == nil {
+		return errors.New("nil attest key triple")
+	}
+
+	if o.AttestKeysTriples == nil {
+		o.AttestKeysTriples = comid.NewKeyTriples()
+	}
+	*o.AttestKeysTriples = append(*o.AttestKeysTriples, *val)
+
+	return nil
+}
+
 func (o *EvTriples) RegisterExtensions(exts extensions.Map) error {
 	EvidenceTriplesExts := extensions.NewMap()
 	for p, v := range exts {
@@ -0,0 +1,264 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package coev
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/veraison/corim/corim"
+	"github.com/veraison/corim/encoding"
+	"github.com/veraison/corim/extensions"
+	cose "github.com/veraison/go-cose"
+)
+
+// EvidenceContentType is the media type of the payload of a
+// SignedConciseEvidence (a tagged-concise-evidence), carried in the content
+// type protected header
+var EvidenceContentType = "application/ce+cbor"
+
+var errNoSign1Message = errors.New("no Sign1 message found")
+
+// SignedConciseEvidence encodes a COSE Sign1 wrapped tagged-concise-evidence,
+// with signature and verification methods. The protected header carries the
+// evidence content type and, optionally, a kid and an x5chain, which can be
+// validated against the same trust anchors used for signed CoRIMs (see
+// [SignedConciseEvidence.VerifyWithX5Chain]).
+type SignedConciseEvidence struct {
+	ConciseEvidence   ConciseEvidence
+	KeyID             []byte
+	SigningCert       *x509.Certificate
+	IntermediateCerts []*x509.Certificate
+	message           *cose.Sign1Message
+}
+
+// NewSignedConciseEvidence instantiates an empty SignedConciseEvidence
+func NewSignedConciseEvidence() *SignedConciseEvidence {
+	return &SignedConciseEvidence{}
+}
+
+// RegisterExtensions registers a struct as a collections of extensions of the
+// signed ConciseEvidence
+func (o *SignedConciseEvidence) RegisterExtensions(exts extensions.Map) error {
+	return o.ConciseEvidence.RegisterExtensions(exts)
+}
+
+// AddSigningCert adds a DER-encoded X.509 certificate to be included in the
+// protected header of the COSE Sign1 message as the leaf certificate in
+// X5Chain.
+func (o *SignedConciseEvidence) AddSigningCert(der []byte) error {
+	if der == nil {
+		return errors.New("nil signing cert")
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return fmt.Errorf("invalid signing certificate: %w", err)
+	}
+
+	o.SigningCert = cert
+	return nil
+}
+
+// AddIntermediateCerts adds DER-encoded X.509 certificates to be included in
+// the protected header of the COSE Sign1 message as part of the X5Chain. The
+// certificates must be concatenated with no intermediate padding, as per X.509
+// convention.
+func (o *SignedConciseEvidence) AddIntermediateCerts(der []byte) error {
+	if len(der) == 0 {
+		return errors.New("nil or empty intermediate certs")
+	}
+
+	certs, err := x509.ParseCertificates(der)
+	if err != nil {
+		return fmt.Errorf("invalid intermediate certificates: %w", err)
+	}
+
+	if len(certs) == 0 {
+		return errors.New("no certificates found in intermediate cert data")
+	}
+
+	o.IntermediateCerts = certs
+	return nil
+}
+
+// Sign returns the serialized signed concise evidence, signed by the supplied
+// cose Signer. The target SignedConciseEvidence must have its ConciseEvidence
+// field correctly populated.
+func (o *SignedConciseEvidence) Sign(signer cose.Signer) ([]byte, error) {
+	if signer == nil {
+		return nil, errors.New("nil signer")
+	}
+
+	tce, err := NewTaggedConciseEvidence(&o.ConciseEvidence)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := tce.ToCBOR()
+	if err != nil {
+		return nil, fmt.Errorf("failed CBOR encoding of concise evidence: %w", err)
+	}
+
+	alg := signer.Algorithm()
+
+	if strings.Contains(alg.String(), "unknown algorithm value") {
+		return nil, errors.New("signer has no algorithm")
+	}
+
+	message := cose.NewSign1Message()
+	message.Payload = payload
+	message.Headers.Protected.SetAlgorithm(alg)
+	message.Headers.Protected[cose.HeaderLabelContentType] = EvidenceContentType
+
+	if o.KeyID != nil {
+		message.Headers.Protected[cose.HeaderLabelKeyID] = o.KeyID
+	}
+
+	if o.SigningCert != nil {
+		message.Headers.Protected[cose.HeaderLabelX5Chain] =
+			corim.EncodeX5ChainHeader(o.SigningCert, o.IntermediateCerts)
+	} else if o.IntermediateCerts != nil {
+		return nil, errors.New("intermediate certificates supplied but no signing certificate")
+	}
+
+	if err := message.Sign(rand.Reader, corim.NoExternalData, signer); err != nil {
+		return nil, fmt.Errorf("COSE Sign1 signature failed: %w", err)
+	}
+
+	o.message = message
+
+	wrap, err := message.MarshalCBOR()
+	if err != nil {
+		return nil, fmt.Errorf("signed concise evidence marshaling failed: %w", err)
+	}
+
+	return wrap, nil
+}
+
+// FromCOSE decodes and effects syntactic validation on the supplied signed
+// concise evidence message, including the embedded concise evidence, which is
+// made available via the ConciseEvidence field.
+func (o *SignedConciseEvidence) FromCOSE(buf []byte) error {
+	o.message = nil
+	o.KeyID = nil
+	o.SigningCert = nil
+	o.IntermediateCerts = nil
+
+	message := cose.NewSign1Message()
+
+	if err := message.UnmarshalCBOR(buf); err != nil {
+		return fmt.Errorf("failed CBOR decoding for COSE-Sign1 signed concise evidence: %w", err)
+	}
+
+	if err := o.processHdrs(message.Headers); err != nil {
+		o.KeyID = nil
+		o.SigningCert = nil
+		o.IntermediateCerts = nil
+		return fmt.Errorf("processing COSE headers: %w", err)
+	}
+
+	payload, found := bytes.CutPrefix(message.Payload, ConciseEvidenceTag)
+	if !found {
+		return errors.New("did not see concise evidence tag in the payload")
+	}
+
+	if err := encoding.PopulateStructFromCBOR(dm, payload, &o.ConciseEvidence); err != nil {
+		return fmt.Errorf("failed CBOR decoding of concise evidence: %w", err)
+	}
+
+	if err := o.ConciseEvidence.Valid(); err != nil {
+		return fmt.Errorf("failed validation of concise evidence: %w", err)
+	}
+
+	o.message = message
+
+	return nil
+}
+
+func (o *SignedConciseEvidence) processHdrs(hdr cose.Headers) error {
+	if hdr.Protected == nil {
+		return errors.New("missing mandatory protected header")
+	}
+
+	if v, ok := hdr.Protected[cose.HeaderLabelContentType]; ok {
+		if v != EvidenceContentType {
+			return fmt.Errorf("expecting content type %q, got %q instead", EvidenceContentType, v)
+		}
+	} else {
+		return errors.New("missing mandatory content type")
+	}
+
+	if v, ok := hdr.Protected[cose.HeaderLabelKeyID]; ok {
+		switch t := v.(type) {
+		case []byte:
+			o.KeyID = t
+		default:
+			return fmt.Errorf("kid: expected a []byte but got %v (%T)", t, t)
+		}
+	}
+
+	if v, ok := hdr.Protected[cose.HeaderLabelX5Chain]; ok {
+		leaf, intermediates, err := corim.DecodeX5ChainHeader(v)
+		if err != nil {
+			return err
+		}
+
+		o.SigningCert = leaf
+		o.IntermediateCerts = intermediates
+	}
+
+	return nil
+}
+
+// Verify verifies the signature of the target SignedConciseEvidence object
+// using the supplied public key
+func (o *SignedConciseEvidence) Verify(pk crypto.PublicKey) error {
+	if o.message == nil {
+		return errNoSign1Message
+	}
+
+	alg, err := o.message.Headers.Protected.Algorithm()
+	if err != nil {
+		return fmt.Errorf("unable to get verification algorithm: %w", err)
+	}
+
+	verifier, err := cose.NewVerifier(alg, pk)
+	if err != nil {
+		return fmt.Errorf("unable to instantiate verifier: %w", err)
+	}
+
+	return o.message.Verify(corim.NoExternalData, verifier)
+}
+
+// VerifyWithX5Chain validates the embedded x5chain against the supplied trust
+// anchors, with the same PKIX and revocation policies used by
+// [corim.SignedCorim.VerifyWithX5Chain], and then verifies the COSE signature
+// with the key of the signing certificate. Call [SignedConciseEvidence.FromCOSE]
+// first.
+func (o *SignedConciseEvidence) VerifyWithX5Chain(anchors corim.TrustAnchors) error {
+	if o.message == nil {
+		return errNoSign1Message
+	}
+
+	if o.SigningCert == nil {
+		return errors.New("x5chain: header not set in signed concise evidence")
+	}
+
+	pk, err := corim.VerifyX5Chain(o.SigningCert, o.IntermediateCerts, anchors, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := o.Verify(pk); err != nil {
+		return fmt.Errorf("x5chain: COSE signature verification failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package coev
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/corim"
+	"github.com/veraison/corim/testdata"
+	cose "github.com/veraison/go-cose"
+)
+
+func testSignedConciseEvidence(t *testing.T) *SignedConciseEvidence {
+	ev, err := NewConciseEvidenceBuilder().
+		AddEvidenceTriple(testEvidenceTriple()).
+		AddProfile(TestProfile).
+		Build()
+	require.NoError(t, err)
+
+	return &SignedConciseEvidence{ConciseEvidence: *ev}
+}
+
+func testEndEntitySigner(t *testing.T) cose.Signer {
+	signer, err := corim.NewSignerFromPEM(testdata.EndEntityKey, cose.AlgorithmES256)
+	require.NoError(t, err)
+
+	return signer
+}
+
+func testTrustAnchors(t *testing.T) corim.TrustAnchors {
+	root, err := x509.ParseCertificate(testdata.RootCA)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(root)
+
+	return corim.TrustAnchors{Pool: pool}
+}
+
+func TestSignedConciseEvidence_SignVerify_ok(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := cose.NewSigner(cose.AlgorithmES256, key)
+	require.NoError(t, err)
+
+	in := testSignedConciseEvidence(t)
+	in.KeyID = []byte("key-1")
+
+	data, err := in.Sign(signer)
+	require.NoError(t, err)
+
+	var out SignedConciseEvidence
+	require.NoError(t, out.FromCOSE(data))
+
+	assert.Equal(t, []byte("key-1"), out.KeyID)
+	assert.Nil(t, out.SigningCert)
+	assert.Equal(t, in.ConciseEvidence.Profile, out.ConciseEvidence.Profile)
+	assert.NoError(t, out.Verify(&key.PublicKey))
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	assert.Error(t, out.Verify(&other.PublicKey))
+
+	assert.EqualError(t, out.VerifyWithX5Chain(testTrustAnchors(t)),
+		"x5chain: header not set in signed concise evidence")
+}
+
+func TestSignedConciseEvidence_VerifyWithX5Chain_ok(t *testing.T) {
+	in := testSignedConciseEvidence(t)
+	require.NoError(t, in.AddSigningCert(testdata.EndEntityDer))
+	require.NoError(t, in.AddIntermediateCerts(testdata.IntermediateCA))
+
+	data, err := in.Sign(testEndEntitySigner(t))
+	require.NoError(t, err)
+
+	var out SignedConciseEvidence
+	require.NoError(t, out.FromCOSE(data))
+
+	require.NotNil(t, out.SigningCert)
+	assert.Equal(t, testdata.EndEntityDer, out.SigningCert.Raw)
+	require.Len(t, out.IntermediateCerts, 1)
+
+	assert.NoError(t, out.VerifyWithX5Chain(testTrustAnchors(t)))
+
+	// the anchors are checked with the same policy as for signed CoRIMs
+	assert.ErrorContains(t, out.VerifyWithX5Chain(corim.TrustAnchors{Pool: x509.NewCertPool()}),
+		"x5chain verification failed")
+}
+
+func TestSignedConciseEvidence_VerifyWithX5Chain_tampered(t *testing.T) {
+	in := testSignedConciseEvidence(t)
+	require.NoError(t, in.AddSigningCert(testdata.EndEntityDer))
+	require.NoError(t, in.AddIntermediateCerts(testdata.IntermediateCA))
+
+	data, err := in.Sign(testEndEntitySigner(t))
+	require.NoError(t, err)
+
+	var out SignedConciseEvidence
+	require.NoError(t, out.FromCOSE(data))
+
+	// sign with a key that does not match the signing certificate
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signer, err := cose.NewSigner(cose.AlgorithmES256, key)
+	require.NoError(t, err)
+
+	data, err = in.Sign(signer)
+	require.NoError(t, err)
+	require.NoError(t, out.FromCOSE(data))
+
+	assert.ErrorContains(t, out.VerifyWithX5Chain(testTrustAnchors(t)),
+		"x5chain: COSE signature verification failed")
+}
+
+func TestSignedConciseEvidence_Sign_NOK(t *testing.T) {
+	in := testSignedConciseEvidence(t)
+
+	_, err := in.Sign(nil)
+	assert.EqualError(t, err, "nil signer")
+
+	require.NoError(t, in.AddIntermediateCerts(testdata.IntermediateCA))
+	_, err = in.Sign(testEndEntitySigner(t))
+	assert.EqualError(t, err, "intermediate certificates supplied but no signing certificate")
+
+	_, err = NewSignedConciseEvidence().Sign(testEndEntitySigner(t))
+	assert.ErrorContains(t, err, "concise Evidence is not valid: ")
+}
+
+func TestSignedConciseEvidence_FromCOSE_NOK(t *testing.T) {
+	signer := testEndEntitySigner(t)
+
+	var out SignedConciseEvidence
+
+	assert.ErrorContains(t, out.FromCOSE([]byte{0x00}),
+		"failed CBOR decoding for COSE-Sign1 signed concise evidence")
+
+	sign := func(hdr cose.ProtectedHeader, payload []byte) []byte {
+		msg := cose.NewSign1Message()
+		msg.Headers.Protected = hdr
+		msg.Headers.Protected.SetAlgorithm(cose.AlgorithmES256)
+		msg.Payload = payload
+		require.NoError(t, msg.Sign(rand.Reader, nil, signer))
+
+		data, err := msg.MarshalCBOR()
+		require.NoError(t, err)
+
+		return data
+	}
+
+	tce, err := NewTaggedConciseEvidence(&testSignedConciseEvidence(t).ConciseEvidence)
+	require.NoError(t, err)
+	payload, err := tce.ToCBOR()
+	require.NoError(t, err)
+
+	err = out.FromCOSE(sign(cose.ProtectedHeader{}, payload))
+	assert.EqualError(t, err, "processing COSE headers: missing mandatory content type")
+
+	err = out.FromCOSE(sign(cose.ProtectedHeader{
+		cose.HeaderLabelContentType: "application/rim+cbor",
+	}, payload))
+	assert.EqualError(t, err, `processing COSE headers: expecting content type "application/ce+cbor", `+
+		`got "application/rim+cbor" instead`)
+
+	err = out.FromCOSE(sign(cose.ProtectedHeader{
+		cose.HeaderLabelContentType: EvidenceContentType,
+	}, payload[3:]))
+	assert.EqualError(t, err, "did not see concise evidence tag in the payload")
+
+	assert.Equal(t, errNoSign1Message, out.Verify(nil))
+}
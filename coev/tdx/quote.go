@@ -0,0 +1,389 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package tdx
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Constants from the Intel TDX DCAP Quote Generation Library and Quote
+// Verification Library specification
+const (
+	QuoteVersion4 = 4
+	QuoteVersion5 = 5
+
+	// AttestationKeyTypeECDSAP256 is the only attestation key type defined
+	// for TDX quotes
+	AttestationKeyTypeECDSAP256 = 2
+
+	// TEETypeTDX identifies a quote generated for a TD (as opposed to an SGX
+	// enclave)
+	TEETypeTDX = 0x00000081
+
+	// TD report body types, as found in the body descriptor of v5 quotes
+	TDReportBodyV10 = 2
+	TDReportBodyV15 = 3
+
+	// Certification data types
+	CertDataTypePCKCertChain = 5
+	CertDataTypeQEReportCert = 6
+
+	tdReportBodyV10Size = 584
+	tdReportBodyV15Size = 648
+	qeReportSize        = 384
+	ecdsaSignatureSize  = 64
+)
+
+// QuoteHeader is the header of a TDX quote
+type QuoteHeader struct {
+	Version            uint16
+	AttestationKeyType uint16
+	TEEType            uint32
+	// QESVN and PCESVN are reserved in v4 and v5 quotes
+	QESVN      uint16
+	PCESVN     uint16
+	QEVendorID [16]byte
+	UserData   [20]byte
+}
+
+// TDReportBody is the TD report body of a TDX quote. TEETCBSVN2 and
+// MRSERVICETD are only present in TD report 1.5 bodies (v5 quotes only).
+type TDReportBody struct {
+	TEETCBSVN      [16]byte
+	MRSEAM         [48]byte
+	MRSIGNERSEAM   [48]byte
+	SEAMAttributes [8]byte
+	TDAttributes   [8]byte
+	XFAM           [8]byte
+	MRTD           [48]byte
+	MRCONFIGID     [48]byte
+	MROWNER        [48]byte
+	MROWNERCONFIG  [48]byte
+	RTMRs          [4][48]byte
+	ReportData     [64]byte
+	TEETCBSVN2     [16]byte
+	MRSERVICETD    [48]byte
+}
+
+// QEReport is the SGX report body of the Quoting Enclave that signed the
+// attestation key
+type QEReport struct {
+	CPUSVN     [16]byte
+	MiscSelect [4]byte
+	Attributes [16]byte
+	MRENCLAVE  [32]byte
+	MRSIGNER   [32]byte
+	ISVProdID  uint16
+	ISVSVN     uint16
+	ReportData [64]byte
+}
+
+// Quote is a parsed TDX quote. QEReport, QEReportSignature and QEAuthData are
+// only set if the certification data is of type CertDataTypeQEReportCert, in
+// which case CertDataType and CertData describe the certification data nested
+// inside it (usually, the PCK certificate chain).
+type Quote struct {
+	Header            QuoteHeader
+	BodyType          uint16
+	Body              TDReportBody
+	Signature         [64]byte
+	AttestationKey    [64]byte
+	QEReport          *QEReport
+	QEReportSignature []byte
+	QEAuthData        []byte
+	CertDataType      uint16
+	CertData          []byte
+}
+
+// quoteReader reads little-endian fields from a quote, keeping track of the
+// offset for error reporting
+type quoteReader struct {
+	data []byte
+	off  int
+}
+
+func (o *quoteReader) next(n int, what string) ([]byte, error) {
+	if n < 0 || len(o.data)-o.off < n {
+		return nil, fmt.Errorf("%s at offset %d: need %d bytes, %d left", what, o.off, n, len(o.data)-o.off)
+	}
+
+	ret := o.data[o.off : o.off+n]
+	o.off += n
+
+	return ret, nil
+}
+
+func (o *quoteReader) copy(dst []byte, what string) error {
+	b, err := o.next(len(dst), what)
+	if err != nil {
+		return err
+	}
+
+	copy(dst, b)
+
+	return nil
+}
+
+func (o *quoteReader) uint16(what string) (uint16, error) {
+	b, err := o.next(2, what)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint16(b), nil
+}
+
+func (o *quoteReader) uint32(what string) (uint32, error) {
+	b, err := o.next(4, what)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+// ParseQuote parses a binary v4 or v5 TDX quote. The signatures are not
+// verified.
+func ParseQuote(data []byte) (*Quote, error) {
+	var (
+		q   Quote
+		err error
+	)
+
+	r := &quoteReader{data: data}
+
+	if err = q.parseHeader(r); err != nil {
+		return nil, err
+	}
+
+	bodySize := tdReportBodyV10Size
+	q.BodyType = TDReportBodyV10
+
+	if q.Header.Version == QuoteVersion5 {
+		if bodySize, err = q.parseBodyDescriptor(r); err != nil {
+			return nil, err
+		}
+	}
+
+	body, err := r.next(bodySize, "TD report body")
+	if err != nil {
+		return nil, err
+	}
+
+	q.Body.parse(body)
+
+	sigDataLen, err := r.uint32("quote signature data length")
+	if err != nil {
+		return nil, err
+	}
+
+	sigData, err := r.next(int(sigDataLen), "quote signature data")
+	if err != nil {
+		return nil, err
+	}
+
+	if r.off != len(data) {
+		return nil, fmt.Errorf("%d trailing bytes after the quote signature data", len(data)-r.off)
+	}
+
+	if err = q.parseSignatureData(&quoteReader{data: sigData}); err != nil {
+		return nil, fmt.Errorf("quote signature data: %w", err)
+	}
+
+	return &q, nil
+}
+
+func (o *Quote) parseHeader(r *quoteReader) error {
+	h := &o.Header
+
+	var err error
+
+	if h.Version, err = r.uint16("version"); err != nil {
+		return err
+	}
+
+	if h.Version != QuoteVersion4 && h.Version != QuoteVersion5 {
+		return fmt.Errorf("unsupported quote version %d", h.Version)
+	}
+
+	if h.AttestationKeyType, err = r.uint16("attestation key type"); err != nil {
+		return err
+	}
+
+	if h.AttestationKeyType != AttestationKeyTypeECDSAP256 {
+		return fmt.Errorf("unsupported attestation key type %d", h.AttestationKeyType)
+	}
+
+	if h.TEEType, err = r.uint32("TEE type"); err != nil {
+		return err
+	}
+
+	if h.TEEType != TEETypeTDX {
+		return fmt.Errorf("unexpected TEE type 0x%x, expected 0x%x (TDX)", h.TEEType, TEETypeTDX)
+	}
+
+	if h.QESVN, err = r.uint16("QE SVN"); err != nil {
+		return err
+	}
+
+	if h.PCESVN, err = r.uint16("PCE SVN"); err != nil {
+		return err
+	}
+
+	if err = r.copy(h.QEVendorID[:], "QE vendor ID"); err != nil {
+		return err
+	}
+
+	return r.copy(h.UserData[:], "user data")
+}
+
+func (o *Quote) parseBodyDescriptor(r *quoteReader) (int, error) {
+	typ, err := r.uint16("body type")
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := r.uint32("body size")
+	if err != nil {
+		return 0, err
+	}
+
+	var expected int
+
+	switch typ {
+	case TDReportBodyV10:
+		expected = tdReportBodyV10Size
+	case TDReportBodyV15:
+		expected = tdReportBodyV15Size
+	default:
+		return 0, fmt.Errorf("unsupported body type %d", typ)
+	}
+
+	if int(size) != expected {
+		return 0, fmt.Errorf("body type %d: expected size %d, got %d", typ, expected, size)
+	}
+
+	o.BodyType = typ
+
+	return expected, nil
+}
+
+// parse fills the target TDReportBody from a 584 (TD report 1.0) or 648 (TD
+// report 1.5) bytes long body
+func (o *TDReportBody) parse(b []byte) {
+	r := &quoteReader{data: b}
+
+	// the length has been checked by the caller, so none of these can fail
+	for _, f := range [][]byte{
+		o.TEETCBSVN[:], o.MRSEAM[:], o.MRSIGNERSEAM[:], o.SEAMAttributes[:],
+		o.TDAttributes[:], o.XFAM[:], o.MRTD[:], o.MRCONFIGID[:], o.MROWNER[:],
+		o.MROWNERCONFIG[:], o.RTMRs[0][:], o.RTMRs[1][:], o.RTMRs[2][:],
+		o.RTMRs[3][:], o.ReportData[:],
+	} {
+		_ = r.copy(f, "TD report body")
+	}
+
+	if len(b) == tdReportBodyV15Size {
+		_ = r.copy(o.TEETCBSVN2[:], "TD report body")
+		_ = r.copy(o.MRSERVICETD[:], "TD report body")
+	}
+}
+
+func (o *Quote) parseSignatureData(r *quoteReader) error {
+	if err := r.copy(o.Signature[:], "ECDSA signature"); err != nil {
+		return err
+	}
+
+	if err := r.copy(o.AttestationKey[:], "ECDSA attestation key"); err != nil {
+		return err
+	}
+
+	typ, data, err := parseCertData(r)
+	if err != nil {
+		return err
+	}
+
+	if r.off != len(r.data) {
+		return fmt.Errorf("%d trailing bytes after the certification data", len(r.data)-r.off)
+	}
+
+	if typ != CertDataTypeQEReportCert {
+		o.CertDataType, o.CertData = typ, data
+		return nil
+	}
+
+	if err := o.parseQEReportCertData(&quoteReader{data: data}); err != nil {
+		return fmt.Errorf("QE report certification data: %w", err)
+	}
+
+	return nil
+}
+
+func parseCertData(r *quoteReader) (uint16, []byte, error) {
+	typ, err := r.uint16("certification data type")
+	if err != nil {
+		return 0, nil, err
+	}
+
+	size, err := r.uint32("certification data size")
+	if err != nil {
+		return 0, nil, err
+	}
+
+	data, err := r.next(int(size), "certification data")
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return typ, data, nil
+}
+
+func (o *Quote) parseQEReportCertData(r *quoteReader) error {
+	report, err := r.next(qeReportSize, "QE report")
+	if err != nil {
+		return err
+	}
+
+	o.QEReport = &QEReport{}
+	o.QEReport.parse(report)
+
+	sig, err := r.next(ecdsaSignatureSize, "QE report signature")
+	if err != nil {
+		return err
+	}
+
+	o.QEReportSignature = sig
+
+	authLen, err := r.uint16("QE authentication data size")
+	if err != nil {
+		return err
+	}
+
+	if o.QEAuthData, err = r.next(int(authLen), "QE authentication data"); err != nil {
+		return err
+	}
+
+	if o.CertDataType, o.CertData, err = parseCertData(r); err != nil {
+		return err
+	}
+
+	if r.off != len(r.data) {
+		return fmt.Errorf("%d trailing bytes after the certification data", len(r.data)-r.off)
+	}
+
+	return nil
+}
+
+// parse fills the target QEReport from a 384 bytes long SGX report body
+func (o *QEReport) parse(b []byte) {
+	copy(o.CPUSVN[:], b[0:16])
+	copy(o.MiscSelect[:], b[16:20])
+	copy(o.Attributes[:], b[48:64])
+	copy(o.MRENCLAVE[:], b[64:96])
+	copy(o.MRSIGNER[:], b[128:160])
+	o.ISVProdID = binary.LittleEndian.Uint16(b[256:258])
+	o.ISVSVN = binary.LittleEndian.Uint16(b[258:260])
+	copy(o.ReportData[:], b[320:384])
+}
@@ -0,0 +1,302 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package tdx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/veraison/corim/coev"
+	"github.com/veraison/corim/comid"
+	"github.com/veraison/corim/extensions"
+	"github.com/veraison/corim/profiles/tdx"
+)
+
+// Vendor and models of the environments of the evidence triples produced by
+// Quote.ToConciseEvidence
+const (
+	QuoteVendor = "Intel Corporation"
+	SeamModel   = "TDX SEAM"
+	TDModel     = "TDX TD"
+	QEModel     = "TDX QE"
+
+	// XFAMKey is the key of the TD measurement carrying XFAM
+	XFAMKey = "xfam"
+	// TEETCBSVN2Key is the key of the TDX module measurement carrying
+	// TEE_TCB_SVN2, for TD report 1.5 bodies
+	TEETCBSVN2Key = "tee_tcb_svn2"
+	// MRSERVICETDKey is the key of the TD measurement carrying MRSERVICETD,
+	// for TD report 1.5 bodies
+	MRSERVICETDKey = "mrservicetd"
+)
+
+// ToConciseEvidence maps the target Quote into a ConciseEvidence using the TDX
+// profile (ProfileID), with one evidence triple for each of the following
+// environments:
+//
+//   - the TDX module (SeamModel): MRSEAM (mrtee), MRSIGNERSEAM (mrsigner),
+//     SEAMATTRIBUTES (attributes) and TEE_TCB_SVN (tcbcompsvn), plus, for TD
+//     report 1.5 bodies, TEE_TCB_SVN2 (tcbcompsvn) in a separate measurement
+//     with the TEETCBSVN2Key key
+//   - the TD (TDModel): MRTD (mrtee), TDATTRIBUTES (attributes) and the RTMRs
+//     (integrity registers 0 to 3), plus XFAM (attributes) in a separate
+//     measurement with the XFAMKey key and, for TD report 1.5 bodies,
+//     MRSERVICETD (mrtee) in another one with the MRSERVICETDKey key
+//   - the Quoting Enclave (QEModel), if the quote carries a QE report:
+//     MISCSELECT (miscselect), ATTRIBUTES (attributes), MRENCLAVE (mrtee),
+//     MRSIGNER (mrsigner), ISVPRODID (isvprodid) and ISVSVN (isvsvn)
+func (o Quote) ToConciseEvidence() (*coev.ConciseEvidence, error) {
+	manifest, found := coev.GetProfileManifest(ProfileID)
+	if !found {
+		return nil, errors.New("TDX evidence profile not registered")
+	}
+
+	ce := manifest.GetConciseEvidence()
+
+	if err := ce.AddProfile(ProfileID.String()); err != nil {
+		return nil, fmt.Errorf("setting profile: %w", err)
+	}
+
+	triples := []func() (*comid.ValueTriple, error){
+		o.seamTriple,
+		o.tdTriple,
+	}
+
+	if o.QEReport != nil {
+		triples = append(triples, o.qeTriple)
+	}
+
+	for _, triple := range triples {
+		vt, err := triple()
+		if err != nil {
+			return nil, err
+		}
+
+		ce.EvTriples.AddEvidenceTriple(vt)
+	}
+
+	if err := ce.Valid(); err != nil {
+		return nil, fmt.Errorf("invalid concise evidence: %w", err)
+	}
+
+	return ce, nil
+}
+
+func (o Quote) seamTriple() (*comid.ValueTriple, error) {
+	mrSeam, err := newTeeDigest(comid.Sha384, o.Body.MRSEAM[:])
+	if err != nil {
+		return nil, fmt.Errorf("MRSEAM: %w", err)
+	}
+
+	mrSignerSeam, err := newTeeDigest(comid.Sha384, o.Body.MRSIGNERSEAM[:])
+	if err != nil {
+		return nil, fmt.Errorf("MRSIGNERSEAM: %w", err)
+	}
+
+	attributes, err := tdx.NewTeeAttributes(clone(o.Body.SEAMAttributes[:]))
+	if err != nil {
+		return nil, fmt.Errorf("SEAMATTRIBUTES: %w", err)
+	}
+
+	tcbCompSvn, err := newTeeTcbCompSvn(o.Body.TEETCBSVN)
+	if err != nil {
+		return nil, fmt.Errorf("TEE_TCB_SVN: %w", err)
+	}
+
+	seam, err := newMeasurement(nil, &tdx.MValExtensions{
+		TeeMrTee:      mrSeam,
+		TeeMrSigner:   mrSignerSeam,
+		TeeAttributes: attributes,
+		TeeTCBCompSvn: tcbCompSvn,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("TDX module measurement: %w", err)
+	}
+
+	measurements := []*comid.Measurement{seam}
+
+	if o.BodyType == TDReportBodyV15 {
+		tcbCompSvn2, err := newTeeTcbCompSvn(o.Body.TEETCBSVN2)
+		if err != nil {
+			return nil, fmt.Errorf("TEE_TCB_SVN2: %w", err)
+		}
+
+		svn2Key, err := comid.NewMkeyString(TEETCBSVN2Key)
+		if err != nil {
+			return nil, fmt.Errorf("TEE_TCB_SVN2: %w", err)
+		}
+
+		svn2, err := newMeasurement(svn2Key, &tdx.MValExtensions{
+			TeeTCBCompSvn: tcbCompSvn2,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("TEE_TCB_SVN2: %w", err)
+		}
+
+		measurements = append(measurements, svn2)
+	}
+
+	return newEvidenceTriple(SeamModel, measurements...), nil
+}
+
+func (o Quote) tdTriple() (*comid.ValueTriple, error) {
+	mrTD, err := newTeeDigest(comid.Sha384, o.Body.MRTD[:])
+	if err != nil {
+		return nil, fmt.Errorf("MRTD: %w", err)
+	}
+
+	attributes, err := tdx.NewTeeAttributes(clone(o.Body.TDAttributes[:]))
+	if err != nil {
+		return nil, fmt.Errorf("TDATTRIBUTES: %w", err)
+	}
+
+	xfam, err := tdx.NewTeeAttributes(clone(o.Body.XFAM[:]))
+	if err != nil {
+		return nil, fmt.Errorf("XFAM: %w", err)
+	}
+
+	rtmrs := comid.NewIntegrityRegisters()
+	for i, rtmr := range o.Body.RTMRs {
+		digests := comid.NewDigests().AddDigest(comid.Sha384, clone(rtmr[:]))
+		if err := rtmrs.AddDigests(uint(i), *digests); err != nil {
+			return nil, fmt.Errorf("RTMR%d: %w", i, err)
+		}
+	}
+
+	td, err := newMeasurement(nil, &tdx.MValExtensions{
+		TeeMrTee:      mrTD,
+		TeeAttributes: attributes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("TD measurement: %w", err)
+	}
+	td.Val.IntegrityRegisters = rtmrs
+
+	xfamKey, err := comid.NewMkeyString(XFAMKey)
+	if err != nil {
+		return nil, fmt.Errorf("XFAM: %w", err)
+	}
+
+	xfamMeasurement, err := newMeasurement(xfamKey, &tdx.MValExtensions{
+		TeeAttributes: xfam,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("XFAM: %w", err)
+	}
+
+	measurements := []*comid.Measurement{td, xfamMeasurement}
+
+	if o.BodyType == TDReportBodyV15 {
+		mrServiceTD, err := newTeeDigest(comid.Sha384, o.Body.MRSERVICETD[:])
+		if err != nil {
+			return nil, fmt.Errorf("MRSERVICETD: %w", err)
+		}
+
+		mrServiceTDKey, err := comid.NewMkeyString(MRSERVICETDKey)
+		if err != nil {
+			return nil, fmt.Errorf("MRSERVICETD: %w", err)
+		}
+
+		serviceTD, err := newMeasurement(mrServiceTDKey, &tdx.MValExtensions{
+			TeeMrTee: mrServiceTD,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("MRSERVICETD: %w", err)
+		}
+
+		measurements = append(measurements, serviceTD)
+	}
+
+	return newEvidenceTriple(TDModel, measurements...), nil
+}
+
+func (o Quote) qeTriple() (*comid.ValueTriple, error) {
+	qe := o.QEReport
+
+	miscSelect, err := tdx.NewTeeMiscSelect(clone(qe.MiscSelect[:]))
+	if err != nil {
+		return nil, fmt.Errorf("QE MISCSELECT: %w", err)
+	}
+
+	attributes, err := tdx.NewTeeAttributes(clone(qe.Attributes[:]))
+	if err != nil {
+		return nil, fmt.Errorf("QE ATTRIBUTES: %w", err)
+	}
+
+	mrEnclave, err := newTeeDigest(comid.Sha256, qe.MRENCLAVE[:])
+	if err != nil {
+		return nil, fmt.Errorf("QE MRENCLAVE: %w", err)
+	}
+
+	mrSigner, err := newTeeDigest(comid.Sha256, qe.MRSIGNER[:])
+	if err != nil {
+		return nil, fmt.Errorf("QE MRSIGNER: %w", err)
+	}
+
+	isvProdID, err := tdx.NewTeeISVProdID(uint(qe.ISVProdID))
+	if err != nil {
+		return nil, fmt.Errorf("QE ISVPRODID: %w", err)
+	}
+
+	isvSVN, err := tdx.NewSvnUint(uint(qe.ISVSVN))
+	if err != nil {
+		return nil, fmt.Errorf("QE ISVSVN: %w", err)
+	}
+
+	m, err := newMeasurement(nil, &tdx.MValExtensions{
+		TeeMiscSelect: miscSelect,
+		TeeAttributes: attributes,
+		TeeMrTee:      mrEnclave,
+		TeeMrSigner:   mrSigner,
+		TeeISVProdID:  isvProdID,
+		TeeISVSVN:     isvSVN,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("QE measurement: %w", err)
+	}
+
+	return newEvidenceTriple(QEModel, m), nil
+}
+
+func newTeeDigest(alg int, value []byte) (*tdx.TeeDigest, error) {
+	return tdx.NewTeeDigest(*comid.NewDigests().AddDigest(alg, clone(value)))
+}
+
+func newTeeTcbCompSvn(svn [16]byte) (*tdx.TeeTcbCompSvn, error) {
+	svns := make([]uint, len(svn))
+	for i, v := range svn {
+		svns[i] = uint(v)
+	}
+
+	return tdx.NewTeeTcbCompSvnUint(svns)
+}
+
+func newMeasurement(key *comid.Mkey, exts *tdx.MValExtensions) (*comid.Measurement, error) {
+	m := &comid.Measurement{Key: key}
+
+	// registering a populated extensions struct sets its values
+	if err := m.RegisterExtensions(extensions.NewMap().Add(comid.ExtMval, exts)); err != nil {
+		return nil, fmt.Errorf("registering extensions: %w", err)
+	}
+
+	return m, nil
+}
+
+func newEvidenceTriple(model string, measurements ...*comid.Measurement) *comid.ValueTriple {
+	vt := &comid.ValueTriple{
+		Environment: comid.Environment{
+			Class: (&comid.Class{}).SetVendor(QuoteVendor).SetModel(model),
+		},
+	}
+
+	for _, m := range measurements {
+		vt.Measurements.Add(m)
+	}
+
+	return vt
+}
+
+func clone(b []byte) []byte {
+	return append([]byte(nil), b...)
+}
@@ -0,0 +1,213 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package tdx
+
+import (
+	_ "embed"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/coev"
+	"github.com/veraison/corim/comid"
+	"github.com/veraison/corim/profiles/tdx"
+)
+
+// synthetic v4 (TD report 1.0) and v5 (TD report 1.5) quotes, as built by
+// testQuote, not captured from TDX hardware. A captured v4 quote is still to be
+// added alongside them.
+var (
+	//go:embed testcases/quote-v4.bin
+	testQuoteV4 []byte
+	//go:embed testcases/quote-v5.bin
+	testQuoteV5 []byte
+)
+
+func mvalExtensions(t *testing.T, m comid.Measurement) *tdx.MValExtensions {
+	exts, ok := m.Val.GetExtensions().(*tdx.MValExtensions)
+	require.True(t, ok, "unexpected extensions type %T", m.Val.GetExtensions())
+
+	return exts
+}
+
+func requireDigest(t *testing.T, expectedAlg int, expectedValue []byte, d *tdx.TeeDigest) {
+	require.NotNil(t, d)
+
+	digests, err := d.GetDigest()
+	require.NoError(t, err)
+
+	assert.Equal(t, comid.Digests{*comid.NewDigestIntAlg(expectedAlg, expectedValue)}, comid.Digests(digests))
+}
+
+func TestQuote_ToConciseEvidence(t *testing.T) {
+	q, err := ParseQuote(testQuote(QuoteVersion4, TDReportBodyV10))
+	require.NoError(t, err)
+
+	ce, err := q.ToConciseEvidence()
+	require.NoError(t, err)
+
+	// round trip through the TDX profile, so that extensions are decoded
+	data, err := ce.ToCBOR()
+	require.NoError(t, err)
+
+	manifest, found := coev.GetProfileManifest(ProfileID)
+	require.True(t, found)
+
+	out := manifest.GetConciseEvidence()
+	require.NoError(t, out.FromCBOR(data))
+
+	require.NotNil(t, out.Profile)
+	profile, err := out.Profile.Get()
+	require.NoError(t, err)
+	assert.Equal(t, ProfileID.String(), profile)
+
+	require.NotNil(t, out.EvTriples.EvidenceTriples)
+	triples := out.EvTriples.EvidenceTriples.Values
+	require.Len(t, triples, 3)
+
+	for i, model := range []string{SeamModel, TDModel, QEModel} {
+		class := triples[i].Environment.Class
+		require.NotNil(t, class)
+		assert.Equal(t, QuoteVendor, *class.Vendor)
+		assert.Equal(t, model, *class.Model)
+	}
+
+	// TDX module
+	require.Len(t, triples[0].Measurements.Values, 1)
+	seam := mvalExtensions(t, triples[0].Measurements.Values[0])
+	requireDigest(t, comid.Sha384, fill(0x11, 48), seam.TeeMrTee)
+	requireDigest(t, comid.Sha384, fill(0x12, 48), seam.TeeMrSigner)
+	require.NotNil(t, seam.TeeAttributes)
+	assert.Equal(t, make([]byte, 8), []byte(*seam.TeeAttributes))
+	require.NotNil(t, seam.TeeTCBCompSvn)
+	svn0, err := seam.TeeTCBCompSvn[0].GetUint()
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), svn0)
+	svn2, err := seam.TeeTCBCompSvn[2].GetUint()
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), svn2)
+
+	// TD
+	require.Len(t, triples[1].Measurements.Values, 2)
+	td := triples[1].Measurements.Values[0]
+	assert.Nil(t, td.Key)
+	tdExts := mvalExtensions(t, td)
+	requireDigest(t, comid.Sha384, fill(0x13, 48), tdExts.TeeMrTee)
+	require.NotNil(t, tdExts.TeeAttributes)
+	assert.Equal(t, []byte{0, 0, 0, 0x10, 0, 0, 0, 0}, []byte(*tdExts.TeeAttributes))
+
+	// register indices are decoded as uint64
+	rtmrs := comid.NewIntegrityRegisters()
+	for i := uint64(0); i < 4; i++ {
+		require.NoError(t, rtmrs.AddDigest(i, *comid.NewDigestIntAlg(comid.Sha384, fill(0x20+byte(i), 48))))
+	}
+	require.NotNil(t, td.Val.IntegrityRegisters)
+	assert.True(t, rtmrs.Equal(*td.Val.IntegrityRegisters))
+
+	xfam := triples[1].Measurements.Values[1]
+	require.NotNil(t, xfam.Key)
+	expectedKey, err := comid.NewMkeyString(XFAMKey)
+	require.NoError(t, err)
+	assert.True(t, expectedKey.Equal(*xfam.Key))
+	xfamExts := mvalExtensions(t, xfam)
+	require.NotNil(t, xfamExts.TeeAttributes)
+	assert.Equal(t, []byte{0xe7, 0x02, 0x06, 0, 0, 0, 0, 0}, []byte(*xfamExts.TeeAttributes))
+
+	// Quoting Enclave
+	require.Len(t, triples[2].Measurements.Values, 1)
+	qe := mvalExtensions(t, triples[2].Measurements.Values[0])
+	require.NotNil(t, qe.TeeMiscSelect)
+	assert.Equal(t, []byte{0x01, 0x00, 0x00, 0x00}, []byte(*qe.TeeMiscSelect))
+	require.NotNil(t, qe.TeeAttributes)
+	assert.Equal(t, byte(0xe7), (*qe.TeeAttributes)[8])
+	requireDigest(t, comid.Sha256, fill(0x41, 32), qe.TeeMrTee)
+	requireDigest(t, comid.Sha256, fill(0x42, 32), qe.TeeMrSigner)
+	require.NotNil(t, qe.TeeISVProdID)
+	prodID, err := qe.TeeISVProdID.GetUint()
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), prodID)
+	require.NotNil(t, qe.TeeISVSVN)
+	isvSVN, err := qe.TeeISVSVN.GetUint()
+	require.NoError(t, err)
+	assert.Equal(t, uint(8), isvSVN)
+}
+
+func TestQuote_ToConciseEvidence_no_QE_report(t *testing.T) {
+	data := testQuoteWithSigData(QuoteVersion5, TDReportBodyV15,
+		testSignatureData(CertDataTypePCKCertChain, testPCKCertChain))
+
+	q, err := ParseQuote(data)
+	require.NoError(t, err)
+
+	ce, err := q.ToConciseEvidence()
+	require.NoError(t, err)
+
+	require.NotNil(t, ce.EvTriples.EvidenceTriples)
+	triples := ce.EvTriples.EvidenceTriples.Values
+	require.Len(t, triples, 2)
+	assert.Equal(t, SeamModel, *triples[0].Environment.Class.Model)
+	assert.Equal(t, TDModel, *triples[1].Environment.Class.Model)
+}
+
+func TestQuote_ToConciseEvidence_fixtures(t *testing.T) {
+	testCases := []struct {
+		name             string
+		data             []byte
+		bodyType         uint16
+		seamMeasurements int
+		tdMeasurements   int
+	}{
+		{"v4", testQuoteV4, TDReportBodyV10, 1, 2},
+		{"v5", testQuoteV5, TDReportBodyV15, 2, 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := ParseQuote(tc.data)
+			require.NoError(t, err)
+			assert.Equal(t, tc.bodyType, q.BodyType)
+
+			ce, err := q.ToConciseEvidence()
+			require.NoError(t, err)
+
+			triples := ce.EvTriples.EvidenceTriples.Values
+			require.Len(t, triples, 3)
+			assert.Len(t, triples[0].Measurements.Values, tc.seamMeasurements)
+			assert.Len(t, triples[1].Measurements.Values, tc.tdMeasurements)
+		})
+	}
+}
+
+func TestQuote_ToConciseEvidence_TD_report_1_5(t *testing.T) {
+	q, err := ParseQuote(testQuoteV5)
+	require.NoError(t, err)
+
+	ce, err := q.ToConciseEvidence()
+	require.NoError(t, err)
+
+	triples := ce.EvTriples.EvidenceTriples.Values
+	require.Len(t, triples, 3)
+
+	svn2Key, err := comid.NewMkeyString(TEETCBSVN2Key)
+	require.NoError(t, err)
+
+	require.Len(t, triples[0].Measurements.Values, 2)
+	svn2 := triples[0].Measurements.Values[1]
+	require.NotNil(t, svn2.Key)
+	assert.True(t, svn2Key.Equal(*svn2.Key))
+	svn2Exts := mvalExtensions(t, svn2)
+	require.NotNil(t, svn2Exts.TeeTCBCompSvn)
+	svn, err := svn2Exts.TeeTCBCompSvn[0].GetUint()
+	require.NoError(t, err)
+	assert.Equal(t, uint(4), svn)
+
+	mrServiceTDKey, err := comid.NewMkeyString(MRSERVICETDKey)
+	require.NoError(t, err)
+
+	require.Len(t, triples[1].Measurements.Values, 3)
+	mrServiceTD := triples[1].Measurements.Values[2]
+	require.NotNil(t, mrServiceTD.Key)
+	assert.True(t, mrServiceTDKey.Equal(*mrServiceTD.Key))
+	requireDigest(t, comid.Sha384, fill(0x17, 48), mvalExtensions(t, mrServiceTD).TeeMrTee)
+}
@@ -0,0 +1,300 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package tdx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testPCKCertChain = []byte("-----BEGIN CERTIFICATE-----\ntest\n-----END CERTIFICATE-----\n")
+	testQEAuthData   = []byte{0x00, 0x01, 0x02, 0x03}
+)
+
+// fill returns a n bytes long slice with every byte set to b, which makes it
+// easy to recognise where the fields of the synthetic quotes end up
+func fill(b byte, n int) []byte {
+	return bytes.Repeat([]byte{b}, n)
+}
+
+type quoteWriter struct {
+	bytes.Buffer
+}
+
+func (o *quoteWriter) uint16(v uint16) *quoteWriter {
+	_ = binary.Write(&o.Buffer, binary.LittleEndian, v)
+	return o
+}
+
+func (o *quoteWriter) uint32(v uint32) *quoteWriter {
+	_ = binary.Write(&o.Buffer, binary.LittleEndian, v)
+	return o
+}
+
+func (o *quoteWriter) bytes(b []byte) *quoteWriter {
+	o.Write(b)
+	return o
+}
+
+func testTDReportBody(bodyType uint16) []byte {
+	var w quoteWriter
+
+	w.bytes([]byte{3, 1, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}) // TEE_TCB_SVN
+	w.bytes(fill(0x11, 48))                                         // MRSEAM
+	w.bytes(fill(0x12, 48))                                         // MRSIGNERSEAM
+	w.bytes(fill(0x00, 8))                                          // SEAMATTRIBUTES
+	w.bytes([]byte{0, 0, 0, 0x10, 0, 0, 0, 0})                      // TDATTRIBUTES
+	w.bytes([]byte{0xe7, 0x02, 0x06, 0, 0, 0, 0, 0})                // XFAM
+	w.bytes(fill(0x13, 48))                                         // MRTD
+	w.bytes(fill(0x14, 48))                                         // MRCONFIGID
+	w.bytes(fill(0x15, 48))                                         // MROWNER
+	w.bytes(fill(0x16, 48))                                         // MROWNERCONFIG
+	w.bytes(fill(0x20, 48))                                         // RTMR0
+	w.bytes(fill(0x21, 48))                                         // RTMR1
+	w.bytes(fill(0x22, 48))                                         // RTMR2
+	w.bytes(fill(0x23, 48))                                         // RTMR3
+	w.bytes(fill(0x30, 64))                                         // REPORTDATA
+
+	if bodyType == TDReportBodyV15 {
+		w.bytes([]byte{4, 1, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}) // TEE_TCB_SVN2
+		w.bytes(fill(0x17, 48))                                         // MRSERVICETD
+	}
+
+	return w.Bytes()
+}
+
+func testQEReport() []byte {
+	r := make([]byte, qeReportSize)
+
+	copy(r[0:], fill(0x40, 16))                           // CPUSVN
+	copy(r[16:], []byte{0x01, 0x00, 0x00, 0x00})          // MISCSELECT
+	copy(r[48:], []byte{0x11, 0, 0, 0, 0, 0, 0, 0, 0xe7}) // ATTRIBUTES
+	copy(r[64:], fill(0x41, 32))                          // MRENCLAVE
+	copy(r[128:], fill(0x42, 32))                         // MRSIGNER
+	binary.LittleEndian.PutUint16(r[256:], 2)             // ISVPRODID
+	binary.LittleEndian.PutUint16(r[258:], 8)             // ISVSVN
+	copy(r[320:], fill(0x43, 64))                         // REPORTDATA
+
+	return r
+}
+
+func testQEReportCertData() []byte {
+	var w quoteWriter
+
+	w.bytes(testQEReport()).
+		bytes(fill(0x50, ecdsaSignatureSize)).
+		uint16(uint16(len(testQEAuthData))).
+		bytes(testQEAuthData).
+		uint16(CertDataTypePCKCertChain).
+		uint32(uint32(len(testPCKCertChain))).
+		bytes(testPCKCertChain)
+
+	return w.Bytes()
+}
+
+func testSignatureData(certType uint16, certData []byte) []byte {
+	var w quoteWriter
+
+	w.bytes(fill(0x60, ecdsaSignatureSize)) // quote signature
+	w.bytes(fill(0x61, 64))                 // attestation key
+	w.uint16(certType).
+		uint32(uint32(len(certData))).
+		bytes(certData)
+
+	return w.Bytes()
+}
+
+// testQuote builds a synthetic quote of the given version and body type (the
+// latter only matters for v5 quotes), with a QE report certification data
+// wrapping a PCK certificate chain
+func testQuote(version, bodyType uint16) []byte {
+	return testQuoteWithSigData(version, bodyType,
+		testSignatureData(CertDataTypeQEReportCert, testQEReportCertData()))
+}
+
+func testQuoteWithSigData(version, bodyType uint16, sigData []byte) []byte {
+	var w quoteWriter
+
+	w.uint16(version).
+		uint16(AttestationKeyTypeECDSAP256).
+		uint32(TEETypeTDX).
+		uint16(0).
+		uint16(0).
+		bytes(fill(0x70, 16)). // QE vendor ID
+		bytes(fill(0x00, 20))  // user data
+
+	body := testTDReportBody(bodyType)
+
+	if version == QuoteVersion5 {
+		w.uint16(bodyType).uint32(uint32(len(body)))
+	}
+
+	w.bytes(body).
+		uint32(uint32(len(sigData))).
+		bytes(sigData)
+
+	return w.Bytes()
+}
+
+func TestQuote_ParseQuote_v4(t *testing.T) {
+	q, err := ParseQuote(testQuote(QuoteVersion4, TDReportBodyV10))
+	require.NoError(t, err)
+
+	assert.Equal(t, uint16(QuoteVersion4), q.Header.Version)
+	assert.Equal(t, uint16(AttestationKeyTypeECDSAP256), q.Header.AttestationKeyType)
+	assert.Equal(t, uint32(TEETypeTDX), q.Header.TEEType)
+	assert.Equal(t, fill(0x70, 16), q.Header.QEVendorID[:])
+	assert.Equal(t, uint16(TDReportBodyV10), q.BodyType)
+
+	assert.Equal(t, byte(3), q.Body.TEETCBSVN[0])
+	assert.Equal(t, fill(0x11, 48), q.Body.MRSEAM[:])
+	assert.Equal(t, fill(0x12, 48), q.Body.MRSIGNERSEAM[:])
+	assert.Equal(t, []byte{0, 0, 0, 0x10, 0, 0, 0, 0}, q.Body.TDAttributes[:])
+	assert.Equal(t, []byte{0xe7, 0x02, 0x06, 0, 0, 0, 0, 0}, q.Body.XFAM[:])
+	assert.Equal(t, fill(0x13, 48), q.Body.MRTD[:])
+	assert.Equal(t, fill(0x16, 48), q.Body.MROWNERCONFIG[:])
+	for i := range q.Body.RTMRs {
+		assert.Equal(t, fill(0x20+byte(i), 48), q.Body.RTMRs[i][:])
+	}
+	assert.Equal(t, fill(0x30, 64), q.Body.ReportData[:])
+	assert.Equal(t, make([]byte, 48), q.Body.MRSERVICETD[:])
+
+	assert.Equal(t, fill(0x60, 64), q.Signature[:])
+	assert.Equal(t, fill(0x61, 64), q.AttestationKey[:])
+
+	require.NotNil(t, q.QEReport)
+	assert.Equal(t, fill(0x40, 16), q.QEReport.CPUSVN[:])
+	assert.Equal(t, []byte{0x01, 0x00, 0x00, 0x00}, q.QEReport.MiscSelect[:])
+	assert.Equal(t, byte(0xe7), q.QEReport.Attributes[8])
+	assert.Equal(t, fill(0x41, 32), q.QEReport.MRENCLAVE[:])
+	assert.Equal(t, fill(0x42, 32), q.QEReport.MRSIGNER[:])
+	assert.Equal(t, uint16(2), q.QEReport.ISVProdID)
+	assert.Equal(t, uint16(8), q.QEReport.ISVSVN)
+	assert.Equal(t, fill(0x43, 64), q.QEReport.ReportData[:])
+	assert.Equal(t, fill(0x50, 64), q.QEReportSignature)
+	assert.Equal(t, testQEAuthData, q.QEAuthData)
+
+	assert.Equal(t, uint16(CertDataTypePCKCertChain), q.CertDataType)
+	assert.Equal(t, testPCKCertChain, q.CertData)
+}
+
+func TestQuote_ParseQuote_v5(t *testing.T) {
+	q, err := ParseQuote(testQuote(QuoteVersion5, TDReportBodyV10))
+	require.NoError(t, err)
+
+	assert.Equal(t, uint16(QuoteVersion5), q.Header.Version)
+	assert.Equal(t, uint16(TDReportBodyV10), q.BodyType)
+	assert.Equal(t, fill(0x13, 48), q.Body.MRTD[:])
+
+	q, err = ParseQuote(testQuote(QuoteVersion5, TDReportBodyV15))
+	require.NoError(t, err)
+
+	assert.Equal(t, uint16(TDReportBodyV15), q.BodyType)
+	assert.Equal(t, fill(0x13, 48), q.Body.MRTD[:])
+	assert.Equal(t, byte(4), q.Body.TEETCBSVN2[0])
+	assert.Equal(t, fill(0x17, 48), q.Body.MRSERVICETD[:])
+	require.NotNil(t, q.QEReport)
+	assert.Equal(t, testPCKCertChain, q.CertData)
+}
+
+func TestQuote_ParseQuote_no_QE_report(t *testing.T) {
+	data := testQuoteWithSigData(QuoteVersion4, TDReportBodyV10,
+		testSignatureData(CertDataTypePCKCertChain, testPCKCertChain))
+
+	q, err := ParseQuote(data)
+	require.NoError(t, err)
+
+	assert.Nil(t, q.QEReport)
+	assert.Nil(t, q.QEAuthData)
+	assert.Equal(t, uint16(CertDataTypePCKCertChain), q.CertDataType)
+	assert.Equal(t, testPCKCertChain, q.CertData)
+}
+
+func TestQuote_ParseQuote_NOK(t *testing.T) {
+	v4 := testQuote(QuoteVersion4, TDReportBodyV10)
+	v5 := testQuote(QuoteVersion5, TDReportBodyV15)
+
+	patch := func(data []byte, off int, b ...byte) []byte {
+		out := bytes.Clone(data)
+		copy(out[off:], b)
+		return out
+	}
+
+	testCases := []struct {
+		name string
+		data []byte
+		err  string
+	}{
+		{
+			name: "empty",
+			data: nil,
+			err:  "version at offset 0: need 2 bytes, 0 left",
+		},
+		{
+			name: "bad version",
+			data: patch(v4, 0, 3),
+			err:  "unsupported quote version 3",
+		},
+		{
+			name: "bad attestation key type",
+			data: patch(v4, 2, 3),
+			err:  "unsupported attestation key type 3",
+		},
+		{
+			name: "SGX quote",
+			data: patch(v4, 4, 0),
+			err:  "unexpected TEE type 0x0, expected 0x81 (TDX)",
+		},
+		{
+			name: "truncated body",
+			data: v4[:100],
+			err:  "TD report body at offset 48: need 584 bytes, 52 left",
+		},
+		{
+			name: "bad body type",
+			data: patch(v5, 48, 1),
+			err:  "unsupported body type 1",
+		},
+		{
+			name: "bad body size",
+			data: patch(v5, 50, 0),
+			err:  "body type 3: expected size 648, got 512",
+		},
+		{
+			name: "trailing bytes",
+			data: append(bytes.Clone(v4), 0x00, 0x00),
+			err:  "2 trailing bytes after the quote signature data",
+		},
+		{
+			name: "truncated signature data",
+			data: v4[:len(v4)-1],
+			err:  "quote signature data at offset 636: need 653 bytes, 652 left",
+		},
+		{
+			name: "truncated QE report",
+			data: testQuoteWithSigData(QuoteVersion4, TDReportBodyV10,
+				testSignatureData(CertDataTypeQEReportCert, testQEReport()[:100])),
+			err: "quote signature data: QE report certification data: " +
+				"QE report at offset 0: need 384 bytes, 100 left",
+		},
+		{
+			name: "trailing certification data",
+			data: testQuoteWithSigData(QuoteVersion4, TDReportBodyV10,
+				append(testSignatureData(CertDataTypePCKCertChain, testPCKCertChain), 0x00)),
+			err: "quote signature data: 1 trailing bytes after the certification data",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseQuote(tc.data)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,345 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package comid
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/veraison/eat"
+)
+
+// BuildError describes a failed call to a builder method
+type BuildError struct {
+	// Call is the (1-based) position of the failed call in the builder chain
+	Call int
+	// Method is the name of the failed builder method
+	Method string
+	Err    error
+}
+
+func (e *BuildError) Error() string {
+	return fmt.Sprintf("%s (call %d): %v", e.Method, e.Call, e.Err)
+}
+
+func (e *BuildError) Unwrap() error {
+	return e.Err
+}
+
+// BuildErrors accumulates the errors of the calls to a builder. It is embedded
+// in the builders of the comid, corim, coev and cots packages, which keep
+// going after a failed call so that all the errors can be reported at once.
+type BuildErrors struct {
+	calls int
+	errs  []error
+}
+
+// Record records the outcome of a call to the named builder method. A non-nil
+// err is wrapped in a *BuildError. It returns true if err is nil.
+func (o *BuildErrors) Record(method string, err error) bool {
+	o.calls++
+
+	if err == nil {
+		return true
+	}
+
+	o.errs = append(o.errs, &BuildError{Call: o.calls, Method: method, Err: err})
+
+	return false
+}
+
+// Err returns the errors recorded so far, joined, or nil if there are none
+func (o *BuildErrors) Err() error {
+	return errors.Join(o.errs...)
+}
+
+// ComidBuilder builds a Comid with the same methods as the fluent Comid
+// setters, but instead of returning nil on failure, it records the reason and
+// carries on. Errors are reported by Err and Build.
+type ComidBuilder struct {
+	BuildErrors
+	comid *Comid
+}
+
+// NewComidBuilder instantiates a builder for an empty Comid
+func NewComidBuilder() *ComidBuilder {
+	return &ComidBuilder{comid: NewComid()}
+}
+
+// Build returns the built Comid, or the errors recorded by the builder. If
+// there are none, the Comid is validated.
+func (o *ComidBuilder) Build() (*Comid, error) {
+	if err := o.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := o.comid.Valid(); err != nil {
+		return nil, fmt.Errorf("invalid comid: %w", err)
+	}
+
+	return o.comid, nil
+}
+
+// SetLanguage is like [Comid.SetLanguage]
+func (o *ComidBuilder) SetLanguage(language string) *ComidBuilder {
+	o.Record("SetLanguage", o.comid.setLanguage(language))
+
+	return o
+}
+
+// SetTagIdentity is like [Comid.SetTagIdentity]
+func (o *ComidBuilder) SetTagIdentity(tagID interface{}, tagIDVersion uint) *ComidBuilder {
+	o.Record("SetTagIdentity", o.comid.setTagIdentity(tagID, tagIDVersion))
+
+	return o
+}
+
+// AddEntity is like [Comid.AddEntity], but also rejects an empty name
+func (o *ComidBuilder) AddEntity(name string, regID *string, roles ...Role) *ComidBuilder {
+	if name == "" {
+		o.Record("AddEntity", errors.New("empty entity name"))
+		return o
+	}
+
+	o.Record("AddEntity", o.comid.addEntity(name, regID, roles...))
+
+	return o
+}
+
+// AddLinkedTag is like [Comid.AddLinkedTag], but also rejects an invalid rel
+func (o *ComidBuilder) AddLinkedTag(tagID interface{}, rel Rel) *ComidBuilder {
+	if err := rel.Valid(); err != nil {
+		o.Record("AddLinkedTag", fmt.Errorf("rel validation failed: %w", err))
+		return o
+	}
+
+	o.Record("AddLinkedTag", o.comid.addLinkedTag(tagID, rel))
+
+	return o
+}
+
+// AddReferenceValue is like [Comid.AddReferenceValue], but also rejects a nil triple
+func (o *ComidBuilder) AddReferenceValue(val *ValueTriple) *ComidBuilder {
+	if err := checkTriple(val); err != nil {
+		o.Record("AddReferenceValue", err)
+		return o
+	}
+
+	o.Record("AddReferenceValue", o.comid.addReferenceValue(val))
+
+	return o
+}
+
+// AddEndorsedValue is like [Comid.AddEndorsedValue], but also rejects a nil triple
+func (o *ComidBuilder) AddEndorsedValue(val *ValueTriple) *ComidBuilder {
+	if err := checkTriple(val); err != nil {
+		o.Record("AddEndorsedValue", err)
+		return o
+	}
+
+	o.Record("AddEndorsedValue", o.comid.addEndorsedValue(val))
+
+	return o
+}
+
+// AddAttestVerifKey is like [Comid.AddAttestVerifKey], but also rejects a nil triple
+func (o *ComidBuilder) AddAttestVerifKey(val *KeyTriple) *ComidBuilder {
+	if err := checkTriple(val); err != nil {
+		o.Record("AddAttestVerifKey", err)
+		return o
+	}
+
+	o.Record("AddAttestVerifKey", o.comid.addAttestVerifKey(val))
+
+	return o
+}
+
+// AddDevIdentityKey is like [Comid.AddDevIdentityKey], but also rejects a nil triple
+func (o *ComidBuilder) AddDevIdentityKey(val *KeyTriple) *ComidBuilder {
+	if err := checkTriple(val); err != nil {
+		o.Record("AddDevIdentityKey", err)
+		return o
+	}
+
+	o.Record("AddDevIdentityKey", o.comid.addDevIdentityKey(val))
+
+	return o
+}
+
+// AddCondEndorseSeries is like [Comid.AddCondEndorseSeries], but also rejects a nil triple
+func (o *ComidBuilder) AddCondEndorseSeries(val *CondEndorseSeriesTriple) *ComidBuilder {
+	if err := checkTriple(val); err != nil {
+		o.Record("AddCondEndorseSeries", err)
+		return o
+	}
+
+	o.Record("AddCondEndorseSeries", o.comid.addCondEndorseSeries(val))
+
+	return o
+}
+
+// MeasurementBuilder builds a Measurement with the same methods as the fluent
+// Measurement setters, but instead of returning nil on failure, it records the
+// reason and carries on. Errors are reported by Err and Build.
+type MeasurementBuilder struct {
+	BuildErrors
+	measurement *Measurement
+}
+
+// NewMeasurementBuilder instantiates a builder for a Measurement without a key
+func NewMeasurementBuilder() *MeasurementBuilder {
+	return &MeasurementBuilder{measurement: &Measurement{}}
+}
+
+// Build returns the built Measurement, or the errors recorded by the builder.
+// If there are none, the Measurement is validated.
+func (o *MeasurementBuilder) Build() (*Measurement, error) {
+	if err := o.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := o.measurement.Valid(); err != nil {
+		return nil, fmt.Errorf("invalid measurement: %w", err)
+	}
+
+	return o.measurement, nil
+}
+
+// SetKey sets the measurement key to the supplied value of the supplied type,
+// like [NewMeasurement]
+func (o *MeasurementBuilder) SetKey(val any, typ string) *MeasurementBuilder {
+	m, err := NewMeasurement(val, typ)
+	if err == nil {
+		o.measurement.Key = m.Key
+	}
+
+	o.Record("SetKey", err)
+
+	return o
+}
+
+// SetVersion is like [Measurement.SetVersion]
+func (o *MeasurementBuilder) SetVersion(ver string, scheme int64) *MeasurementBuilder {
+	o.Record("SetVersion", o.measurement.setVersion(ver, scheme))
+
+	return o
+}
+
+// SetRawValueBytes is like [Measurement.SetRawValueBytes]
+func (o *MeasurementBuilder) SetRawValueBytes(rawValue, rawValueMask []byte) *MeasurementBuilder {
+	o.measurement.SetRawValueBytes(rawValue, rawValueMask)
+	o.Record("SetRawValueBytes", nil)
+
+	return o
+}
+
+// SetSVN is like [Measurement.SetSVN]
+func (o *MeasurementBuilder) SetSVN(svn uint64) *MeasurementBuilder {
+	o.measurement.SetSVN(svn)
+	o.Record("SetSVN", nil)
+
+	return o
+}
+
+// SetMinSVN is like [Measurement.SetMinSVN]
+func (o *MeasurementBuilder) SetMinSVN(svn uint64) *MeasurementBuilder {
+	o.measurement.SetMinSVN(svn)
+	o.Record("SetMinSVN", nil)
+
+	return o
+}
+
+// AddDigest is like [Measurement.AddDigest]
+func (o *MeasurementBuilder) AddDigest(algID int, digest []byte) *MeasurementBuilder {
+	o.measurement.AddDigest(algID, digest)
+	o.Record("AddDigest", nil)
+
+	return o
+}
+
+// AddCryptoKey is like [Measurement.AddCryptoKey], but also rejects a nil or
+// invalid key
+func (o *MeasurementBuilder) AddCryptoKey(key *CryptoKey) *MeasurementBuilder {
+	if key == nil {
+		o.Record("AddCryptoKey", errors.New("nil crypto key"))
+		return o
+	}
+
+	if err := key.Valid(); err != nil {
+		o.Record("AddCryptoKey", err)
+		return o
+	}
+
+	o.Record("AddCryptoKey", o.measurement.addCryptoKey(key))
+
+	return o
+}
+
+// SetFlagsTrue is like [Measurement.SetFlagsTrue]
+func (o *MeasurementBuilder) SetFlagsTrue(flags ...Flag) *MeasurementBuilder {
+	o.measurement.SetFlagsTrue(flags...)
+	o.Record("SetFlagsTrue", nil)
+
+	return o
+}
+
+// SetFlagsFalse is like [Measurement.SetFlagsFalse]
+func (o *MeasurementBuilder) SetFlagsFalse(flags ...Flag) *MeasurementBuilder {
+	o.measurement.SetFlagsFalse(flags...)
+	o.Record("SetFlagsFalse", nil)
+
+	return o
+}
+
+// SetIPaddr is like [Measurement.SetIPaddr], but also rejects an invalid
+// address
+func (o *MeasurementBuilder) SetIPaddr(a net.IP) *MeasurementBuilder {
+	if a.To16() == nil {
+		o.Record("SetIPaddr", fmt.Errorf("invalid IP address: %s", a))
+		return o
+	}
+
+	o.Record("SetIPaddr", o.measurement.setIPaddr(a))
+
+	return o
+}
+
+// SetMACaddr is like [Measurement.SetMACaddr]
+func (o *MeasurementBuilder) SetMACaddr(a MACaddr) *MeasurementBuilder {
+	o.measurement.SetMACaddr(a)
+	o.Record("SetMACaddr", nil)
+
+	return o
+}
+
+// SetSerialNumber is like [Measurement.SetSerialNumber]
+func (o *MeasurementBuilder) SetSerialNumber(sn string) *MeasurementBuilder {
+	o.measurement.SetSerialNumber(sn)
+	o.Record("SetSerialNumber", nil)
+
+	return o
+}
+
+// SetUEID is like [Measurement.SetUEID]
+func (o *MeasurementBuilder) SetUEID(ueid eat.UEID) *MeasurementBuilder {
+	o.Record("SetUEID", o.measurement.setUEID(ueid))
+
+	return o
+}
+
+// SetUUID is like [Measurement.SetUUID]
+func (o *MeasurementBuilder) SetUUID(u UUID) *MeasurementBuilder {
+	o.Record("SetUUID", o.measurement.setUUID(u))
+
+	return o
+}
+
+// SetName is like [Measurement.SetName]
+func (o *MeasurementBuilder) SetName(name string) *MeasurementBuilder {
+	o.measurement.SetName(name)
+	o.Record("SetName", nil)
+
+	return o
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package comid
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/swid"
+)
+
+func testValueTriple() *ValueTriple {
+	return &ValueTriple{
+		Environment: Environment{
+			Instance: MustNewUUIDInstance(TestUUID),
+		},
+		Measurements: *NewMeasurements().Add(&Measurement{
+			Val: Mval{
+				RawValue: NewRawValueFromBytes([]byte{0xde, 0xad, 0xbe, 0xef}),
+			},
+		}),
+	}
+}
+
+func TestComidBuilder_Build_OK(t *testing.T) {
+	regID := TestRegID
+
+	c, err := NewComidBuilder().
+		SetLanguage("en-GB").
+		SetTagIdentity(TestTagID, 1).
+		AddEntity("ACME Ltd.", &regID, RoleTagCreator, RoleCreator).
+		AddLinkedTag(TestUUIDString, RelSupplements).
+		AddReferenceValue(testValueTriple()).
+		AddEndorsedValue(testValueTriple()).
+		Build()
+	require.NoError(t, err)
+
+	expected := NewComid().
+		SetLanguage("en-GB").
+		SetTagIdentity(TestTagID, 1).
+		AddEntity("ACME Ltd.", &regID, RoleTagCreator, RoleCreator).
+		AddLinkedTag(TestUUIDString, RelSupplements).
+		AddReferenceValue(testValueTriple()).
+		AddEndorsedValue(testValueTriple())
+	require.NotNil(t, expected)
+
+	assert.Equal(t, expected, c)
+}
+
+func TestComidBuilder_Build_accumulates_errors(t *testing.T) {
+	badRegID := "not a URI"
+
+	b := NewComidBuilder().
+		SetTagIdentity(TestTagID, 0).
+		SetLanguage("").
+		AddEntity("ACME Ltd.", &badRegID, RoleCreator).
+		AddReferenceValue(nil).
+		AddEndorsedValue(testValueTriple())
+
+	_, err := b.Build()
+	require.Error(t, err)
+	assert.Equal(t, err, b.Err())
+
+	var errs []*BuildError
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var be *BuildError
+		require.True(t, errors.As(e, &be))
+		errs = append(errs, be)
+	}
+
+	require.Len(t, errs, 3)
+
+	assert.Equal(t, 2, errs[0].Call)
+	assert.Equal(t, "SetLanguage", errs[0].Method)
+	assert.EqualError(t, errs[0], "SetLanguage (call 2): empty language")
+
+	assert.Equal(t, 3, errs[1].Call)
+	assert.Equal(t, "AddEntity", errs[1].Method)
+	assert.ErrorContains(t, errs[1], "invalid regid")
+
+	assert.Equal(t, 4, errs[2].Call)
+	assert.Equal(t, "AddReferenceValue", errs[2].Method)
+	assert.EqualError(t, errs[2], "AddReferenceValue (call 4): nil triple")
+}
+
+func TestComidBuilder_Build_invalid_comid(t *testing.T) {
+	_, err := NewComidBuilder().
+		AddReferenceValue(testValueTriple()).
+		Build()
+	assert.EqualError(t, err, "invalid comid: tag-identity validation failed: empty tag-id")
+}
+
+func TestComidBuilder_AddReferenceValue_invalid_triple(t *testing.T) {
+	_, err := NewComidBuilder().
+		SetTagIdentity(TestTagID, 0).
+		AddReferenceValue(&ValueTriple{}).
+		Build()
+	assert.ErrorContains(t, err, "invalid comid: ")
+}
+
+func TestComidBuilder_AddAttestVerifKey_nil(t *testing.T) {
+	_, err := NewComidBuilder().
+		SetTagIdentity(TestTagID, 0).
+		AddAttestVerifKey(nil).
+		AddDevIdentityKey(nil).
+		Build()
+	assert.EqualError(t, err, "AddAttestVerifKey (call 2): nil triple\n"+
+		"AddDevIdentityKey (call 3): nil triple")
+}
+
+func TestComidBuilder_AddLinkedTag_bad_tag_id(t *testing.T) {
+	_, err := NewComidBuilder().
+		SetTagIdentity(TestTagID, 0).
+		AddLinkedTag(42, RelReplaces).
+		Build()
+	assert.EqualError(t, err, "AddLinkedTag (call 2): invalid tag-id: unsupported type int")
+}
+
+func TestComidBuilder_stricter_than_setters(t *testing.T) {
+	// the fluent setters accept these, only the builder rejects them
+	assert.NotNil(t, NewComid().AddEntity("", nil, RoleCreator))
+	assert.NotNil(t, NewComid().AddLinkedTag(TestUUIDString, RelUnset))
+	assert.NotNil(t, NewComid().AddReferenceValue(nil))
+	assert.NotNil(t, (&Measurement{}).AddCryptoKey(nil))
+	assert.NotNil(t, (&Measurement{}).AddCryptoKey(&CryptoKey{}))
+	assert.NotNil(t, (&Measurement{}).SetIPaddr(net.IP{0x01}))
+
+	_, err := NewComidBuilder().
+		SetTagIdentity(TestTagID, 0).
+		AddEntity("", nil, RoleCreator).
+		AddLinkedTag(TestUUIDString, RelUnset).
+		AddReferenceValue(nil).
+		AddEndorsedValue(nil).
+		AddCondEndorseSeries(nil).
+		Build()
+	assert.EqualError(t, err, "AddEntity (call 2): empty entity name\n"+
+		"AddLinkedTag (call 3): rel validation failed: rel is unset\n"+
+		"AddReferenceValue (call 4): nil triple\n"+
+		"AddEndorsedValue (call 5): nil triple\n"+
+		"AddCondEndorseSeries (call 6): nil triple")
+
+	_, err = NewMeasurementBuilder().
+		SetKey(TestUUID, "uuid").
+		AddCryptoKey(&CryptoKey{}).
+		Build()
+	assert.EqualError(t, err, "AddCryptoKey (call 2): CryptoKey not set")
+}
+
+func TestParseTagID(t *testing.T) {
+	id, err := ParseTagID(TestUUIDString)
+	require.NoError(t, err)
+	assert.Equal(t, TestUUIDString, id.String())
+
+	id, err = ParseTagID(TestTagID)
+	require.NoError(t, err)
+	assert.Equal(t, swid.NewTagID(TestTagID), id)
+
+	id, err = ParseTagID(TestUUID[:])
+	require.NoError(t, err)
+	assert.Equal(t, TestUUIDString, id.String())
+
+	_, err = ParseTagID("")
+	assert.ErrorContains(t, err, "invalid tag-id: ")
+
+	_, err = ParseTagID([]byte{0x01})
+	assert.ErrorContains(t, err, "invalid tag-id: ")
+
+	_, err = ParseTagID(nil)
+	assert.EqualError(t, err, "invalid tag-id: unsupported type <nil>")
+}
+
+func TestMeasurementBuilder_Build_OK(t *testing.T) {
+	m, err := NewMeasurementBuilder().
+		SetKey(TestUUID, "uuid").
+		SetVersion("1.2.3", 16384).
+		SetSVN(2).
+		AddDigest(1, MustHexDecode(t, "e45b72f5c0c0b572db4d8d3ab7e97f368ff74e62347a824decb67a84e5224d75")).
+		SetFlagsTrue(FlagIsDebug).
+		SetIPaddr(TestIPaddr).
+		SetMACaddr(MACaddr(TestMACaddr)).
+		SetSerialNumber("C02X70VHJHD5").
+		SetUEID(TestUEID).
+		SetName("boot").
+		Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", m.Val.Ver.Version)
+	assert.Equal(t, "boot", *m.Val.Name)
+	assert.True(t, m.Key.IsSet())
+}
+
+func TestMeasurementBuilder_Build_accumulates_errors(t *testing.T) {
+	_, err := NewMeasurementBuilder().
+		SetKey(TestUUID, "nope").
+		SetVersion("", 0).
+		AddDigest(1, []byte{0x00}).
+		SetIPaddr(net.IP{0x01}).
+		SetSVN(1).
+		AddCryptoKey(nil).
+		Build()
+	require.Error(t, err)
+
+	assert.ErrorContains(t, err, "SetKey (call 1): ")
+	assert.ErrorContains(t, err, "SetVersion (call 2): invalid version scheme: ")
+	assert.ErrorContains(t, err, "SetIPaddr (call 4): invalid IP address")
+	assert.ErrorContains(t, err, "AddCryptoKey (call 6): nil crypto key")
+	// like Measurement.AddDigest, the digest is only checked by Build
+	assert.NotContains(t, err.Error(), "call 3")
+	assert.NotContains(t, err.Error(), "call 5")
+}
+
+func TestMeasurementBuilder_Build_invalid_measurement(t *testing.T) {
+	_, err := NewMeasurementBuilder().SetKey(TestUUID, "uuid").Build()
+	assert.ErrorContains(t, err, "invalid measurement: ")
+}
@@ -33,6 +33,8 @@ var (
 		562: TaggedPKIXAsn1DerCert{},
 		563: TaggedMaskedRawValue{},
 		564: TaggedRawIntRange{},
+		// not defined by the CoRIM specification
+		60030: TaggedVersionRange{},
 	}
 )
 
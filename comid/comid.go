@@ -5,13 +5,13 @@ package comid
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"iter"
 	"net/url"
 
 	"github.com/veraison/corim/encoding"
 	"github.com/veraison/corim/extensions"
-	"github.com/veraison/swid"
 )
 
 // Comid is the top-level representation of a Concise Module IDentifier with
@@ -64,15 +64,22 @@ func (o *Comid) GetExtensions() extensions.IMapValue {
 // SetLanguage sets the language used in the target Comid to the supplied
 // language tag.  See also: BCP 47 and the IANA Language subtag registry.
 func (o *Comid) SetLanguage(language string) *Comid {
-	if o != nil {
-		if language == "" {
-			return nil
-		}
-		o.Language = &language
+	if o != nil && o.setLanguage(language) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *Comid) setLanguage(language string) error {
+	if language == "" {
+		return errors.New("empty language")
+	}
+
+	o.Language = &language
+
+	return nil
+}
+
 // SetTagIdentity sets the identifier of the target Comid to the supplied tagID,
 // which MUST be of type string or [16]byte.  A tagIDVersion must also be
 // supplied to disambiguate between different revisions of the same tag
@@ -80,17 +87,24 @@ func (o *Comid) SetLanguage(language string) *Comid {
 // been associated with a CoMID, pick a tagIDVersion greater than any other
 // existing tagIDVersion's associated with that tagID.
 func (o *Comid) SetTagIdentity(tagID interface{}, tagIDVersion uint) *Comid {
-	if o != nil {
-		id := swid.NewTagID(tagID)
-		if id == nil {
-			return nil
-		}
-		o.TagIdentity.TagID = *id
-		o.TagIdentity.TagVersion = tagIDVersion
+	if o != nil && o.setTagIdentity(tagID, tagIDVersion) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *Comid) setTagIdentity(tagID interface{}, tagIDVersion uint) error {
+	id, err := ParseTagID(tagID)
+	if err != nil {
+		return err
+	}
+
+	o.TagIdentity.TagID = *id
+	o.TagIdentity.TagVersion = tagIDVersion
+
+	return nil
+}
+
 func IsAbsoluteURI(s string) error {
 	var (
 		u   *url.URL
@@ -129,135 +143,180 @@ func String2URI(s *string) (*TaggedURI, error) {
 // or more claimed roles chosen from the following: RoleTagCreator, RoleCreator
 // and RoleMaintainer.
 func (o *Comid) AddEntity(name string, regID *string, roles ...Role) *Comid {
-	if o != nil {
-		var rs Roles
-		if rs.Add(roles...) == nil {
-			return nil
-		}
+	if o != nil && o.addEntity(name, regID, roles...) != nil {
+		return nil
+	}
+	return o
+}
 
-		uri, err := String2URI(regID)
-		if err != nil {
-			return nil
-		}
+func (o *Comid) addEntity(name string, regID *string, roles ...Role) error {
+	var rs Roles
+	rs.Add(roles...)
 
-		e := Entity{
-			Name:  MustNewStringEntityName(name),
-			RegID: uri,
-			Roles: rs,
-		}
+	uri, err := String2URI(regID)
+	if err != nil {
+		return fmt.Errorf("invalid regid: %w", err)
+	}
 
-		if o.Entities == nil {
-			o.Entities = NewEntities()
-		}
+	e := Entity{
+		Name:  MustNewStringEntityName(name),
+		RegID: uri,
+		Roles: rs,
+	}
 
-		if o.Entities.Add(&e) == nil {
-			return nil
-		}
+	if o.Entities == nil {
+		o.Entities = NewEntities()
 	}
-	return o
+
+	o.Entities.Add(&e)
+
+	return nil
 }
 
 // AddLinkedTag adds a link relationship of type rel between the target Comid
 // and another CoMID identified by its tagID.  The rel parameter can be one of
 // RelSupplements or RelReplaces.
 func (o *Comid) AddLinkedTag(tagID interface{}, rel Rel) *Comid {
-	if o != nil {
-		id := swid.NewTagID(tagID)
-		if id == nil {
-			return nil
-		}
+	if o != nil && o.addLinkedTag(tagID, rel) != nil {
+		return nil
+	}
+	return o
+}
 
-		lt := LinkedTag{
-			LinkedTagID: *id,
-			Rel:         rel,
-		}
+func (o *Comid) addLinkedTag(tagID interface{}, rel Rel) error {
+	id, err := ParseTagID(tagID)
+	if err != nil {
+		return err
+	}
 
-		if o.LinkedTags == nil {
-			o.LinkedTags = new(LinkedTags)
-		}
+	lt := LinkedTag{
+		LinkedTagID: *id,
+		Rel:         rel,
+	}
 
-		if o.LinkedTags.AddLinkedTag(lt) == nil {
-			return nil
-		}
+	if o.LinkedTags == nil {
+		o.LinkedTags = new(LinkedTags)
 	}
-	return o
+
+	o.LinkedTags.AddLinkedTag(lt)
+
+	return nil
 }
 
 // AddReferenceValue adds the supplied reference value to the
 // reference-triples list of the target Comid.
 func (o *Comid) AddReferenceValue(val *ValueTriple) *Comid {
-	if o != nil {
-		if o.Triples.ReferenceValues == nil {
-			o.Triples.ReferenceValues = NewValueTriples()
-		}
-
-		if o.Triples.AddReferenceValue(val) == nil {
-			return nil
-		}
+	if o != nil && o.addReferenceValue(val) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *Comid) addReferenceValue(val *ValueTriple) error {
+	if o.Triples.ReferenceValues == nil {
+		o.Triples.ReferenceValues = NewValueTriples()
+	}
+
+	o.Triples.AddReferenceValue(val)
+
+	return nil
+}
+
 // AddEndorsedValue adds the supplied endorsed value to the
 // endorsed-triples list of the target Comid.
 func (o *Comid) AddEndorsedValue(val *ValueTriple) *Comid {
-	if o != nil {
-		if o.Triples.EndorsedValues == nil {
-			o.Triples.EndorsedValues = NewValueTriples()
-		}
-
-		if o.Triples.AddEndorsedValue(val) == nil {
-			return nil
-		}
+	if o != nil && o.addEndorsedValue(val) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *Comid) addEndorsedValue(val *ValueTriple) error {
+	if o.Triples.EndorsedValues == nil {
+		o.Triples.EndorsedValues = NewValueTriples()
+	}
+
+	o.Triples.AddEndorsedValue(val)
+
+	return nil
+}
+
 // AddAttestVerifKey adds the supplied verification key to the
 // attest-key-triples list of the target Comid.
 func (o *Comid) AddAttestVerifKey(val *KeyTriple) *Comid {
-	if o != nil {
-		if o.Triples.AttestVerifKeys == nil {
-			o.Triples.AttestVerifKeys = NewKeyTriples()
-		}
-
-		if o.Triples.AddAttestVerifKey(val) == nil {
-			return nil
-		}
+	if o != nil && o.addAttestVerifKey(val) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *Comid) addAttestVerifKey(val *KeyTriple) error {
+	if err := checkTriple(val); err != nil {
+		return err
+	}
+
+	if o.Triples.AttestVerifKeys == nil {
+		o.Triples.AttestVerifKeys = NewKeyTriples()
+	}
+
+	o.Triples.AddAttestVerifKey(val)
+
+	return nil
+}
+
 // AddDevIdentityKey adds the supplied identity key to the
 // identity-triples list of the Comid.
 func (o *Comid) AddDevIdentityKey(val *KeyTriple) *Comid {
-	if o != nil {
-		if o.Triples.DevIdentityKeys == nil {
-			o.Triples.DevIdentityKeys = NewKeyTriples()
-		}
-
-		if o.Triples.AddDevIdentityKey(val) == nil {
-			return nil
-		}
+	if o != nil && o.addDevIdentityKey(val) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *Comid) addDevIdentityKey(val *KeyTriple) error {
+	if err := checkTriple(val); err != nil {
+		return err
+	}
+
+	if o.Triples.DevIdentityKeys == nil {
+		o.Triples.DevIdentityKeys = NewKeyTriples()
+	}
+
+	o.Triples.AddDevIdentityKey(val)
+
+	return nil
+}
+
 // AddCondEndorseSeries adds the supplied conditional series triple to the
 // conditional series triple list of the Comid.
 func (o *Comid) AddCondEndorseSeries(val *CondEndorseSeriesTriple) *Comid {
-	if o != nil {
-		if o.Triples.CondEndorseSeries == nil {
-			o.Triples.CondEndorseSeries = NewCondEndorseSeriesTriples()
-		}
-
-		if o.Triples.AddCondEndorseSeries(val) == nil {
-			return nil
-		}
+	if o != nil && o.addCondEndorseSeries(val) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *Comid) addCondEndorseSeries(val *CondEndorseSeriesTriple) error {
+	if o.Triples.CondEndorseSeries == nil {
+		o.Triples.CondEndorseSeries = NewCondEndorseSeriesTriples()
+	}
+
+	o.Triples.AddCondEndorseSeries(val)
+
+	return nil
+}
+
+// checkTriple checks that the supplied triple is set. Its content is only
+// validated by Valid (or ValidateAll), so that triples can be completed after
+// being added.
+func checkTriple[T any](val *T) error {
+	if val == nil {
+		return errors.New("nil triple")
+	}
+
+	return nil
+}
+
 // IterRefVals provides an iterator over reference value ValueTriple's inside
 // the Comid.
 func (o *Comid) IterRefVals() iter.Seq[*ValueTriple] {
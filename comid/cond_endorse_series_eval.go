@@ -0,0 +1,107 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package comid
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrSeriesConditionNotMet is returned by CondEndorseSeriesTriple.Evaluate
+	// when the claims do not satisfy the triple's condition.
+	ErrSeriesConditionNotMet = errors.New("series condition not met")
+	// ErrNoSeriesRecordSelected is returned by CondEndorseSeriesTriple.Evaluate
+	// when the condition is satisfied, but none of the series records'
+	// selections are.
+	ErrNoSeriesRecordSelected = errors.New("no series record selected")
+)
+
+// Evaluate evaluates the conditional endorsement series triple against the
+// supplied claims (e.g. evidence, or the claims accepted so far by a
+// verifier). If the condition is satisfied, the series records are tried in
+// order, and the additions of the first record whose selection is satisfied
+// are returned, together with the index of that record. Otherwise, the
+// returned error wraps ErrSeriesConditionNotMet or ErrNoSeriesRecordSelected.
+//
+// Measurements are matched as per Measurement.MatchReference, within the
+// environment of the condition. The authorized-by of the condition applies to
+// each of the condition's measurements (unless a measurement specifies its
+// own), and is matched against the authorized-by of the claimed measurements.
+//
+// See https://ietf-rats-wg.github.io/draft-ietf-rats-corim/draft-ietf-rats-corim.html#section-8.5.8
+// nolint:gocritic
+func (o CondEndorseSeriesTriple) Evaluate(claims []ValueTriple) (*Measurements, int, error) {
+	cond := o.Condition
+
+	if !hasEnvironment(claims, cond.Environment) {
+		return nil, -1, fmt.Errorf("%w: %w", ErrSeriesConditionNotMet, ErrEnvironmentMismatch)
+	}
+
+	for i, m := range cond.Measurements.Values {
+		if m.AuthorizedBy == nil {
+			m.AuthorizedBy = cond.AuthorizedBy
+		}
+
+		if err := matchClaims(claims, cond.Environment, m); err != nil {
+			return nil, -1, fmt.Errorf("%w: measurement at index %d: %w",
+				ErrSeriesConditionNotMet, i, err)
+		}
+	}
+
+	for i, rec := range o.Series.Values {
+		if matchAllClaims(claims, cond.Environment, rec.Selection) == nil {
+			return &o.Series.Values[i].Addition, i, nil
+		}
+	}
+
+	return nil, -1, ErrNoSeriesRecordSelected
+}
+
+func hasEnvironment(claims []ValueTriple, env Environment) bool {
+	for _, c := range claims {
+		if c.Environment.Match(env) == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchClaims checks that at least one of the measurements of the claims
+// whose environment matches env satisfies the reference measurement. If none
+// does, the returned error describes the last mismatch, preferring mismatches
+// of measurements with the expected key.
+func matchClaims(claims []ValueTriple, env Environment, ref Measurement) error {
+	reason := ErrEnvironmentMismatch
+
+	for _, c := range claims {
+		if c.Environment.Match(env) != nil {
+			continue
+		}
+
+		for _, m := range c.Measurements.Values {
+			err := m.MatchReference(ref)
+			if err == nil {
+				return nil
+			}
+
+			if !errors.Is(err, ErrMeasurementKeyMismatch) || errors.Is(reason, ErrEnvironmentMismatch) {
+				reason = err
+			}
+		}
+	}
+
+	return reason
+}
+
+func matchAllClaims(claims []ValueTriple, env Environment, refs Measurements) error {
+	for i, ref := range refs.Values {
+		if err := matchClaims(claims, env, ref); err != nil {
+			return fmt.Errorf("measurement at index %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
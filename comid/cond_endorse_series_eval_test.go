@@ -0,0 +1,162 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package comid
+
+import (
+	_ "embed"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testcases/comid-series.cbor
+var testComidSeries []byte
+
+func testSeriesEvidence(ver string, svn uint64, signer string) []ValueTriple {
+	m := MustNewUUIDMeasurement(TestUUID).
+		SetFlagsTrue(FlagIsConfigured).
+		SetSVN(svn)
+	m.Val.Ver = &Version{Version: ver}
+
+	if signer != "" {
+		m.AuthorizedBy = &CryptoKeys{&CryptoKey{TaggedPKIXBase64Key(signer)}}
+	}
+
+	return []ValueTriple{{
+		Environment: Environment{
+			Class: NewClassOID(TestOID).
+				SetVendor("ACME Inc.").
+				SetModel("ACME RoadRunner Firmware"),
+		},
+		Measurements: *NewMeasurements().Add(m),
+	}}
+}
+
+func Test_CondEndorseSeriesTriple_Evaluate_comid_series(t *testing.T) {
+	var c Comid
+	require.NoError(t, c.FromCBOR(testComidSeries))
+	require.NotNil(t, c.Triples.CondEndorseSeries)
+	require.Len(t, c.Triples.CondEndorseSeries.Values, 2)
+
+	first := c.Triples.CondEndorseSeries.Values[0]
+	second := c.Triples.CondEndorseSeries.Values[1]
+
+	testCases := []struct {
+		triple   CondEndorseSeriesTriple
+		claims   []ValueTriple
+		index    int
+		addition string
+	}{
+		{first, testSeriesEvidence("2.0.0", 3, "base64_key_ACME_signer"), 0, "-NO_CVE-"},
+		{first, testSeriesEvidence("1.0.0", 2, "base64_key_ACME_signer"), 1, "CVE_WARNING"},
+		{first, testSeriesEvidence("1.0.0", 1, "base64_key_ACME_signer"), 2, "CVE_VULNERABLE"},
+		// the second triple has an empty claims-list, so the authority is
+		// not checked
+		{second, testSeriesEvidence("1.0.0", 2, ""), 1, "CVE_WARNING"},
+	}
+
+	for _, tc := range testCases {
+		additions, index, err := tc.triple.Evaluate(tc.claims)
+		require.NoError(t, err)
+		assert.Equal(t, tc.index, index)
+		require.Len(t, additions.Values, 1)
+		assert.Equal(t, tc.addition, *additions.Values[0].Val.Name)
+	}
+}
+
+func Test_CondEndorseSeriesTriple_Evaluate_comid_series_NOK(t *testing.T) {
+	var c Comid
+	require.NoError(t, c.FromCBOR(testComidSeries))
+	first := c.Triples.CondEndorseSeries.Values[0]
+
+	// no record for version 3.0.0
+	_, index, err := first.Evaluate(testSeriesEvidence("3.0.0", 4, "base64_key_ACME_signer"))
+	assert.ErrorIs(t, err, ErrNoSeriesRecordSelected)
+	assert.Equal(t, -1, index)
+
+	// evidence not authorized by the ACME signer
+	_, _, err = first.Evaluate(testSeriesEvidence("2.0.0", 3, "base64_key_EVIL_signer"))
+	assert.ErrorIs(t, err, ErrSeriesConditionNotMet)
+	assert.ErrorContains(t, err, "not asserted by a required authority")
+
+	_, _, err = first.Evaluate(testSeriesEvidence("2.0.0", 3, ""))
+	assert.ErrorIs(t, err, ErrSeriesConditionNotMet)
+
+	// different environment
+	claims := testSeriesEvidence("2.0.0", 3, "base64_key_ACME_signer")
+	claims[0].Environment.Class.SetModel("ACME Coyote Firmware")
+	_, _, err = first.Evaluate(claims)
+	assert.ErrorIs(t, err, ErrSeriesConditionNotMet)
+	assert.ErrorIs(t, err, ErrEnvironmentMismatch)
+
+	_, _, err = first.Evaluate(nil)
+	assert.ErrorIs(t, err, ErrSeriesConditionNotMet)
+}
+
+func Test_CondEndorseSeriesTriple_Evaluate_comid_cond_endorse_series(t *testing.T) {
+	var c Comid
+	require.NoError(t, c.FromCBOR(testComidCondEndorseSeries))
+	require.NotNil(t, c.Triples.CondEndorseSeries)
+	triple := c.Triples.CondEndorseSeries.Values[0]
+
+	m := MustNewUUIDMeasurement(TestUUID).
+		AddDigest(Sha256, MustHexDecode(t, "44aa336af4cb14a879432e53dd6571c7fa9bccafb75f488259262d6ea3a4d91b")).
+		SetSVN(2)
+	m.AuthorizedBy = &CryptoKeys{&CryptoKey{TaggedPKIXBase64Key("base64_key_for-RIM-creator")}}
+
+	claims := []ValueTriple{{
+		Environment: Environment{
+			Class: NewClassOID("2.16.840.1.113741.1.2.3.4.1").
+				SetVendor("ACME Inc").
+				SetModel("0123456789ABCDEF"),
+		},
+		Measurements: *NewMeasurements().Add(m),
+	}}
+
+	additions, index, err := triple.Evaluate(claims)
+	require.NoError(t, err)
+	assert.Equal(t, 1, index)
+	require.Len(t, additions.Values, 1)
+	assert.Equal(t, "2.0.0", additions.Values[0].Val.Ver.Version)
+
+	claims[0].Measurements.Values[0].Val.Digests = NewDigests().AddDigest(Sha256, make([]byte, 32))
+	_, _, err = triple.Evaluate(claims)
+	assert.ErrorIs(t, err, ErrSeriesConditionNotMet)
+}
+
+func Test_CondEndorseSeriesTriple_Evaluate_extensions(t *testing.T) {
+	blue := "blue"
+	red := "red"
+	env := Environment{Class: NewClassUUID(TestUUID)}
+
+	selection := MustNewUUIDMeasurement(TestUUID)
+	selection.Val.Register(&testMvalExtension{Colour: &blue})
+
+	triple := CondEndorseSeriesTriple{
+		Condition: CondEndorseSeriesCondition{Environment: env},
+		Series: *NewCondEndorseSeriesRecords().Add(&CondEndorseSeriesRecord{
+			Selection: *NewMeasurements().Add(selection),
+			Addition:  *NewMeasurements().Add(MustNewUUIDMeasurement(TestUUID).SetName("blue")),
+		}),
+	}
+
+	claim := MustNewUUIDMeasurement(TestUUID)
+	claim.Val.Register(&testMvalExtension{Colour: &blue})
+
+	additions, index, err := triple.Evaluate([]ValueTriple{
+		{Environment: env, Measurements: *NewMeasurements().Add(claim)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, index)
+	assert.Equal(t, "blue", *additions.Values[0].Val.Name)
+
+	claim = MustNewUUIDMeasurement(TestUUID)
+	claim.Val.Register(&testMvalExtension{Colour: &red})
+
+	_, _, err = triple.Evaluate([]ValueTriple{
+		{Environment: env, Measurements: *NewMeasurements().Add(claim)},
+	})
+	assert.ErrorIs(t, err, ErrNoSeriesRecordSelected)
+}
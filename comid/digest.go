@@ -4,7 +4,11 @@
 package comid
 
 import (
+	"bytes"
 	"crypto"
+	_ "crypto/sha256" // register hash functions used by DigestAlgorithm.Compute
+	_ "crypto/sha3"
+	_ "crypto/sha512"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -75,6 +79,21 @@ var (
 		"sha3-384":    Sha3_384,
 		"sha3-512":    Sha3_512,
 	}
+
+	algToHash = map[int]crypto.Hash{
+		Sha256:     crypto.SHA256,
+		Sha256_128: crypto.SHA256,
+		Sha256_120: crypto.SHA256,
+		Sha256_96:  crypto.SHA256,
+		Sha256_64:  crypto.SHA256,
+		Sha256_32:  crypto.SHA256,
+		Sha384:     crypto.SHA384,
+		Sha512:     crypto.SHA512,
+		Sha3_224:   crypto.SHA3_224,
+		Sha3_256:   crypto.SHA3_256,
+		Sha3_384:   crypto.SHA3_384,
+		Sha3_512:   crypto.SHA3_512,
+	}
 )
 
 func IntDigestAlgorithm(val int) DigestAlgorithm {
@@ -156,6 +175,22 @@ func (o DigestAlgorithm) Int() int {
 	}
 }
 
+// Compute returns the digest of the supplied data using the algorithm. For
+// truncated algorithms (e.g. sha-256-32), the leftmost bytes of the full
+// digest are returned. An error is returned if the algorithm is not one of the
+// known hash algorithms.
+func (o DigestAlgorithm) Compute(data []byte) ([]byte, error) {
+	h, ok := algToHash[o.Int()]
+	if !ok || !h.Available() {
+		return nil, fmt.Errorf("unsupported hash algorithm %s", o.String())
+	}
+
+	hasher := h.New()
+	hasher.Write(data)
+
+	return hasher.Sum(nil)[:algToValueLen[o.Int()]], nil
+}
+
 func (o DigestAlgorithm) MarshalCBOR() ([]byte, error) {
 	return em.Marshal(o.val)
 }
@@ -269,6 +304,17 @@ func (o Digest) Valid() error {
 	return nil
 }
 
+// Matches returns true if the digest is the digest of the supplied data. An
+// error is returned if the digest algorithm is not supported.
+func (o Digest) Matches(data []byte) (bool, error) {
+	sum, err := o.Algorithm.Compute(data)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(sum, o.Value), nil
+}
+
 func (o Digest) PublicKey() (crypto.PublicKey, error) {
 	return nil, errors.New("cannot get PublicKey from a digest")
 }
@@ -274,3 +274,37 @@ func TestDigest_UnmarshalJSON_bad(t *testing.T) {
 	err = digest.UnmarshalJSON([]byte(`[1, "@@@"]`))
 	assert.ErrorContains(t, err, "val: illegal base64 data")
 }
+
+func TestDigestAlgorithm_Compute(t *testing.T) {
+	data := []byte("abc")
+
+	sum, err := IntDigestAlgorithm(Sha256).Compute(data)
+	assert.NoError(t, err)
+	assert.Equal(t, MustHexDecode(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"), sum)
+
+	sum, err = IntDigestAlgorithm(Sha256_32).Compute(data)
+	assert.NoError(t, err)
+	assert.Equal(t, MustHexDecode(t, "ba7816bf"), sum)
+
+	sum, err = StringDigestAlgorithm("sha3-256").Compute(data)
+	assert.NoError(t, err)
+	assert.Equal(t, MustHexDecode(t, "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532"), sum)
+
+	_, err = IntDigestAlgorithm(42).Compute(data)
+	assert.EqualError(t, err, "unsupported hash algorithm 42")
+}
+
+func TestDigest_Matches(t *testing.T) {
+	d := NewDigestIntAlg(Sha256_32, MustHexDecode(t, "ba7816bf"))
+
+	ok, err := d.Matches([]byte("abc"))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = d.Matches([]byte("abd"))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = NewDigestStringAlg("md5", []byte{0x00}).Matches([]byte("abc"))
+	assert.EqualError(t, err, "unsupported hash algorithm md5")
+}
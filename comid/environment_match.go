@@ -0,0 +1,231 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package comid
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"fmt"
+
+	"github.com/veraison/corim/extensions"
+)
+
+// ErrEnvironmentMismatch is wrapped by the errors returned when a claimed
+// environment is not covered by a reference environment.
+var ErrEnvironmentMismatch = errors.New("environment mismatch")
+
+// Match checks whether the target (claimed, e.g. evidence) Environment is
+// covered by the supplied reference Environment. Only the fields that are set
+// in the reference are compared, so a reference that specifies just a class
+// (or a subset of the class fields) covers any environment with a matching
+// class, whatever its instance and group. A nil error is returned on match;
+// otherwise, the returned error wraps ErrEnvironmentMismatch and describes the
+// first field that failed to match.
+//
+// See https://ietf-rats-wg.github.io/draft-ietf-rats-corim/draft-ietf-rats-corim.html#section-8.5.5
+func (o Environment) Match(ref Environment) error {
+	if ref.Class != nil {
+		if o.Class == nil {
+			return envMismatch("class", "not present in claim")
+		}
+
+		if err := o.Class.Match(*ref.Class); err != nil {
+			return err
+		}
+	}
+
+	if ref.Instance != nil {
+		if o.Instance == nil {
+			return envMismatch("instance", "not present in claim")
+		}
+
+		if err := o.Instance.Match(*ref.Instance); err != nil {
+			return err
+		}
+	}
+
+	if ref.Group != nil {
+		if o.Group == nil {
+			return envMismatch("group", "not present in claim")
+		}
+
+		if err := o.Group.Match(*ref.Group); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CompareAgainstReference returns true if the target Environment is covered by
+// the supplied reference Environment. See Match for details.
+func (o Environment) CompareAgainstReference(ref Environment) bool {
+	return o.Match(ref) == nil
+}
+
+// Match checks whether the target (claimed) Class is covered by the supplied
+// reference Class. Each of class-id, vendor, model, layer and index is only
+// compared if it is set in the reference.
+func (o Class) Match(ref Class) error {
+	if ref.ClassID != nil {
+		if o.ClassID == nil {
+			return envMismatch("class-id", "not present in claim")
+		}
+
+		if !o.ClassID.Equal(*ref.ClassID) {
+			return envMismatch("class-id", "claimed %s %q does not match reference %s %q",
+				o.ClassID.Type(), o.ClassID.String(), ref.ClassID.Type(), ref.ClassID.String())
+		}
+	}
+
+	if ref.Vendor != nil {
+		if o.Vendor == nil {
+			return envMismatch("vendor", "not present in claim")
+		}
+
+		if *o.Vendor != *ref.Vendor {
+			return envMismatch("vendor", "claimed %q does not match reference %q",
+				*o.Vendor, *ref.Vendor)
+		}
+	}
+
+	if ref.Model != nil {
+		if o.Model == nil {
+			return envMismatch("model", "not present in claim")
+		}
+
+		if *o.Model != *ref.Model {
+			return envMismatch("model", "claimed %q does not match reference %q",
+				*o.Model, *ref.Model)
+		}
+	}
+
+	if ref.Layer != nil {
+		if o.Layer == nil {
+			return envMismatch("layer", "not present in claim")
+		}
+
+		if *o.Layer != *ref.Layer {
+			return envMismatch("layer", "claimed %d does not match reference %d",
+				*o.Layer, *ref.Layer)
+		}
+	}
+
+	if ref.Index != nil {
+		if o.Index == nil {
+			return envMismatch("index", "not present in claim")
+		}
+
+		if *o.Index != *ref.Index {
+			return envMismatch("index", "claimed %d does not match reference %d",
+				*o.Index, *ref.Index)
+		}
+	}
+
+	return nil
+}
+
+// Equal returns true if the target ClassID has the same type and value as
+// the supplied one.
+func (o ClassID) Equal(r ClassID) bool {
+	return typeChoiceEqual(o.Value, r.Value)
+}
+
+// Match checks whether the target (claimed) Instance is equal to the supplied
+// reference Instance.
+func (o Instance) Match(ref Instance) error {
+	if !o.Equal(ref) {
+		return envMismatch("instance", "claimed %s does not match reference %s",
+			typeChoiceString(o.Value), typeChoiceString(ref.Value))
+	}
+
+	return nil
+}
+
+// Equal returns true if the target Instance has the same type and value as
+// the supplied one. Instances holding public keys are equal if the keys are,
+// regardless of how they are encoded.
+func (o Instance) Equal(r Instance) bool {
+	return typeChoiceEqual(o.Value, r.Value)
+}
+
+// Match checks whether the target (claimed) Group is equal to the supplied
+// reference Group.
+func (o Group) Match(ref Group) error {
+	if !o.Equal(ref) {
+		return envMismatch("group", "claimed %s does not match reference %s",
+			typeChoiceString(o.Value), typeChoiceString(ref.Value))
+	}
+
+	return nil
+}
+
+// Equal returns true if the target Group has the same type and value as the
+// supplied one.
+func (o Group) Equal(r Group) bool {
+	return typeChoiceEqual(o.Value, r.Value)
+}
+
+// typeChoiceValue is the common subset of the IClassIDValue, IInstanceValue
+// and IGroupValue interfaces.
+type typeChoiceValue interface {
+	extensions.ITypeChoiceValue
+
+	Bytes() []byte
+}
+
+type publicKeyer interface {
+	PublicKey() (crypto.PublicKey, error)
+}
+
+type publicKeyComparer interface {
+	Equal(crypto.PublicKey) bool
+}
+
+// typeChoiceEqual compares two type choice values. Values of different types
+// never match. Values that are public keys are compared by key, so that, for
+// example, differently formatted PEM encodings of the same key are equal.
+// Everything else (including certificates) is compared byte-wise.
+func typeChoiceEqual(lhs, rhs typeChoiceValue) bool {
+	if lhs == nil || rhs == nil {
+		return lhs == nil && rhs == nil
+	}
+
+	if lhs.Type() != rhs.Type() {
+		return false
+	}
+
+	if isKeyType(lhs.Type()) {
+		if lk, ok := lhs.(publicKeyer); ok {
+			if rk, ok := rhs.(publicKeyer); ok {
+				lpk, lerr := lk.PublicKey()
+				rpk, rerr := rk.PublicKey()
+				if lerr == nil && rerr == nil {
+					if cmp, ok := lpk.(publicKeyComparer); ok {
+						return cmp.Equal(rpk)
+					}
+				}
+			}
+		}
+	}
+
+	return bytes.Equal(lhs.Bytes(), rhs.Bytes())
+}
+
+func isKeyType(typ string) bool {
+	return typ == PKIXBase64KeyType || typ == COSEKeyType
+}
+
+func typeChoiceString(v typeChoiceValue) string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	return fmt.Sprintf("%s %q", v.Type(), v.String())
+}
+
+func envMismatch(field, format string, args ...any) error {
+	return fmt.Errorf("%w: %s: %s", ErrEnvironmentMismatch, field, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package comid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvironment_Match_OK(t *testing.T) {
+	claim := Environment{
+		Class: NewClassUUID(TestUUID).
+			SetVendor("ACME Ltd.").
+			SetModel("RoadRunner").
+			SetLayer(1).
+			SetIndex(2),
+		Instance: MustNewUEIDInstance(TestUEID),
+		Group:    MustNewUUIDGroup(TestUUID),
+	}
+
+	refs := []Environment{
+		{},
+		{Class: &Class{}},
+		{Class: NewClassUUID(TestUUID)},
+		{Class: (&Class{}).SetVendor("ACME Ltd.").SetModel("RoadRunner")},
+		{Class: (&Class{}).SetLayer(1).SetIndex(2)},
+		{Instance: MustNewUEIDInstance(TestUEID)},
+		{Group: MustNewUUIDGroup(TestUUID)},
+		claim,
+	}
+
+	for i, ref := range refs {
+		assert.NoError(t, claim.Match(ref), "ref %d", i)
+		assert.True(t, claim.CompareAgainstReference(ref), "ref %d", i)
+	}
+}
+
+func TestEnvironment_Match_NOK(t *testing.T) {
+	claim := Environment{
+		Class:    NewClassUUID(TestUUID).SetVendor("ACME Ltd.").SetLayer(1),
+		Instance: MustNewUEIDInstance(TestUEID),
+	}
+
+	testCases := []struct {
+		ref      Environment
+		expected string
+	}{
+		{
+			Environment{Class: NewClassOID(TestOID)},
+			`environment mismatch: class-id: claimed uuid "31fb5abf-023e-4992-aa4e-95f9c1503bfa" does not match reference oid "2.5.2.8192"`,
+		},
+		{
+			Environment{Class: (&Class{}).SetVendor("Wile E. Coyote")},
+			`environment mismatch: vendor: claimed "ACME Ltd." does not match reference "Wile E. Coyote"`,
+		},
+		{
+			Environment{Class: (&Class{}).SetModel("RoadRunner")},
+			`environment mismatch: model: not present in claim`,
+		},
+		{
+			Environment{Class: (&Class{}).SetLayer(2)},
+			`environment mismatch: layer: claimed 1 does not match reference 2`,
+		},
+		{
+			Environment{Class: (&Class{}).SetIndex(0)},
+			`environment mismatch: index: not present in claim`,
+		},
+		{
+			Environment{Instance: MustNewUUIDInstance(TestUUID)},
+			`environment mismatch: instance: claimed ueid "At6tvu/erQ==" does not match reference uuid "31fb5abf-023e-4992-aa4e-95f9c1503bfa"`,
+		},
+		{
+			Environment{Group: MustNewUUIDGroup(TestUUID)},
+			`environment mismatch: group: not present in claim`,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := claim.Match(tc.ref)
+		assert.ErrorIs(t, err, ErrEnvironmentMismatch)
+		assert.EqualError(t, err, tc.expected)
+		assert.False(t, claim.CompareAgainstReference(tc.ref))
+	}
+}
+
+func TestInstance_Equal(t *testing.T) {
+	// the same key, with different PEM line wrapping
+	reformattedKey := strings.ReplaceAll(TestECPubKey, "\nlLT4", "lLT4")
+
+	assert.True(t, MustNewPKIXBase64KeyInstance(TestECPubKey).Equal(
+		*MustNewPKIXBase64KeyInstance(reformattedKey)))
+	assert.True(t, MustNewBytesInstance(TestBytes).Equal(*MustNewBytesInstance(TestBytes)))
+	assert.False(t, MustNewBytesInstance(TestBytes).Equal(*MustNewBytesInstance([]byte{0x00})))
+	assert.False(t, MustNewBytesInstance(TestUUID[:]).Equal(*MustNewUUIDInstance(TestUUID)))
+
+	inst, err := newTestInstance(nil)
+	assert.NoError(t, err)
+	assert.True(t, inst.Equal(*inst))
+	assert.False(t, inst.Equal(*MustNewBytesInstance([]byte("test"))))
+	assert.False(t, inst.Equal(Instance{}))
+	assert.True(t, Instance{}.Equal(Instance{}))
+}
+
+func TestGroup_Equal(t *testing.T) {
+	assert.True(t, MustNewBytesGroup(TestBytes).Equal(*MustNewBytesGroup(TestBytes)))
+	assert.False(t, MustNewBytesGroup(TestBytes).Equal(*MustNewUUIDGroup(TestUUID)))
+}
+
+func TestClassID_Equal(t *testing.T) {
+	assert.True(t, MustNewOIDClassID(TestOID).Equal(*MustNewOIDClassID(TestOID)))
+	assert.False(t, MustNewOIDClassID(TestOID).Equal(*MustNewOIDClassID("2.5.2.8193")))
+	assert.False(t, MustNewBytesClassID(TestUUID[:]).Equal(*MustNewUUIDClassID(TestUUID)))
+}
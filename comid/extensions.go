@@ -34,6 +34,13 @@ type IMvalConstrainer interface {
 	ConstrainMval(*Mval) error
 }
 
+// IMvalComparator may be implemented by Mval extensions in order to control
+// how the extension fields of a claimed Mval are compared against a reference
+// Mval. The method is invoked on the reference's extensions.
+type IMvalComparator interface {
+	CompareMval(claim *Mval) []MvalFieldMatch
+}
+
 type IEntityConstrainer interface {
 	ConstrainEntity(*Entity) error
 }
@@ -99,6 +106,19 @@ func (o *Extensions) validMval(triples *Mval) error {
 	return nil
 }
 
+func (o *Extensions) compareMval(claim *Mval) ([]MvalFieldMatch, bool) {
+	if !o.HaveExtensions() {
+		return nil, false
+	}
+
+	ev, ok := o.IMapValue.(IMvalComparator)
+	if ok {
+		return ev.CompareMval(claim), true
+	}
+
+	return nil, false
+}
+
 func (o *Extensions) validEntity(triples *Entity) error {
 	if !o.HaveExtensions() {
 		return nil
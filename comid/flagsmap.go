@@ -82,7 +82,7 @@ func (o FlagsMap) IsEmpty() bool {
 		return false
 	}
 
-	return o.IsEmpty()
+	return o.Extensions.IsEmpty()
 }
 
 func (o *FlagsMap) AnySet() bool {
@@ -67,3 +67,18 @@ func Test_FlagsMap_Equal_False(t *testing.T) {
 
 	assert.False(t, claim.Equal(*ref))
 }
+
+func Test_FlagsMap_IsEmpty(t *testing.T) {
+	fm := NewFlagsMap()
+	assert.True(t, fm.IsEmpty())
+
+	fm.Register(&TestExtension{})
+	assert.True(t, fm.IsEmpty())
+
+	fm.SetTrue(FlagTestFlag)
+	assert.False(t, fm.IsEmpty())
+
+	fm.Clear(FlagTestFlag)
+	fm.SetFalse(FlagIsSecure)
+	assert.False(t, fm.IsEmpty())
+}
@@ -70,6 +70,16 @@ func (o Mkey) Valid() error {
 	return nil
 }
 
+// Equal returns true if the target Mkey has the same type and value as the
+// supplied one.
+func (o Mkey) Equal(r Mkey) bool {
+	if o.Value == nil || r.Value == nil {
+		return o.Value == nil && r.Value == nil
+	}
+
+	return o.Value.Type() == r.Value.Type() && o.Value.String() == r.Value.String()
+}
+
 func (o Mkey) GetKeyUint() (uint64, error) {
 	switch t := o.Value.(type) {
 	case UintMkey:
@@ -448,23 +458,7 @@ func (o Mval) MarshalJSON() ([]byte, error) {
 // nolint:gocritic,gocyclo
 func (o Mval) Valid() error {
 	// Check if no measurement values are set
-	if o.Ver == nil &&
-		o.SVN == nil &&
-		o.Digests == nil &&
-		o.Flags == nil &&
-		o.RawValue == nil &&
-		o.RawValueMask == nil &&
-		o.MACAddr == nil &&
-		o.IPAddr == nil &&
-		o.SerialNumber == nil &&
-		o.UEID == nil &&
-		o.UUID == nil &&
-		o.Name == nil &&
-		o.CryptoKeys == nil &&
-		o.IntegrityRegisters == nil &&
-		o.IntRange == nil &&
-		o.IsEmpty() {
-
+	if o.hasNoValues() {
 		return fmt.Errorf("no measurement value set")
 	}
 
@@ -498,18 +492,15 @@ func (o Mval) Valid() error {
 
 	// Validate MAC Address
 	if o.MACAddr != nil {
-		macLen := len(*o.MACAddr)
-		if macLen != 6 && macLen != 8 { // MAC address must be either 6 or 8 bytes
-			return fmt.Errorf("invalid MAC address length: expected 6 or 8 bytes, got %d", macLen)
+		if err := validMACaddr(*o.MACAddr); err != nil {
+			return err
 		}
 	}
 
 	// Validate IP Address
 	if o.IPAddr != nil {
-		ip := *o.IPAddr
-		// Must be valid IPv4 or IPv6 (i.e., .To4() != nil or .To16() != nil)
-		if ip.To4() == nil && ip.To16() == nil {
-			return fmt.Errorf("invalid IP address: %s", ip.String())
+		if err := validIPaddr(*o.IPAddr); err != nil {
+			return err
 		}
 	}
 
@@ -525,6 +516,46 @@ func (o Mval) Valid() error {
 	return o.validMval(&o)
 }
 
+// hasNoValues returns true if none of the measurement values, including the
+// extensions, are set
+// nolint:gocritic
+func (o Mval) hasNoValues() bool {
+	return o.Ver == nil &&
+		o.SVN == nil &&
+		o.Digests == nil &&
+		o.Flags == nil &&
+		o.RawValue == nil &&
+		o.RawValueMask == nil &&
+		o.MACAddr == nil &&
+		o.IPAddr == nil &&
+		o.SerialNumber == nil &&
+		o.UEID == nil &&
+		o.UUID == nil &&
+		o.Name == nil &&
+		o.CryptoKeys == nil &&
+		o.IntegrityRegisters == nil &&
+		o.IntRange == nil &&
+		o.IsEmpty()
+}
+
+func validMACaddr(a MACaddr) error {
+	// MAC address must be either 6 or 8 bytes
+	if len(a) != 6 && len(a) != 8 {
+		return fmt.Errorf("invalid MAC address length: expected 6 or 8 bytes, got %d", len(a))
+	}
+
+	return nil
+}
+
+func validIPaddr(ip net.IP) error {
+	// Must be valid IPv4 or IPv6 (i.e., .To4() != nil or .To16() != nil)
+	if ip.To4() == nil && ip.To16() == nil {
+		return fmt.Errorf("invalid IP address: %s", ip.String())
+	}
+
+	return nil
+}
+
 // Measurement stores a measurement-map with CBOR and JSON serializations.
 type Measurement struct {
 	Key          *Mkey       `cbor:"0,keyasint,omitempty" json:"key,omitempty"`
@@ -611,17 +642,24 @@ func (o Measurement) GetExtensions() extensions.IMapValue {
 }
 
 func (o *Measurement) SetVersion(ver string, scheme int64) *Measurement {
-	if o != nil {
-		v := NewVersion().SetVersion(ver).SetScheme(scheme)
-		if v == nil {
-			return nil
-		}
-
-		o.Val.Ver = v
+	if o != nil && o.setVersion(ver, scheme) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *Measurement) setVersion(ver string, scheme int64) error {
+	v := NewVersion().SetVersion(ver)
+
+	if err := v.setScheme(scheme); err != nil {
+		return fmt.Errorf("invalid version scheme: %w", err)
+	}
+
+	o.Val.Ver = v
+
+	return nil
+}
+
 // SetRawValueBytes sets the supplied raw-value and its mask in the
 // measurement-values-map of the target measurement
 func (o *Measurement) SetRawValueBytes(rawValue, rawValueMask []byte) *Measurement {
@@ -669,16 +707,22 @@ func (o *Measurement) AddDigest(algID int, digest []byte) *Measurement {
 // AddCryptoKey adds the supplied CryptoKey to the measurement-values-map of the
 // target measurement
 func (o *Measurement) AddCryptoKey(key *CryptoKey) *Measurement {
-	if o != nil {
-		ck := o.Val.CryptoKeys
-		if ck == nil {
-			ck = NewCryptoKeys()
-		}
-		o.Val.CryptoKeys = ck.Add(key)
+	if o != nil && o.addCryptoKey(key) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *Measurement) addCryptoKey(key *CryptoKey) error {
+	ck := o.Val.CryptoKeys
+	if ck == nil {
+		ck = NewCryptoKeys()
+	}
+	o.Val.CryptoKeys = ck.Add(key)
+
+	return nil
+}
+
 // SetFlagsTrue sets the supplied operational flags to true in the
 // measurement-values-map of the target measurement
 func (o *Measurement) SetFlagsTrue(flags ...Flag) *Measurement {
@@ -726,12 +770,18 @@ func (o *Measurement) ClearFlags(flags ...Flag) *Measurement {
 // SetIPaddr sets the supplied IP (v4 or v6) address in the
 // measurement-values-map of the target measurement
 func (o *Measurement) SetIPaddr(a net.IP) *Measurement {
-	if o != nil {
-		o.Val.IPAddr = &a
+	if o != nil && o.setIPaddr(a) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *Measurement) setIPaddr(a net.IP) error {
+	o.Val.IPAddr = &a
+
+	return nil
+}
+
 // SetMACaddr sets the supplied MAC address in the measurement-values-map of the
 // target measurement
 func (o *Measurement) SetMACaddr(a MACaddr) *Measurement {
@@ -753,27 +803,41 @@ func (o *Measurement) SetSerialNumber(sn string) *Measurement {
 // SetUEID sets the supplied ueid in the measurement-values-map
 // of the target measurement
 func (o *Measurement) SetUEID(ueid eat.UEID) *Measurement {
-	if o != nil {
-		if ueid.Validate() != nil {
-			return nil
-		}
-		o.Val.UEID = &ueid
+	if o != nil && o.setUEID(ueid) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *Measurement) setUEID(ueid eat.UEID) error {
+	if err := ueid.Validate(); err != nil {
+		return fmt.Errorf("invalid UEID: %w", err)
+	}
+
+	o.Val.UEID = &ueid
+
+	return nil
+}
+
 // SetUUID sets the supplied uuid in the measurement-values-map
 // of the target measurement
 func (o *Measurement) SetUUID(u UUID) *Measurement {
-	if o != nil {
-		if u.Valid() != nil {
-			return nil
-		}
-		o.Val.UUID = &u
+	if o != nil && o.setUUID(u) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *Measurement) setUUID(u UUID) error {
+	if err := u.Valid(); err != nil {
+		return fmt.Errorf("invalid UUID: %w", err)
+	}
+
+	o.Val.UUID = &u
+
+	return nil
+}
+
 // SetName sets the supplied name string in the measurement-values-map of the
 // target measurement
 func (o *Measurement) SetName(name string) *Measurement {
@@ -494,6 +494,14 @@ func TestMkey_UintMkey(t *testing.T) {
 	assert.EqualValues(t, 7, ret)
 }
 
+func TestMkey_Equal(t *testing.T) {
+	assert.True(t, MustNewMkey(uint64(7), UintType).Equal(*MustNewMkey(uint64(7), UintType)))
+	assert.False(t, MustNewMkey(uint64(7), UintType).Equal(*MustNewMkey(uint64(8), UintType)))
+	assert.False(t, MustNewMkey(uint64(7), UintType).Equal(*MustNewMkey("7", StringType)))
+	assert.False(t, MustNewMkey("foo", StringType).Equal(Mkey{}))
+	assert.True(t, Mkey{}.Equal(Mkey{}))
+}
+
 func TestMval_Valid(t *testing.T) {
 	t.Run("No fields set", func(t *testing.T) {
 		mval := Mval{}
@@ -0,0 +1,494 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package comid
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MvalFieldMatch records the outcome of comparing a single entry of a claimed
+// measurement-values-map against the corresponding entry of a reference
+// measurement-values-map.
+type MvalFieldMatch struct {
+	// Field is the JSON name of the measurement-values-map entry (e.g.
+	// "digests"). Extension fields use the JSON name of the extension.
+	Field string
+	// Matched is true if the claimed value satisfies the reference value.
+	Matched bool
+	// Reason is a human-readable explanation of why the comparison failed.
+	// It is empty for matching fields.
+	Reason string
+}
+
+// String returns a printable representation of the field match outcome.
+func (o MvalFieldMatch) String() string {
+	if o.Matched {
+		return fmt.Sprintf("%s: matched", o.Field)
+	}
+
+	return fmt.Sprintf("%s: %s", o.Field, o.Reason)
+}
+
+// MvalMatchResult is the structured outcome of comparing a claimed Mval against
+// a reference Mval. Only the entries that are set in the reference are
+// compared; entries that are present only in the claim are ignored.
+type MvalMatchResult struct {
+	Fields []MvalFieldMatch
+}
+
+// Matched returns true if every compared field matched.
+func (o MvalMatchResult) Matched() bool {
+	for _, f := range o.Fields {
+		if !f.Matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Failures returns the fields that did not match.
+func (o MvalMatchResult) Failures() []MvalFieldMatch {
+	var ret []MvalFieldMatch
+
+	for _, f := range o.Fields {
+		if !f.Matched {
+			ret = append(ret, f)
+		}
+	}
+
+	return ret
+}
+
+// Err returns nil if the result is a match, or an error summarizing the
+// fields that failed to match.
+func (o MvalMatchResult) Err() error {
+	failures := o.Failures()
+	if len(failures) == 0 {
+		return nil
+	}
+
+	reasons := make([]string, 0, len(failures))
+	for _, f := range failures {
+		reasons = append(reasons, f.String())
+	}
+
+	return fmt.Errorf("measurement values mismatch: %s", strings.Join(reasons, "; "))
+}
+
+func (o *MvalMatchResult) add(field string, matched bool, reason string) {
+	if matched {
+		reason = ""
+	}
+
+	o.Fields = append(o.Fields, MvalFieldMatch{Field: field, Matched: matched, Reason: reason})
+}
+
+func (o *MvalMatchResult) missing(field string) {
+	o.add(field, false, "not present in claim")
+}
+
+// CompareAgainstReference checks whether the target (claimed, e.g. evidence)
+// Mval satisfies the supplied reference Mval, and returns a structured account
+// of the comparison of each field set in the reference.
+//
+// The comparison rules follow the CoRIM spec, see:
+// https://ietf-rats-wg.github.io/draft-ietf-rats-corim/draft-ietf-rats-corim.html#section-8.5.6
+//
+// If the reference Mval has extensions that implement IMvalComparator, they
+// are used to compare the extension fields. Otherwise, every extension field
+// set in the reference must be equal to the same field in the claim.
+// nolint:gocritic,gocyclo
+func (o Mval) CompareAgainstReference(ref Mval) MvalMatchResult {
+	var res MvalMatchResult
+
+	if ref.Ver != nil {
+		if o.Ver == nil {
+			res.missing("version")
+		} else {
+			err := o.Ver.MatchReference(*ref.Ver)
+			res.add("version", err == nil, fmt.Sprint(err))
+		}
+	}
+
+	if ref.SVN != nil {
+		if o.SVN == nil {
+			res.missing("svn")
+		} else {
+			ok, reason := matchSVN(*o.SVN, *ref.SVN)
+			res.add("svn", ok, reason)
+		}
+	}
+
+	if ref.Digests != nil {
+		if o.Digests == nil {
+			res.missing("digests")
+		} else {
+			res.add("digests", o.Digests.CompareAgainstReference(*ref.Digests),
+				"no claimed digest matches the reference digests")
+		}
+	}
+
+	if ref.Flags != nil && ref.Flags.AnySet() {
+		if o.Flags == nil {
+			res.missing("flags")
+		} else {
+			ok, reason := matchFlags(*o.Flags, *ref.Flags)
+			res.add("flags", ok, reason)
+		}
+	}
+
+	if ref.RawValue != nil {
+		if o.RawValue == nil {
+			res.missing("raw-value")
+		} else {
+			ok, reason := matchRawValue(*o.RawValue, *ref.RawValue, ref.RawValueMask)
+			res.add("raw-value", ok, reason)
+		}
+	}
+
+	if ref.MACAddr != nil {
+		if o.MACAddr == nil {
+			res.missing("mac-addr")
+		} else {
+			res.add("mac-addr", o.MACAddr.CompareAgainstReference(*ref.MACAddr),
+				"claimed MAC address does not match reference")
+		}
+	}
+
+	if ref.IPAddr != nil {
+		if o.IPAddr == nil {
+			res.missing("ip-addr")
+		} else {
+			res.add("ip-addr", o.IPAddr.Equal(*ref.IPAddr),
+				fmt.Sprintf("claimed IP address %s does not match reference %s",
+					o.IPAddr, ref.IPAddr))
+		}
+	}
+
+	if ref.SerialNumber != nil {
+		if o.SerialNumber == nil {
+			res.missing("serial-number")
+		} else {
+			res.add("serial-number", *o.SerialNumber == *ref.SerialNumber,
+				fmt.Sprintf("claimed serial number %q does not match reference %q",
+					*o.SerialNumber, *ref.SerialNumber))
+		}
+	}
+
+	if ref.UEID != nil {
+		if o.UEID == nil {
+			res.missing("ueid")
+		} else {
+			res.add("ueid", bytes.Equal(*o.UEID, *ref.UEID),
+				fmt.Sprintf("claimed UEID %x does not match reference %x",
+					[]byte(*o.UEID), []byte(*ref.UEID)))
+		}
+	}
+
+	if ref.UUID != nil {
+		if o.UUID == nil {
+			res.missing("uuid")
+		} else {
+			res.add("uuid", *o.UUID == *ref.UUID,
+				fmt.Sprintf("claimed UUID %s does not match reference %s",
+					o.UUID, ref.UUID))
+		}
+	}
+
+	if ref.Name != nil {
+		if o.Name == nil {
+			res.missing("name")
+		} else {
+			res.add("name", *o.Name == *ref.Name,
+				fmt.Sprintf("claimed name %q does not match reference %q",
+					*o.Name, *ref.Name))
+		}
+	}
+
+	if ref.CryptoKeys != nil {
+		if o.CryptoKeys == nil {
+			res.missing("cryptokeys")
+		} else {
+			ok, reason := matchCryptoKeys(*o.CryptoKeys, *ref.CryptoKeys)
+			res.add("cryptokeys", ok, reason)
+		}
+	}
+
+	if ref.IntegrityRegisters != nil {
+		if o.IntegrityRegisters == nil {
+			res.missing("integrity-registers")
+		} else {
+			res.add("integrity-registers",
+				o.IntegrityRegisters.CompareAgainstReference(*ref.IntegrityRegisters),
+				"claimed integrity registers do not match reference")
+		}
+	}
+
+	if ref.IntRange != nil {
+		if o.IntRange == nil {
+			res.missing("int-range")
+		} else {
+			ok, reason := matchRawInt(*o.IntRange, *ref.IntRange)
+			res.add("int-range", ok, reason)
+		}
+	}
+
+	if !ref.IsEmpty() {
+		fields, ok := ref.compareMval(&o)
+		if !ok {
+			fields = matchExtensions(&o.Extensions, &ref.Extensions)
+		}
+		res.Fields = append(res.Fields, fields...)
+	}
+
+	return res
+}
+
+func matchSVN(claim, ref SVN) (bool, string) {
+	claimVal, claimMin, ok := svnValue(claim.Value)
+	if !ok {
+		return reflect.DeepEqual(claim.Value, ref.Value),
+			fmt.Sprintf("claimed SVN %v does not match reference %v", claim.Value, ref.Value)
+	}
+
+	refVal, refMin, ok := svnValue(ref.Value)
+	if !ok {
+		return false, fmt.Sprintf("unsupported reference SVN type %s", ref.Value.Type())
+	}
+
+	if refMin {
+		return claimVal >= refVal,
+			fmt.Sprintf("claimed svn %d is lower than reference min-svn %d", claimVal, refVal)
+	}
+
+	if claimMin {
+		return false, fmt.Sprintf("claimed min-svn %d cannot satisfy exact reference svn %d",
+			claimVal, refVal)
+	}
+
+	return claimVal == refVal,
+		fmt.Sprintf("claimed svn %d does not equal reference svn %d", claimVal, refVal)
+}
+
+// svnValue extracts the numeric value of an SVN, and whether it is a minimum
+// value. ok is false for unrecognized SVN types.
+func svnValue(v ISVNValue) (val uint64, isMin bool, ok bool) {
+	switch t := v.(type) {
+	case TaggedSVN:
+		return uint64(t), false, true
+	case *TaggedSVN:
+		return uint64(*t), false, true
+	case TaggedMinSVN:
+		return uint64(t), true, true
+	case *TaggedMinSVN:
+		return uint64(*t), true, true
+	default:
+		return 0, false, false
+	}
+}
+
+// matchFlags checks that each operational flag that is set in the reference
+// has the same value in the claim. Flags that are unset in the reference are
+// not compared. Extension flags are compared for equality.
+// nolint:gocritic
+func matchFlags(claim, ref FlagsMap) (bool, string) {
+	for flag := FlagIsConfigured; flag <= FlagIsConfidentialityProtected; flag++ {
+		refVal := ref.Get(flag)
+		if refVal == nil {
+			continue
+		}
+
+		claimVal := claim.Get(flag)
+		if claimVal == nil {
+			return false, fmt.Sprintf("flag %d not present in claim", flag)
+		}
+
+		if *claimVal != *refVal {
+			return false, fmt.Sprintf("flag %d: claimed %t, reference %t", flag, *claimVal, *refVal)
+		}
+	}
+
+	if !ref.Extensions.IsEmpty() {
+		for _, f := range matchExtensions(&claim.Extensions, &ref.Extensions) {
+			if !f.Matched {
+				return false, fmt.Sprintf("flag %s", f)
+			}
+		}
+	}
+
+	return true, ""
+}
+
+func matchRawValue(claim, ref RawValue, legacyMask *[]byte) (bool, string) {
+	mask := ref.Mask()
+	if mask == nil && legacyMask != nil {
+		mask = *legacyMask
+	}
+
+	ok, err := maskedEqual(claim.Bytes(), claim.Mask(), ref.Bytes(), mask)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	return ok, "claimed raw value does not match reference"
+}
+
+// matchCryptoKeys checks that every key in the reference is present (same type
+// and same bytes) among the claimed keys.
+func matchCryptoKeys(claim, ref CryptoKeys) (bool, string) {
+outer:
+	for i, rk := range ref {
+		for _, ck := range claim {
+			if cryptoKeyEqual(ck, rk) {
+				continue outer
+			}
+		}
+
+		return false, fmt.Sprintf("reference key at index %d not found in claim", i)
+	}
+
+	return true, ""
+}
+
+func matchRawInt(claim, ref RawInt) (bool, string) {
+	var ok bool
+
+	switch r := ref.Value.(type) {
+	case *RawIntInteger:
+		ok = rawIntAgainstInteger(claim, *r)
+	case RawIntInteger:
+		ok = rawIntAgainstInteger(claim, r)
+	case *TaggedRawIntRange:
+		ok = rawIntAgainstRange(claim, *r)
+	case TaggedRawIntRange:
+		ok = rawIntAgainstRange(claim, r)
+	default:
+		return false, fmt.Sprintf("unsupported reference int-range type %s", ref.Type())
+	}
+
+	return ok, fmt.Sprintf("claimed %s does not satisfy reference %s", claim, ref)
+}
+
+func rawIntAgainstInteger(claim RawInt, ref RawIntInteger) bool {
+	switch c := claim.Value.(type) {
+	case *RawIntInteger:
+		return c.CompareAgainstRefInteger(ref)
+	case RawIntInteger:
+		return c.CompareAgainstRefInteger(ref)
+	case *TaggedRawIntRange:
+		return c.CompareAgainstRefInteger(ref)
+	case TaggedRawIntRange:
+		return c.CompareAgainstRefInteger(ref)
+	default:
+		return false
+	}
+}
+
+func rawIntAgainstRange(claim RawInt, ref TaggedRawIntRange) bool {
+	switch c := claim.Value.(type) {
+	case *RawIntInteger:
+		return c.CompareAgainstRefRange(ref)
+	case RawIntInteger:
+		return c.CompareAgainstRefRange(ref)
+	case *TaggedRawIntRange:
+		return c.CompareAgainstRefRange(ref)
+	case TaggedRawIntRange:
+		return c.CompareAgainstRefRange(ref)
+	default:
+		return false
+	}
+}
+
+// matchExtensions is the default comparison for extensions that do not
+// provide their own comparator: every extension field set in the reference
+// must be present, and equal, in the claim.
+func matchExtensions(claim, ref *Extensions) []MvalFieldMatch {
+	var ret []MvalFieldMatch
+
+	for _, rv := range ref.Values() {
+		if reflect.ValueOf(rv.Value).IsZero() {
+			continue
+		}
+
+		name := rv.JSONTag
+		if name == "" {
+			name = rv.FieldName
+		}
+
+		cv, err := claim.Get(rv.FieldName)
+		if err != nil || reflect.ValueOf(cv).IsZero() {
+			ret = append(ret, MvalFieldMatch{Field: name, Reason: "not present in claim"})
+			continue
+		}
+
+		if !reflect.DeepEqual(cv, rv.Value) {
+			ret = append(ret, MvalFieldMatch{Field: name, Reason: "claimed value does not match reference"})
+			continue
+		}
+
+		ret = append(ret, MvalFieldMatch{Field: name, Matched: true})
+	}
+
+	return ret
+}
+
+// ErrMeasurementKeyMismatch is wrapped by the error returned by
+// Measurement.MatchReference when the claimed and reference keys differ.
+var ErrMeasurementKeyMismatch = errors.New("measurement key mismatch")
+
+// MatchReference checks whether the target (claimed) Measurement satisfies the
+// supplied reference Measurement. If the reference has a key, the claim must
+// have the same key. If the reference has authorized-by keys, the claim must
+// have been authorized by at least one of them.
+func (o Measurement) MatchReference(ref Measurement) error {
+	if ref.Key != nil && ref.Key.IsSet() {
+		if o.Key == nil || !o.Key.Equal(*ref.Key) {
+			return fmt.Errorf("%w: claimed %s, reference %s",
+				ErrMeasurementKeyMismatch, mkeyString(o.Key), mkeyString(ref.Key))
+		}
+	}
+
+	if err := o.Val.CompareAgainstReference(ref.Val).Err(); err != nil {
+		return err
+	}
+
+	if ref.AuthorizedBy != nil {
+		if o.AuthorizedBy == nil || !containsAnyCryptoKey(*o.AuthorizedBy, *ref.AuthorizedBy) {
+			return errors.New("claim not asserted by a required authority")
+		}
+	}
+
+	return nil
+}
+
+func mkeyString(k *Mkey) string {
+	if k == nil || !k.IsSet() {
+		return "<none>"
+	}
+
+	return fmt.Sprintf("%s %q", k.Type(), k.Value.String())
+}
+
+func cryptoKeyEqual(lhs, rhs *CryptoKey) bool {
+	return lhs.Type() == rhs.Type() && bytes.Equal(lhs.Value.Bytes(), rhs.Value.Bytes())
+}
+
+// containsAnyCryptoKey returns true if any of the wanted keys is among the
+// keys we have.
+func containsAnyCryptoKey(have, want CryptoKeys) bool {
+	for _, w := range want {
+		for _, h := range have {
+			if cryptoKeyEqual(h, w) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package comid
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMvalExtension struct {
+	Colour *string `cbor:"-1,keyasint,omitempty" json:"colour,omitempty"`
+}
+
+type testMvalComparator struct {
+	Size *uint64 `cbor:"-2,keyasint,omitempty" json:"size,omitempty"`
+}
+
+func (o *testMvalComparator) CompareMval(claim *Mval) []MvalFieldMatch {
+	size, err := claim.GetUint64("size")
+	if err != nil {
+		return []MvalFieldMatch{{Field: "size", Reason: "not present in claim"}}
+	}
+
+	if size > *o.Size {
+		return []MvalFieldMatch{{Field: "size", Reason: "too big"}}
+	}
+
+	return []MvalFieldMatch{{Field: "size", Matched: true}}
+}
+
+func TestMval_CompareAgainstReference_empty_ref(t *testing.T) {
+	claim := Mval{Name: &TestTagID}
+
+	res := claim.CompareAgainstReference(Mval{})
+	assert.True(t, res.Matched())
+	assert.Empty(t, res.Fields)
+	assert.NoError(t, res.Err())
+}
+
+func TestMval_CompareAgainstReference_OK(t *testing.T) {
+	ipAddr := net.ParseIP("2001:db8::68")
+	ver := Version{Version: "1.2.3"}
+	name := "foo"
+	serial := "C02X70VHJHD5"
+
+	ref := Mval{
+		Ver:          &ver,
+		SVN:          MustNewTaggedMinSVN(2),
+		Digests:      NewDigests().AddDigest(Sha256_32, []byte{0xde, 0xad, 0xbe, 0xef}),
+		Flags:        newTestFlagsMap(FlagIsSecure, nil),
+		RawValue:     MustNewMaskedRawValue([][]byte{{0x01, 0x02}, {0xff, 0x00}}),
+		MACAddr:      (*MACaddr)(&TestMACaddr),
+		IPAddr:       &TestIPaddr,
+		SerialNumber: &serial,
+		UEID:         &TestUEID,
+		UUID:         &TestUUID,
+		Name:         &name,
+		CryptoKeys:   &CryptoKeys{MustNewPKIXBase64Key(TestECPubKey)},
+		IntRange:     &RawInt{Value: &TaggedRawIntRange{Min: int64Ptr(-1), Max: int64Ptr(10)}},
+	}
+
+	claimSerial := serial
+	claim := Mval{
+		Ver:          &ver,
+		SVN:          MustNewTaggedSVN(3),
+		Digests:      NewDigests().AddDigest(Sha256_32, []byte{0xde, 0xad, 0xbe, 0xef}),
+		Flags:        newTestFlagsMap(FlagIsSecure, []Flag{FlagIsDebug}),
+		RawValue:     MustNewRawValue([]byte{0x01, 0x99}, BytesType),
+		MACAddr:      (*MACaddr)(&TestMACaddr),
+		IPAddr:       &ipAddr,
+		SerialNumber: &claimSerial,
+		UEID:         &TestUEID,
+		UUID:         &TestUUID,
+		Name:         &name,
+		CryptoKeys: &CryptoKeys{
+			MustNewCryptoKeyTaggedBytes(TestBytes),
+			MustNewPKIXBase64Key(TestECPubKey),
+		},
+		IntRange: &RawInt{Value: RawIntInteger(5)},
+	}
+
+	res := claim.CompareAgainstReference(ref)
+	assert.True(t, res.Matched(), res.Err())
+	assert.Len(t, res.Fields, 13)
+	assert.Empty(t, res.Failures())
+}
+
+func TestMval_CompareAgainstReference_NOK(t *testing.T) {
+	otherName := "bar"
+	name := "foo"
+
+	ref := Mval{
+		SVN:      MustNewTaggedSVN(2),
+		Digests:  NewDigests().AddDigest(Sha256_32, []byte{0xde, 0xad, 0xbe, 0xef}),
+		Flags:    newTestFlagsMap(FlagIsSecure, nil),
+		RawValue: MustNewRawValue([]byte{0x01, 0x02}, BytesType),
+		Name:     &name,
+		UUID:     &TestUUID,
+		IntRange: &RawInt{Value: RawIntInteger(5)},
+	}
+
+	claim := Mval{
+		SVN:      MustNewTaggedMinSVN(2),
+		Digests:  NewDigests().AddDigest(Sha256_32, []byte{0xba, 0xdb, 0xad, 0x00}),
+		Flags:    newTestFlagsMap(FlagIsDebug, []Flag{FlagIsSecure}),
+		RawValue: MustNewRawValue([]byte{0x01, 0x03}, BytesType),
+		Name:     &otherName,
+		IntRange: &RawInt{Value: &TaggedRawIntRange{Min: int64Ptr(4), Max: int64Ptr(6)}},
+	}
+
+	res := claim.CompareAgainstReference(ref)
+	assert.False(t, res.Matched())
+
+	failures := res.Failures()
+	require.Len(t, failures, 7)
+
+	var fields []string
+	for _, f := range failures {
+		fields = append(fields, f.Field)
+		assert.NotEmpty(t, f.Reason)
+	}
+	assert.Equal(t, []string{
+		"svn", "digests", "flags", "raw-value", "uuid", "name", "int-range",
+	}, fields)
+
+	assert.Equal(t, "claimed min-svn 2 cannot satisfy exact reference svn 2", failures[0].Reason)
+	assert.Equal(t, "not present in claim", failures[4].Reason)
+	assert.ErrorContains(t, res.Err(), "uuid: not present in claim")
+}
+
+func TestMval_CompareAgainstReference_svn(t *testing.T) {
+	testCases := []struct {
+		claim    *SVN
+		ref      *SVN
+		expected bool
+	}{
+		{MustNewTaggedSVN(2), MustNewTaggedSVN(2), true},
+		{MustNewTaggedSVN(3), MustNewTaggedSVN(2), false},
+		{MustNewTaggedSVN(2), MustNewTaggedMinSVN(2), true},
+		{MustNewTaggedSVN(1), MustNewTaggedMinSVN(2), false},
+		{MustNewTaggedMinSVN(3), MustNewTaggedMinSVN(2), true},
+		{MustNewTaggedMinSVN(1), MustNewTaggedMinSVN(2), false},
+		{MustNewTaggedMinSVN(2), MustNewTaggedSVN(2), false},
+	}
+
+	for _, tc := range testCases {
+		claim := Mval{SVN: tc.claim}
+		res := claim.CompareAgainstReference(Mval{SVN: tc.ref})
+		assert.Equal(t, tc.expected, res.Matched(), "claim %s %v, ref %s %v",
+			tc.claim.Value.Type(), tc.claim.Value, tc.ref.Value.Type(), tc.ref.Value)
+	}
+}
+
+func TestMval_CompareAgainstReference_extensions(t *testing.T) {
+	blue := "blue"
+	red := "red"
+
+	var ref, claim Mval
+	ref.Register(&testMvalExtension{Colour: &blue})
+	claim.Register(&testMvalExtension{Colour: &blue})
+
+	res := claim.CompareAgainstReference(ref)
+	assert.True(t, res.Matched())
+	assert.Equal(t, []MvalFieldMatch{{Field: "colour", Matched: true}}, res.Fields)
+
+	var other Mval
+	other.Register(&testMvalExtension{Colour: &red})
+
+	res = other.CompareAgainstReference(ref)
+	assert.False(t, res.Matched())
+	assert.Equal(t, "colour", res.Failures()[0].Field)
+
+	res = Mval{}.CompareAgainstReference(ref)
+	assert.False(t, res.Matched())
+	assert.Equal(t, "not present in claim", res.Failures()[0].Reason)
+}
+
+func TestMval_CompareAgainstReference_extension_comparator(t *testing.T) {
+	var ref, claim Mval
+	ref.Register(&testMvalComparator{Size: uint64Ptr(10)})
+	claim.Register(&testMvalComparator{Size: uint64Ptr(7)})
+
+	res := claim.CompareAgainstReference(ref)
+	assert.True(t, res.Matched())
+
+	var other Mval
+	other.Register(&testMvalComparator{Size: uint64Ptr(11)})
+
+	res = other.CompareAgainstReference(ref)
+	assert.False(t, res.Matched())
+	assert.EqualError(t, res.Err(), "measurement values mismatch: size: too big")
+}
+
+func TestMval_CompareAgainstReference_extension_flags_only(t *testing.T) {
+	refFlags := NewFlagsMap()
+	refFlags.Register(&TestExtension{})
+	refFlags.SetTrue(FlagTestFlag)
+
+	claimFlags := NewFlagsMap()
+	claimFlags.Register(&TestExtension{})
+	claimFlags.SetTrue(FlagTestFlag)
+
+	ref := Mval{Flags: refFlags}
+
+	res := Mval{Flags: claimFlags}.CompareAgainstReference(ref)
+	assert.True(t, res.Matched())
+
+	claimFlags.SetFalse(FlagTestFlag)
+
+	res = Mval{Flags: claimFlags}.CompareAgainstReference(ref)
+	assert.False(t, res.Matched())
+	assert.Equal(t, "flags", res.Failures()[0].Field)
+}
+
+func newTestFlagsMap(set Flag, unset []Flag) *FlagsMap {
+	ret := NewFlagsMap()
+	ret.SetTrue(set)
+	ret.SetFalse(unset...)
+
+	return ret
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
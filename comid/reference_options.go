@@ -0,0 +1,28 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package comid
+
+import "fmt"
+
+// ReferenceOptions controls the generation of a CoMID with reference values
+// from golden measurements, such as DICE TCB infos or SPDM measurement blocks
+type ReferenceOptions struct {
+	// TagID and TagVersion are the tag identity of the generated CoMID. TagID
+	// must be a string, a UUID or its bytes (see Comid.SetTagIdentity).
+	TagID      any
+	TagVersion uint
+	// MinSVN sets security version numbers as min-svn rather than as exact
+	// svn, so that later versions of the firmware are also accepted
+	MinSVN bool
+}
+
+// NewComid returns an empty CoMID with the tag identity set in the options
+func (o ReferenceOptions) NewComid() (*Comid, error) {
+	c := NewComid().SetTagIdentity(o.TagID, o.TagVersion)
+	if c == nil {
+		return nil, fmt.Errorf("invalid tag ID: %v (%T)", o.TagID, o.TagID)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,21 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package comid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferenceOptions_NewComid(t *testing.T) {
+	c, err := ReferenceOptions{TagID: TestTagID, TagVersion: 2}.NewComid()
+	require.NoError(t, err)
+	assert.Equal(t, TestTagID, c.TagIdentity.TagID.String())
+	assert.Equal(t, uint(2), c.TagIdentity.TagVersion)
+
+	_, err = ReferenceOptions{TagID: 1}.NewComid()
+	assert.EqualError(t, err, "invalid tag ID: 1 (int)")
+}
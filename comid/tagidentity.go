@@ -6,6 +6,7 @@ package comid
 import (
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/veraison/swid"
 )
 
@@ -21,3 +22,30 @@ func (o TagIdentity) Valid() error {
 
 	return nil
 }
+
+// ParseTagID is like swid.NewTagID, but returns an error explaining why the
+// supplied value cannot be used as a tag-id. The value can be a UUID in string
+// or binary form, or a (non-empty) string.
+func ParseTagID(v interface{}) (*swid.TagID, error) {
+	switch t := v.(type) {
+	case string:
+		if id, err := swid.NewTagIDFromUUIDString(t); err == nil {
+			return id, nil
+		}
+		id, err := swid.NewTagIDFromString(t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag-id: %w", err)
+		}
+		return id, nil
+	case []byte:
+		id, err := swid.NewTagIDFromUUIDBytes(t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag-id: %w", err)
+		}
+		return id, nil
+	case uuid.UUID:
+		return swid.NewTagID(t), nil
+	default:
+		return nil, fmt.Errorf("invalid tag-id: unsupported type %T", v)
+	}
+}
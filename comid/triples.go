@@ -262,15 +262,7 @@ func (o *Triples) IterDevIdentityKeys() iter.Seq[*KeyTriple] {
 // nolint:gocyclo
 func (o *Triples) Valid() error {
 	// non-empty<>
-	if (o.ReferenceValues == nil || o.ReferenceValues.IsEmpty()) &&
-		(o.EndorsedValues == nil || o.EndorsedValues.IsEmpty()) &&
-		(o.AttestVerifKeys == nil || len(*o.AttestVerifKeys) == 0) &&
-		(o.DevIdentityKeys == nil || len(*o.DevIdentityKeys) == 0) &&
-		(o.DomainDependencies == nil || o.DomainDependencies.IsEmpty()) &&
-		(o.DomainMemberships == nil || o.DomainMemberships.IsEmpty()) &&
-		(o.CoswidTriples == nil || o.CoswidTriples.IsEmpty()) &&
-		(o.CondEndorseSeries == nil || o.CondEndorseSeries.IsEmpty()) &&
-		(o.CondEndorsements == nil || o.CondEndorsements.IsEmpty()) {
+	if o.isEmpty() {
 		return fmt.Errorf("triples struct must not be empty")
 	}
 
@@ -335,6 +327,19 @@ func (o *Triples) Valid() error {
 	return o.validTriples(o)
 }
 
+// isEmpty returns true if none of the triples are set
+func (o *Triples) isEmpty() bool {
+	return (o.ReferenceValues == nil || o.ReferenceValues.IsEmpty()) &&
+		(o.EndorsedValues == nil || o.EndorsedValues.IsEmpty()) &&
+		(o.AttestVerifKeys == nil || len(*o.AttestVerifKeys) == 0) &&
+		(o.DevIdentityKeys == nil || len(*o.DevIdentityKeys) == 0) &&
+		(o.DomainDependencies == nil || o.DomainDependencies.IsEmpty()) &&
+		(o.DomainMemberships == nil || o.DomainMemberships.IsEmpty()) &&
+		(o.CoswidTriples == nil || o.CoswidTriples.IsEmpty()) &&
+		(o.CondEndorseSeries == nil || o.CondEndorseSeries.IsEmpty()) &&
+		(o.CondEndorsements == nil || o.CondEndorsements.IsEmpty())
+}
+
 func (o *Triples) AddReferenceValue(val *ValueTriple) *Triples {
 	if o != nil {
 		if o.ReferenceValues == nil {
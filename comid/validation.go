@@ -0,0 +1,399 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package comid
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PathElement is a step in the path from the root of a structure to one of its
+// values: a map entry or an array element.
+type PathElement struct {
+	// JSON is the JSON member name, or the array index
+	JSON string
+	// CBOR is the CBOR map key, or the array index
+	CBOR int
+}
+
+// Path locates a value inside a CoRIM, CoMID, CoEV or CoTS structure
+type Path []PathElement
+
+// JSONPointer returns the path as an RFC 6901 JSON pointer, e.g.
+// "/triples/reference-values/12/measurements/0/value/digests"
+func (o Path) JSONPointer() string {
+	var sb strings.Builder
+
+	for _, e := range o {
+		sb.WriteByte('/')
+		sb.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(e.JSON))
+	}
+
+	return sb.String()
+}
+
+// CBORPath returns the path as the sequence of CBOR map keys and array
+// indices, e.g. [4 0 12 1 0 1 2]
+func (o Path) CBORPath() []int {
+	ret := make([]int, len(o))
+
+	for i, e := range o {
+		ret[i] = e.CBOR
+	}
+
+	return ret
+}
+
+// ValidationError is a violation found at Path
+type ValidationError struct {
+	Path Path
+	Err  error
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Path) == 0 {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("%s: %v", e.Path.JSONPointer(), e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors is the list of violations found by a ValidateAll method
+type ValidationErrors []*ValidationError
+
+func (o ValidationErrors) Error() string {
+	msgs := make([]string, len(o))
+
+	for i, e := range o {
+		msgs[i] = e.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+func (o ValidationErrors) Unwrap() []error {
+	ret := make([]error, len(o))
+
+	for i, e := range o {
+		ret[i] = e
+	}
+
+	return ret
+}
+
+// Validator collects violations together with the path at which they were
+// found. Field and Index return validators for the children of the current
+// value that share the same list of violations. Profile constrainers can use
+// a Validator to report more than one violation, or to point at a value
+// inside the constrained one.
+type Validator struct {
+	path Path
+	errs *ValidationErrors
+}
+
+// NewValidator instantiates a Validator for the root of a structure
+func NewValidator() *Validator {
+	return &Validator{errs: new(ValidationErrors)}
+}
+
+// Field returns a Validator for the map entry with the supplied JSON name and
+// CBOR key
+func (o *Validator) Field(jsonName string, cborKey int) *Validator {
+	return o.child(PathElement{JSON: jsonName, CBOR: cborKey})
+}
+
+// Index returns a Validator for the array element at the supplied index
+func (o *Validator) Index(i int) *Validator {
+	return o.child(PathElement{JSON: strconv.Itoa(i), CBOR: i})
+}
+
+func (o *Validator) child(e PathElement) *Validator {
+	path := make(Path, len(o.path), len(o.path)+1)
+	copy(path, o.path)
+
+	return &Validator{path: append(path, e), errs: o.errs}
+}
+
+// Check records err, if not nil, at the current path. If err is a
+// ValidationErrors or a *ValidationError (e.g., as returned by a nested
+// ValidateAll, or by a profile constrainer), its violations are recorded
+// relative to the current path.
+func (o *Validator) Check(err error) {
+	var verrs ValidationErrors
+
+	switch t := err.(type) {
+	case nil:
+		return
+	case ValidationErrors:
+		verrs = t
+	case *ValidationError:
+		verrs = ValidationErrors{t}
+	default:
+		*o.errs = append(*o.errs, &ValidationError{Path: o.path, Err: err})
+		return
+	}
+
+	for _, e := range verrs {
+		path := make(Path, 0, len(o.path)+len(e.Path))
+		path = append(append(path, o.path...), e.Path...)
+
+		*o.errs = append(*o.errs, &ValidationError{Path: path, Err: e.Err})
+	}
+}
+
+// Err returns the violations recorded so far as a ValidationErrors, or nil if
+// there are none
+func (o *Validator) Err() error {
+	if len(*o.errs) == 0 {
+		return nil
+	}
+
+	return *o.errs
+}
+
+// ValidateAll is like Valid, except that, rather than stopping at the first
+// violation, it returns all of them as a ValidationErrors.
+// nolint:gocritic
+func (o Comid) ValidateAll() error {
+	v := NewValidator()
+
+	v.Field("tag-identity", 1).Check(o.TagIdentity.Valid())
+
+	if o.Entities != nil {
+		ev := v.Field("entities", 2)
+		for i, e := range o.Entities.Values {
+			ev.Index(i).Check(e.ValidateAll())
+		}
+	}
+
+	if o.LinkedTags != nil {
+		lv := v.Field("linked-tags", 3)
+		for i, lt := range *o.LinkedTags {
+			lv.Index(i).Check(lt.Valid())
+		}
+	}
+
+	v.Field("triples", 4).Check(o.Triples.ValidateAll())
+
+	v.Check(o.validComid(&o))
+
+	return v.Err()
+}
+
+// ValidateAll is like Valid, except that, rather than stopping at the first
+// violation, it returns all of them as a ValidationErrors.
+// nolint:gocritic
+func (o Entity) ValidateAll() error {
+	v := NewValidator()
+
+	if o.Name == nil {
+		v.Field("name", 0).Check(errors.New("empty entity-name"))
+	} else {
+		v.Field("name", 0).Check(o.Name.Valid())
+	}
+
+	if o.RegID != nil && o.RegID.Empty() {
+		v.Field("regid", 1).Check(errors.New("empty reg-id"))
+	}
+
+	v.Field("roles", 2).Check(o.Roles.Valid())
+
+	v.Check(o.validEntity(&o))
+
+	return v.Err()
+}
+
+// ValidateAll is like Valid, except that, rather than stopping at the first
+// violation, it returns all of them as a ValidationErrors.
+// nolint:gocyclo
+func (o *Triples) ValidateAll() error {
+	v := NewValidator()
+
+	if o.isEmpty() {
+		v.Check(errors.New("triples struct must not be empty"))
+	}
+
+	if o.ReferenceValues != nil {
+		rv := v.Field("reference-values", 0)
+		for i, vt := range o.ReferenceValues.Values {
+			rv.Index(i).Check(vt.ValidateAll())
+		}
+	}
+
+	if o.EndorsedValues != nil {
+		ev := v.Field("endorsed-values", 1)
+		for i, vt := range o.EndorsedValues.Values {
+			ev.Index(i).Check(vt.ValidateAll())
+		}
+	}
+
+	if o.DevIdentityKeys != nil {
+		kv := v.Field("dev-identity-keys", 2)
+		for i := range *o.DevIdentityKeys {
+			kv.Index(i).Check((*o.DevIdentityKeys)[i].ValidateAll())
+		}
+	}
+
+	if o.AttestVerifKeys != nil {
+		kv := v.Field("attester-verification-keys", 3)
+		for i := range *o.AttestVerifKeys {
+			kv.Index(i).Check((*o.AttestVerifKeys)[i].ValidateAll())
+		}
+	}
+
+	// dependency and membership triples are also checked across records, so
+	// their violations are reported against the whole array
+	if o.DomainDependencies != nil {
+		v.Field("dependency-triples", 4).Check(o.DomainDependencies.Valid())
+	}
+
+	if o.DomainMemberships != nil {
+		v.Field("membership-triples", 5).Check(o.DomainMemberships.Valid())
+	}
+
+	if o.CoswidTriples != nil {
+		cv := v.Field("coswid-triples", 6)
+		for i, t := range *o.CoswidTriples {
+			cv.Index(i).Check(t.Valid())
+		}
+	}
+
+	if o.CondEndorseSeries != nil {
+		sv := v.Field("conditional-endorsement-series", 8)
+		for i, t := range o.CondEndorseSeries.Values {
+			sv.Index(i).Check(t.Valid())
+		}
+	}
+
+	if o.CondEndorsements != nil {
+		cv := v.Field("conditional-endorsements", 10)
+		for i, t := range o.CondEndorsements.Values {
+			cv.Index(i).Check(t.Valid())
+		}
+	}
+
+	v.Check(o.validTriples(o))
+
+	return v.Err()
+}
+
+// ValidateAll is like Valid, except that, rather than stopping at the first
+// violation, it returns all of them as a ValidationErrors.
+// nolint:gocritic
+func (o ValueTriple) ValidateAll() error {
+	v := NewValidator()
+
+	v.Field("environment", 0).Check(o.Environment.Valid())
+
+	mv := v.Field("measurements", 1)
+
+	if o.Measurements.IsEmpty() {
+		mv.Check(errors.New("no measurement entries"))
+	}
+
+	for i, m := range o.Measurements.Values {
+		mv.Index(i).Check(m.ValidateAll())
+	}
+
+	return v.Err()
+}
+
+// ValidateAll is like Valid, except that, rather than stopping at the first
+// violation, it returns all of them as a ValidationErrors.
+func (o *KeyTriple) ValidateAll() error {
+	v := NewValidator()
+
+	v.Field("environment", 0).Check(o.Environment.Valid())
+
+	kv := v.Field("verification-keys", 1)
+
+	if len(o.VerifKeys) == 0 {
+		kv.Check(errors.New("no keys to validate"))
+	}
+
+	for i, k := range o.VerifKeys {
+		kv.Index(i).Check(k.Valid())
+	}
+
+	if o.Conditions != nil {
+		v.Field("conditions", 2).Check(o.Conditions.Valid())
+	}
+
+	return v.Err()
+}
+
+// ValidateAll is like Valid, except that, rather than stopping at the first
+// violation, it returns all of them as a ValidationErrors.
+// nolint:gocritic
+func (o Measurement) ValidateAll() error {
+	v := NewValidator()
+
+	if o.Key != nil && o.Key.IsSet() {
+		v.Field("key", 0).Check(o.Key.Valid())
+	}
+
+	v.Field("value", 1).Check(o.Val.ValidateAll())
+
+	return v.Err()
+}
+
+// ValidateAll is like Valid, except that, rather than stopping at the first
+// violation, it returns all of them as a ValidationErrors.
+// nolint:gocritic
+func (o Mval) ValidateAll() error {
+	v := NewValidator()
+
+	if o.hasNoValues() {
+		v.Check(errors.New("no measurement value set"))
+	}
+
+	if o.Ver != nil {
+		v.Field("version", 0).Check(o.Ver.Valid())
+	}
+
+	if o.Digests != nil {
+		dv := v.Field("digests", 2)
+		for i, d := range *o.Digests {
+			dv.Index(i).Check(d.Valid())
+		}
+	}
+
+	if o.Flags != nil {
+		v.Field("flags", 3).Check(o.Flags.Valid())
+	}
+
+	if o.MACAddr != nil {
+		v.Field("mac-addr", 6).Check(validMACaddr(*o.MACAddr))
+	}
+
+	if o.IPAddr != nil {
+		v.Field("ip-addr", 7).Check(validIPaddr(*o.IPAddr))
+	}
+
+	if o.CryptoKeys != nil {
+		kv := v.Field("cryptokeys", 13)
+
+		if len(*o.CryptoKeys) == 0 {
+			kv.Check(errors.New("no keys to validate"))
+		}
+
+		for i, k := range *o.CryptoKeys {
+			kv.Index(i).Check(k.Valid())
+		}
+	}
+
+	if o.IntRange != nil {
+		v.Field("int-range", 15).Check(o.IntRange.Valid())
+	}
+
+	v.Check(o.validMval(&o))
+
+	return v.Err()
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package comid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPath_JSONPointer(t *testing.T) {
+	p := Path{
+		{JSON: "triples", CBOR: 4},
+		{JSON: "reference-values", CBOR: 0},
+		{JSON: "12", CBOR: 12},
+		{JSON: "a/b~c", CBOR: -1},
+	}
+
+	assert.Equal(t, "/triples/reference-values/12/a~1b~0c", p.JSONPointer())
+	assert.Equal(t, []int{4, 0, 12, -1}, p.CBORPath())
+
+	assert.Equal(t, "", Path{}.JSONPointer())
+	assert.Equal(t, []int{}, Path{}.CBORPath())
+}
+
+func TestValidator_Check(t *testing.T) {
+	v := NewValidator()
+	assert.NoError(t, v.Err())
+
+	v.Check(nil)
+	assert.NoError(t, v.Err())
+
+	fv := v.Field("value", 1)
+	fv.Check(errors.New("one"))
+
+	nested := NewValidator()
+	nested.Field("digests", 2).Index(3).Check(errors.New("two"))
+	fv.Check(nested.Err())
+
+	v.Index(7).Check(&ValidationError{Path: Path{{JSON: "name", CBOR: 11}}, Err: errors.New("three")})
+
+	err := v.Err()
+	require.Error(t, err)
+
+	var verrs ValidationErrors
+	require.True(t, errors.As(err, &verrs))
+	require.Len(t, verrs, 3)
+
+	assert.Equal(t, "/value", verrs[0].Path.JSONPointer())
+	assert.Equal(t, "/value/digests/3", verrs[1].Path.JSONPointer())
+	assert.Equal(t, []int{1, 2, 3}, verrs[1].Path.CBORPath())
+	assert.Equal(t, "/7/name", verrs[2].Path.JSONPointer())
+
+	assert.EqualError(t, err, "/value: one\n/value/digests/3: two\n/7/name: three")
+}
+
+func TestComid_ValidateAll_OK(t *testing.T) {
+	c := NewComid().
+		SetTagIdentity(TestTagID, 0).
+		AddReferenceValue(testValueTriple())
+	require.NotNil(t, c)
+
+	assert.NoError(t, c.ValidateAll())
+}
+
+func TestComid_ValidateAll_collects_all(t *testing.T) {
+	badMAC := MACaddr{0x01, 0x02}
+
+	c := NewComid().
+		AddReferenceValue(testValueTriple()).
+		AddReferenceValue(&ValueTriple{
+			Environment: Environment{
+				Instance: MustNewUUIDInstance(TestUUID),
+			},
+			Measurements: *NewMeasurements().
+				Add(&Measurement{}).
+				Add(&Measurement{
+					Val: Mval{
+						Digests: NewDigests().AddDigest(1, []byte{0x00}),
+						MACAddr: &badMAC,
+					},
+				}),
+		}).
+		AddEndorsedValue(&ValueTriple{
+			Environment: Environment{
+				Instance: MustNewUUIDInstance(TestUUID),
+			},
+		})
+	require.NotNil(t, c)
+
+	// Valid only reports the first violation
+	assert.EqualError(t, c.Valid(), "tag-identity validation failed: empty tag-id")
+
+	err := c.ValidateAll()
+	require.Error(t, err)
+
+	var verrs ValidationErrors
+	require.True(t, errors.As(err, &verrs))
+
+	type violation struct {
+		pointer string
+		cbor    []int
+	}
+
+	var actual []violation
+	for _, e := range verrs {
+		actual = append(actual, violation{e.Path.JSONPointer(), e.Path.CBORPath()})
+	}
+
+	expected := []violation{
+		{"/tag-identity", []int{1}},
+		{"/triples/reference-values/1/measurements/0/value", []int{4, 0, 1, 1, 0, 1}},
+		{"/triples/reference-values/1/measurements/1/value/digests/0", []int{4, 0, 1, 1, 1, 1, 2, 0}},
+		{"/triples/reference-values/1/measurements/1/value/mac-addr", []int{4, 0, 1, 1, 1, 1, 6}},
+		{"/triples/endorsed-values/0/measurements", []int{4, 1, 0, 1}},
+	}
+	assert.Equal(t, expected, actual)
+
+	assert.EqualError(t, verrs[1], "/triples/reference-values/1/measurements/0/value: no measurement value set")
+	assert.EqualError(t, verrs[3], "/triples/reference-values/1/measurements/1/value/mac-addr: "+
+		"invalid MAC address length: expected 6 or 8 bytes, got 2")
+	assert.EqualError(t, verrs[4], "/triples/endorsed-values/0/measurements: no measurement entries")
+}
+
+func TestComid_ValidateAll_empty_triples(t *testing.T) {
+	c := NewComid().SetTagIdentity(TestTagID, 0)
+	require.NotNil(t, c)
+
+	assert.EqualError(t, c.ValidateAll(), "/triples: triples struct must not be empty")
+}
+
+func TestComid_ValidateAll_entities(t *testing.T) {
+	c := NewComid().
+		SetTagIdentity(TestTagID, 0).
+		AddReferenceValue(testValueTriple())
+	require.NotNil(t, c)
+
+	c.Entities = NewEntities()
+	c.Entities.Add(&Entity{})
+
+	err := c.ValidateAll()
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "/entities/0/name: empty entity-name")
+	assert.ErrorContains(t, err, "/entities/0/roles: empty roles")
+}
+
+type testPathConstrainer struct{}
+
+func (o *testPathConstrainer) ConstrainMval(m *Mval) error {
+	v := NewValidator()
+
+	if m.Name == nil {
+		v.Field("name", 11).Check(errors.New("name is required"))
+	}
+
+	if m.SVN == nil {
+		v.Field("svn", 1).Check(errors.New("svn is required"))
+	}
+
+	return v.Err()
+}
+
+func TestComid_ValidateAll_constrainer(t *testing.T) {
+	vt := testValueTriple()
+	vt.Measurements.Values[0].Val.Register(&testPathConstrainer{})
+
+	c := NewComid().
+		SetTagIdentity(TestTagID, 0).
+		AddReferenceValue(vt)
+	require.NotNil(t, c)
+
+	err := c.ValidateAll()
+	assert.EqualError(t, err,
+		"/triples/reference-values/0/measurements/0/value/name: name is required\n"+
+			"/triples/reference-values/0/measurements/0/value/svn: svn is required")
+
+	// a plain error from a constrainer is reported against the constrained value
+	c = NewComid().
+		SetTagIdentity(TestTagID, 0).
+		AddReferenceValue(testValueTriple())
+	require.NotNil(t, c)
+	c.Triples.Register(&TestExtension{})
+
+	assert.EqualError(t, c.ValidateAll(), "/triples: invalid")
+}
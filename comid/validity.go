@@ -0,0 +1,72 @@
+// Copyright 2021-2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package comid
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrExpired is returned when a validity period is checked after its
+	// not-after time
+	ErrExpired = errors.New("expired")
+	// ErrNotYetValid is returned when a validity period is checked before its
+	// not-before time
+	ErrNotYetValid = errors.New("not yet valid")
+)
+
+// Validity stores a validity-map, as used by CoRIM (signature validity and
+// rim-validity) and CoTL (tl-validity), with JSON and CBOR serializations.
+type Validity struct {
+	NotBefore *time.Time `cbor:"0,keyasint,omitempty" json:"not-before,omitempty"`
+	NotAfter  time.Time  `cbor:"1,keyasint" json:"not-after"`
+}
+
+func NewValidity() *Validity {
+	return &Validity{}
+}
+
+// Set instantiates a Validity object (using the supplied time inputs) & checks it been valid
+func (o *Validity) Set(notAfter time.Time, notBefore *time.Time) *Validity {
+	if o != nil {
+		v := Validity{
+			NotBefore: notBefore,
+			NotAfter:  notAfter,
+		}
+
+		if v.Valid() != nil {
+			return nil
+		}
+
+		*o = v
+	}
+	return o
+}
+
+// Valid checks for validity of fields inside the Validity object
+func (o Validity) Valid() error {
+	if o.NotBefore != nil {
+		if delta := o.NotAfter.Sub(*o.NotBefore); delta < 0 {
+			return fmt.Errorf("invalid not-before / not-after: negative delta (%d)", delta)
+		}
+	}
+	return nil
+}
+
+// CheckAt returns nil if the supplied time falls within the validity window
+// (both ends included), or an error wrapping ErrNotYetValid or ErrExpired
+// otherwise.
+func (o Validity) CheckAt(t time.Time) error {
+	if o.NotBefore != nil && t.Before(*o.NotBefore) {
+		return fmt.Errorf("%w: not-before %s", ErrNotYetValid, o.NotBefore.Format(time.RFC3339))
+	}
+
+	if t.After(o.NotAfter) {
+		return fmt.Errorf("%w: not-after %s", ErrExpired, o.NotAfter.Format(time.RFC3339))
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package comid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testValidityNotBefore = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	testValidityNotAfter  = time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestValidity_Set(t *testing.T) {
+	v := NewValidity().Set(testValidityNotAfter, &testValidityNotBefore)
+	require.NotNil(t, v)
+	assert.Equal(t, testValidityNotAfter, v.NotAfter)
+
+	assert.Nil(t, NewValidity().Set(testValidityNotBefore, &testValidityNotAfter))
+}
+
+func TestValidity_Valid(t *testing.T) {
+	assert.NoError(t, Validity{NotAfter: testValidityNotAfter}.Valid())
+	assert.ErrorContains(t, Validity{
+		NotBefore: &testValidityNotAfter,
+		NotAfter:  testValidityNotBefore,
+	}.Valid(), "negative delta")
+}
+
+func TestValidity_CheckAt(t *testing.T) {
+	v := Validity{NotBefore: &testValidityNotBefore, NotAfter: testValidityNotAfter}
+
+	assert.NoError(t, v.CheckAt(testValidityNotBefore))
+	assert.NoError(t, v.CheckAt(testValidityNotAfter))
+	assert.NoError(t, v.CheckAt(testValidityNotBefore.Add(time.Hour)))
+
+	err := v.CheckAt(testValidityNotBefore.Add(-time.Second))
+	assert.ErrorIs(t, err, ErrNotYetValid)
+	assert.EqualError(t, err, "not yet valid: not-before 2026-01-01T00:00:00Z")
+
+	err = v.CheckAt(testValidityNotAfter.Add(time.Second))
+	assert.ErrorIs(t, err, ErrExpired)
+	assert.EqualError(t, err, "expired: not-after 2027-01-01T00:00:00Z")
+
+	v.NotBefore = nil
+	assert.NoError(t, v.CheckAt(time.Time{}))
+}
@@ -4,15 +4,33 @@
 package comid
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/veraison/swid"
 )
 
 // Version stores a version-map with JSON and CBOR serializations.
+//
+// In a reference value, Op may be set to turn the version into a range (e.g.
+// VersionGE for "at least"), in which case the CBOR serialization is a
+// TaggedVersionRange rather than a plain version-map.
 type Version struct {
 	Version string              `cbor:"0,keyasint" json:"value"`
 	Scheme  *swid.VersionScheme `cbor:"1,keyasint,omitempty" json:"scheme,omitempty"`
+	Op      VersionOperator     `cbor:"-" json:"op,omitempty"`
+}
+
+// versionMap is Version without its CBOR (un)marshalers
+type versionMap Version
+
+// TaggedVersionRange is the CBOR serialization of a Version with an
+// operator: #6.60030([operator, version-map]). The tag is not defined by the
+// CoRIM specification.
+type TaggedVersionRange struct {
+	_       struct{} `cbor:",toarray"`
+	Op      VersionOperator
+	Version versionMap
 }
 
 func NewVersion() *Version {
@@ -26,27 +44,93 @@ func (o *Version) SetVersion(v string) *Version {
 	return o
 }
 
-func (o *Version) SetScheme(v int64) *Version {
+// SetOperator sets the relation a claimed version must have with the target
+// reference version. It returns nil if op is not one of the Version*
+// operators.
+func (o *Version) SetOperator(op VersionOperator) *Version {
 	if o != nil {
-		var scheme swid.VersionScheme
-		if scheme.SetCode(v) != nil {
+		if !op.isKnown() {
 			return nil
 		}
 
-		o.Scheme = &scheme
+		o.Op = op
+	}
+	return o
+}
+
+func (o *Version) SetScheme(v int64) *Version {
+	if o != nil && o.setScheme(v) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *Version) setScheme(v int64) error {
+	var scheme swid.VersionScheme
+	if err := scheme.SetCode(v); err != nil {
+		return err
+	}
+
+	o.Scheme = &scheme
+
+	return nil
+}
+
 func (o Version) Valid() error {
 	if o.Version == "" {
 		return fmt.Errorf("empty version")
 	}
+
+	if o.Op != "" && !o.Op.isKnown() {
+		return fmt.Errorf("unknown version operator %q", o.Op)
+	}
+
+	return nil
+}
+
+// MarshalCBOR encodes the target Version as a version-map or, if Op is set,
+// as a TaggedVersionRange
+func (o Version) MarshalCBOR() ([]byte, error) {
+	if o.Op == "" {
+		return em.Marshal(versionMap(o))
+	}
+
+	return em.Marshal(TaggedVersionRange{Op: o.Op, Version: versionMap(o)})
+}
+
+// UnmarshalCBOR decodes a version-map or a TaggedVersionRange into the target
+// Version
+func (o *Version) UnmarshalCBOR(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("empty input")
+	}
+
+	if majorType := (data[0] & 0xe0) >> 5; majorType == 6 { // tag
+		var r TaggedVersionRange
+
+		if err := dm.Unmarshal(data, &r); err != nil {
+			return err
+		}
+
+		*o = Version(r.Version)
+		o.Op = r.Op
+
+		return nil
+	}
+
+	var v versionMap
+
+	if err := dm.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*o = Version(v)
+
 	return nil
 }
 
 func (o Version) Equal(r Version) bool {
-	if o.Version != r.Version {
+	if o.Version != r.Version || o.Op != r.Op {
 		return false
 	}
 
@@ -63,6 +147,8 @@ func (o Version) Equal(r Version) bool {
 	return true
 }
 
+// CompareAgainstReference returns true if the (claimed) version matches the
+// supplied reference version, see MatchReference.
 func (o Version) CompareAgainstReference(r Version) bool {
-	return o.Equal(r)
+	return o.MatchReference(r) == nil
 }
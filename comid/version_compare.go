@@ -0,0 +1,298 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package comid
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/veraison/swid"
+)
+
+var (
+	// ErrUnknownVersionScheme is returned when versions cannot be ordered
+	// because their version scheme is missing or not one of the schemes
+	// defined by CoSWID.
+	ErrUnknownVersionScheme = errors.New("unknown version scheme")
+	// ErrVersionSchemeMismatch is returned when comparing versions that use
+	// different version schemes.
+	ErrVersionSchemeMismatch = errors.New("version scheme mismatch")
+)
+
+// VersionOperator is the relation a claimed version must have with a
+// reference version in order to match it.
+type VersionOperator string
+
+const (
+	VersionEQ VersionOperator = "=="
+	VersionGT VersionOperator = ">"
+	VersionGE VersionOperator = ">="
+	VersionLT VersionOperator = "<"
+	VersionLE VersionOperator = "<="
+)
+
+func (o VersionOperator) isKnown() bool {
+	switch o {
+	case VersionEQ, VersionGT, VersionGE, VersionLT, VersionLE:
+		return true
+	default:
+		return false
+	}
+}
+
+// Compare orders the target version with respect to the supplied one,
+// according to their version scheme. It returns -1, 0 or +1 if the target
+// version is respectively lower, equal or greater than the supplied version.
+// If only one of the versions specifies a scheme, it is used for both. An
+// error is returned if the schemes differ, if no scheme is specified, if the
+// scheme is not one of those defined by CoSWID, or if a version does not
+// conform to the scheme.
+func (o Version) Compare(r Version) (int, error) {
+	scheme, err := versionScheme(o.Scheme, r.Scheme)
+	if err != nil {
+		return 0, err
+	}
+
+	return compareVersions(scheme, o.Version, r.Version)
+}
+
+// MatchReference checks whether the (claimed) target version matches the
+// supplied reference version.
+//
+// A reference version with no operator must be equal to the claimed version,
+// scheme included. Otherwise, versions are ordered as per Compare, except that
+// identical version strings always satisfy VersionEQ, VersionGE and VersionLE,
+// and that, if neither version specifies a scheme, VersionEQ requires the
+// version strings to be equal.
+func (o Version) MatchReference(r Version) error {
+	switch r.Op {
+	case "":
+		o.Op = ""
+		if !o.Equal(r) {
+			return fmt.Errorf("claimed version %q does not match reference %q",
+				o.Version, r.Version)
+		}
+		return nil
+	case VersionEQ, VersionGE, VersionLE:
+		if o.Version == r.Version &&
+			(o.Scheme == nil || r.Scheme == nil || *o.Scheme == *r.Scheme) {
+			return nil
+		}
+	case VersionGT, VersionLT:
+	default:
+		return fmt.Errorf("unknown version operator %q", r.Op)
+	}
+
+	if o.Scheme == nil && r.Scheme == nil && r.Op == VersionEQ {
+		return fmt.Errorf("claimed version %q does not match reference %q",
+			o.Version, r.Version)
+	}
+
+	cmp, err := o.Compare(r)
+	if err != nil {
+		return err
+	}
+
+	var ok bool
+
+	switch r.Op {
+	case VersionEQ:
+		ok = cmp == 0
+	case VersionGT:
+		ok = cmp > 0
+	case VersionGE:
+		ok = cmp >= 0
+	case VersionLT:
+		ok = cmp < 0
+	case VersionLE:
+		ok = cmp <= 0
+	}
+
+	if !ok {
+		return fmt.Errorf("claimed version %q does not satisfy reference \"%s%s\"",
+			o.Version, r.Op, r.Version)
+	}
+
+	return nil
+}
+
+func versionScheme(lhs, rhs *swid.VersionScheme) (string, error) {
+	var scheme string
+
+	switch {
+	case lhs != nil && rhs != nil:
+		if lhs.String() != rhs.String() {
+			return "", fmt.Errorf("%w: %s and %s", ErrVersionSchemeMismatch, lhs, rhs)
+		}
+		scheme = lhs.String()
+	case lhs != nil:
+		scheme = lhs.String()
+	case rhs != nil:
+		scheme = rhs.String()
+	default:
+		return "", fmt.Errorf("%w: no scheme specified", ErrUnknownVersionScheme)
+	}
+
+	switch scheme {
+	case "multipartnumeric", "multipartnumeric+suffix", "alphanumeric", "decimal", "semver":
+		return scheme, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownVersionScheme, scheme)
+	}
+}
+
+func compareVersions(scheme, lhs, rhs string) (int, error) {
+	var cmp func(string, string) (int, error)
+
+	switch scheme {
+	case "multipartnumeric":
+		cmp = compareMultipartNumeric
+	case "multipartnumeric+suffix":
+		cmp = compareMultipartNumericSuffix
+	case "alphanumeric":
+		cmp = func(l, r string) (int, error) { return strings.Compare(l, r), nil }
+	case "decimal":
+		cmp = compareDecimal
+	case "semver":
+		cmp = compareSemver
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrUnknownVersionScheme, scheme)
+	}
+
+	ret, err := cmp(lhs, rhs)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", scheme, err)
+	}
+
+	return ret, nil
+}
+
+// compareMultipartNumeric compares dot-separated sequences of non-negative
+// integers of arbitrary size. Missing trailing parts are taken to be zero, so
+// that "1.2" and "1.2.0" are equal.
+func compareMultipartNumeric(lhs, rhs string) (int, error) {
+	lparts, err := numericParts(lhs)
+	if err != nil {
+		return 0, err
+	}
+
+	rparts, err := numericParts(rhs)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < max(len(lparts), len(rparts)); i++ {
+		l, r := "0", "0"
+		if i < len(lparts) {
+			l = lparts[i]
+		}
+		if i < len(rparts) {
+			r = rparts[i]
+		}
+
+		if c := compareDigits(l, r); c != 0 {
+			return c, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// compareMultipartNumericSuffix compares multipart numeric versions followed
+// by an optional textual suffix (e.g. "1.0.2k"). The numeric parts are compared
+// first; if they are equal, the suffixes are compared as strings, so that a
+// version without suffix is lower than the same version with a suffix.
+func compareMultipartNumericSuffix(lhs, rhs string) (int, error) {
+	lnum, lsuffix := splitNumericSuffix(lhs)
+	rnum, rsuffix := splitNumericSuffix(rhs)
+
+	c, err := compareMultipartNumeric(lnum, rnum)
+	if err != nil || c != 0 {
+		return c, err
+	}
+
+	return strings.Compare(lsuffix, rsuffix), nil
+}
+
+func compareDecimal(lhs, rhs string) (int, error) {
+	l, err := parseDecimal(lhs)
+	if err != nil {
+		return 0, err
+	}
+
+	r, err := parseDecimal(rhs)
+	if err != nil {
+		return 0, err
+	}
+
+	return l.Cmp(r), nil
+}
+
+func parseDecimal(v string) (*big.Rat, error) {
+	// big.Rat also accepts fractions, which are not decimal numbers
+	if strings.Contains(v, "/") {
+		return nil, fmt.Errorf("invalid decimal version %q", v)
+	}
+
+	r, ok := new(big.Rat).SetString(v)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal version %q", v)
+	}
+
+	return r, nil
+}
+
+func compareSemver(lhs, rhs string) (int, error) {
+	l, err := semver.StrictNewVersion(lhs)
+	if err != nil {
+		return 0, fmt.Errorf("invalid semver version %q: %w", lhs, err)
+	}
+
+	r, err := semver.StrictNewVersion(rhs)
+	if err != nil {
+		return 0, fmt.Errorf("invalid semver version %q: %w", rhs, err)
+	}
+
+	return l.Compare(r), nil
+}
+
+func numericParts(v string) ([]string, error) {
+	parts := strings.Split(v, ".")
+
+	for _, p := range parts {
+		if p == "" || strings.Trim(p, "0123456789") != "" {
+			return nil, fmt.Errorf("invalid multipart numeric version %q", v)
+		}
+	}
+
+	return parts, nil
+}
+
+func splitNumericSuffix(v string) (string, string) {
+	i := strings.IndexFunc(v, func(r rune) bool {
+		return r != '.' && (r < '0' || r > '9')
+	})
+	if i == -1 {
+		return v, ""
+	}
+	return v[:i], v[i:]
+}
+
+// compareDigits compares two strings of decimal digits as integers.
+func compareDigits(lhs, rhs string) int {
+	lhs = strings.TrimLeft(lhs, "0")
+	rhs = strings.TrimLeft(rhs, "0")
+
+	if len(lhs) != len(rhs) {
+		if len(lhs) < len(rhs) {
+			return -1
+		}
+		return 1
+	}
+
+	return strings.Compare(lhs, rhs)
+}
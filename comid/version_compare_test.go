@@ -0,0 +1,215 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package comid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/swid"
+)
+
+func testVersion(v string, scheme int64) Version {
+	ver := NewVersion().SetVersion(v)
+	if scheme != 0 {
+		ver = ver.SetScheme(scheme)
+	}
+	return *ver
+}
+
+func TestVersion_Compare(t *testing.T) {
+	testCases := []struct {
+		scheme   int64
+		lhs      string
+		rhs      string
+		expected int
+	}{
+		{swid.VersionSchemeMultipartNumeric, "1.4.2", "1.4.2", 0},
+		{swid.VersionSchemeMultipartNumeric, "1.4", "1.4.0.0", 0},
+		{swid.VersionSchemeMultipartNumeric, "1.10.0", "1.9.9", 1},
+		{swid.VersionSchemeMultipartNumeric, "1.04.1", "1.4.2", -1},
+		{swid.VersionSchemeMultipartNumeric, "18446744073709551616", "18446744073709551615", 1},
+		{swid.VersionSchemeMultipartNumericSuffix, "1.0.2k", "1.0.2", 1},
+		{swid.VersionSchemeMultipartNumericSuffix, "1.0.2k", "1.0.2m", -1},
+		{swid.VersionSchemeMultipartNumericSuffix, "1.0.10a", "1.0.9z", 1},
+		{swid.VersionSchemeMultipartNumericSuffix, "2.1-rc1", "2.1-rc1", 0},
+		{swid.VersionSchemeAlphaNumeric, "abc", "abd", -1},
+		{swid.VersionSchemeAlphaNumeric, "b", "abc", 1},
+		{swid.VersionSchemeDecimal, "1.5", "1.50", 0},
+		{swid.VersionSchemeDecimal, "1.25", "1.3", -1},
+		{swid.VersionSchemeSemVer, "1.2.3", "1.2.3", 0},
+		{swid.VersionSchemeSemVer, "1.2.3-alpha", "1.2.3", -1},
+		{swid.VersionSchemeSemVer, "1.2.3+build.7", "1.2.3", 0},
+		{swid.VersionSchemeSemVer, "1.10.0", "1.9.0", 1},
+	}
+
+	for _, tc := range testCases {
+		lhs := testVersion(tc.lhs, tc.scheme)
+		rhs := testVersion(tc.rhs, tc.scheme)
+
+		actual, err := lhs.Compare(rhs)
+		require.NoError(t, err, "%s %s", tc.lhs, tc.rhs)
+		assert.Equal(t, tc.expected, actual, "%s vs %s (%s)", tc.lhs, tc.rhs, lhs.Scheme)
+	}
+}
+
+func TestVersion_Compare_scheme_from_one_side(t *testing.T) {
+	c, err := testVersion("1.10", swid.VersionSchemeMultipartNumeric).
+		Compare(testVersion("1.9", 0))
+	require.NoError(t, err)
+	assert.Equal(t, 1, c)
+
+	c, err = testVersion("1.10", 0).
+		Compare(testVersion("1.9", swid.VersionSchemeMultipartNumeric))
+	require.NoError(t, err)
+	assert.Equal(t, 1, c)
+}
+
+func TestVersion_Compare_NOK(t *testing.T) {
+	_, err := testVersion("1.0", 0).Compare(testVersion("1.0", 0))
+	assert.ErrorIs(t, err, ErrUnknownVersionScheme)
+
+	_, err = testVersion("1.0", swid.VersionSchemeSemVer).
+		Compare(testVersion("1.0", swid.VersionSchemeDecimal))
+	assert.ErrorIs(t, err, ErrVersionSchemeMismatch)
+	assert.EqualError(t, err, "version scheme mismatch: semver and decimal")
+
+	var custom swid.VersionScheme
+	require.NoError(t, custom.UnmarshalJSON([]byte(`"my-scheme"`)))
+	_, err = Version{Version: "1", Scheme: &custom}.Compare(Version{Version: "2", Scheme: &custom})
+	assert.EqualError(t, err, "unknown version scheme: my-scheme")
+
+	_, err = testVersion("1.0", swid.VersionSchemeSemVer).Compare(testVersion("1.0.0", 0))
+	assert.ErrorContains(t, err, `semver: invalid semver version "1.0"`)
+
+	_, err = testVersion("1..0", swid.VersionSchemeMultipartNumeric).Compare(testVersion("1.0", 0))
+	assert.EqualError(t, err, `multipartnumeric: invalid multipart numeric version "1..0"`)
+
+	_, err = testVersion("1/2", swid.VersionSchemeDecimal).Compare(testVersion("0.5", 0))
+	assert.EqualError(t, err, `decimal: invalid decimal version "1/2"`)
+
+	_, err = testVersion(">=1.0", swid.VersionSchemeDecimal).Compare(testVersion("0.5", 0))
+	assert.Error(t, err)
+}
+
+func testRange(op VersionOperator, v string, scheme int64) Version {
+	ver := testVersion(v, scheme)
+	ver.Op = op
+	return ver
+}
+
+func TestVersion_SetOperator(t *testing.T) {
+	v := NewVersion().SetVersion("1.4.2").SetOperator(VersionGE)
+	require.NotNil(t, v)
+	assert.Equal(t, VersionGE, v.Op)
+
+	assert.Nil(t, NewVersion().SetVersion("1.4.2").SetOperator("~"))
+
+	v.Op = "~"
+	assert.EqualError(t, v.Valid(), `unknown version operator "~"`)
+}
+
+func TestVersion_MatchReference(t *testing.T) {
+	ref := testRange(VersionGE, "1.4.2", swid.VersionSchemeMultipartNumeric)
+
+	for _, v := range []string{"1.4.2", "1.4.10", "2"} {
+		assert.NoError(t, testVersion(v, swid.VersionSchemeMultipartNumeric).MatchReference(ref), v)
+		assert.NoError(t, testVersion(v, 0).MatchReference(ref), v)
+	}
+
+	err := testVersion("1.4.1", swid.VersionSchemeMultipartNumeric).MatchReference(ref)
+	assert.EqualError(t, err, `claimed version "1.4.1" does not satisfy reference ">=1.4.2"`)
+
+	ref = testRange(VersionLT, "2.0.0", swid.VersionSchemeSemVer)
+	assert.NoError(t, testVersion("1.99.0", 0).MatchReference(ref))
+	assert.Error(t, testVersion("2.0.0", 0).MatchReference(ref))
+	assert.ErrorIs(t, testVersion("1.0", swid.VersionSchemeDecimal).MatchReference(ref),
+		ErrVersionSchemeMismatch)
+
+	err = testVersion("1.0", 0).MatchReference(testRange("~", "1.0", 0))
+	assert.EqualError(t, err, `unknown version operator "~"`)
+}
+
+func TestVersion_MatchReference_no_operator(t *testing.T) {
+	// exact match, regardless of the scheme
+	v := testVersion("build-42", swid.VersionSchemeSemVer)
+	assert.NoError(t, v.MatchReference(v))
+
+	err := testVersion("1.4", swid.VersionSchemeMultipartNumeric).
+		MatchReference(testVersion("1.4.0", swid.VersionSchemeMultipartNumeric))
+	assert.EqualError(t, err, `claimed version "1.4" does not match reference "1.4.0"`)
+
+	assert.Error(t, testVersion("1.4", 0).
+		MatchReference(testVersion("1.4", swid.VersionSchemeMultipartNumeric)))
+}
+
+func TestVersion_MatchReference_no_scheme(t *testing.T) {
+	assert.NoError(t, testVersion("1.0", 0).MatchReference(testRange(VersionEQ, "1.0", 0)))
+
+	err := testVersion("1.0", 0).MatchReference(testRange(VersionEQ, "1.0.0", 0))
+	assert.EqualError(t, err, `claimed version "1.0" does not match reference "1.0.0"`)
+
+	err = testVersion("1.0", 0).MatchReference(testRange(VersionGE, "0.9", 0))
+	assert.ErrorIs(t, err, ErrUnknownVersionScheme)
+}
+
+func TestVersion_MatchReference_identical(t *testing.T) {
+	// identical strings match even if they do not conform to the scheme
+	v := testVersion("build-42", swid.VersionSchemeSemVer)
+
+	for _, op := range []VersionOperator{VersionEQ, VersionGE, VersionLE} {
+		assert.NoError(t, v.MatchReference(testRange(op, "build-42", swid.VersionSchemeSemVer)), op)
+	}
+
+	assert.Error(t, v.MatchReference(testRange(VersionGT, "build-42", swid.VersionSchemeSemVer)))
+
+	// unless the schemes differ
+	err := v.MatchReference(testRange(VersionEQ, "build-42", swid.VersionSchemeDecimal))
+	assert.ErrorIs(t, err, ErrVersionSchemeMismatch)
+}
+
+func TestVersion_CompareAgainstReference(t *testing.T) {
+	v := testVersion("build-42", swid.VersionSchemeSemVer)
+	assert.True(t, v.CompareAgainstReference(v))
+
+	assert.False(t, testVersion("1.4", swid.VersionSchemeMultipartNumeric).
+		CompareAgainstReference(testVersion("1.4.0", swid.VersionSchemeMultipartNumeric)))
+
+	assert.True(t, testVersion("1.4", swid.VersionSchemeMultipartNumeric).
+		CompareAgainstReference(testRange(VersionEQ, "1.4.0", swid.VersionSchemeMultipartNumeric)))
+}
+
+func TestVersion_CBOR_range_round_trip(t *testing.T) {
+	ref := testRange(VersionGE, "1.4.2", swid.VersionSchemeSemVer)
+
+	data, err := em.Marshal(ref)
+	require.NoError(t, err)
+	// #6.60030([">=", {0: "1.4.2", 1: 16384}])
+	assert.Equal(t, MustHexDecode(t, "d9ea7e82623e3da20065312e342e3201194000"), data)
+
+	var out Version
+	require.NoError(t, dm.Unmarshal(data, &out))
+	assert.Equal(t, ref, out)
+
+	// no operator: plain version-map
+	data, err = em.Marshal(testVersion("1.4.2", 0))
+	require.NoError(t, err)
+	assert.Equal(t, MustHexDecode(t, "a10065312e342e32"), data)
+
+	out = Version{}
+	require.NoError(t, dm.Unmarshal(data, &out))
+	assert.Equal(t, testVersion("1.4.2", 0), out)
+}
+
+func TestMval_CompareAgainstReference_version_range(t *testing.T) {
+	ref := Mval{Ver: NewVersion().SetVersion("1.4.2").SetOperator(VersionGE).
+		SetScheme(swid.VersionSchemeSemVer)}
+
+	res := Mval{Ver: NewVersion().SetVersion("1.5.0")}.CompareAgainstReference(ref)
+	assert.True(t, res.Matched())
+
+	res = Mval{Ver: NewVersion().SetVersion("1.4.1")}.CompareAgainstReference(ref)
+	assert.False(t, res.Matched())
+}
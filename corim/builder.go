@@ -0,0 +1,119 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/veraison/corim/comid"
+	"github.com/veraison/corim/cotl"
+	"github.com/veraison/corim/cots"
+	"github.com/veraison/swid"
+)
+
+// UnsignedCorimBuilder builds an UnsignedCorim with the same methods as the
+// fluent UnsignedCorim setters, but instead of returning nil on failure, it
+// records the reason and carries on. Errors are reported by Err and Build.
+type UnsignedCorimBuilder struct {
+	comid.BuildErrors
+	corim *UnsignedCorim
+}
+
+// NewUnsignedCorimBuilder instantiates a builder for an empty UnsignedCorim
+func NewUnsignedCorimBuilder() *UnsignedCorimBuilder {
+	return &UnsignedCorimBuilder{corim: NewUnsignedCorim()}
+}
+
+// Build returns the built UnsignedCorim, or the errors recorded by the
+// builder. If there are none, the UnsignedCorim is validated.
+func (o *UnsignedCorimBuilder) Build() (*UnsignedCorim, error) {
+	if err := o.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := o.corim.Valid(); err != nil {
+		return nil, fmt.Errorf("invalid unsigned corim: %w", err)
+	}
+
+	return o.corim, nil
+}
+
+// SetID is like [UnsignedCorim.SetID]
+func (o *UnsignedCorimBuilder) SetID(v interface{}) *UnsignedCorimBuilder {
+	o.Record("SetID", o.corim.setID(v))
+
+	return o
+}
+
+// AddComid is like [UnsignedCorim.AddComid]
+func (o *UnsignedCorimBuilder) AddComid(c *comid.Comid) *UnsignedCorimBuilder {
+	o.Record("AddComid", o.corim.addComid(c))
+
+	return o
+}
+
+// AddCots is like [UnsignedCorim.AddCots]
+func (o *UnsignedCorimBuilder) AddCots(c *cots.ConciseTaStore) *UnsignedCorimBuilder {
+	o.Record("AddCots", o.corim.addCots(c))
+
+	return o
+}
+
+// AddCotl is like [UnsignedCorim.AddCotl]
+func (o *UnsignedCorimBuilder) AddCotl(c *cotl.ConciseTagList) *UnsignedCorimBuilder {
+	o.Record("AddCotl", o.corim.addCotl(c))
+
+	return o
+}
+
+// AddCoswid is like [UnsignedCorim.AddCoswid]
+func (o *UnsignedCorimBuilder) AddCoswid(c *swid.SoftwareIdentity) *UnsignedCorimBuilder {
+	o.Record("AddCoswid", o.corim.addCoswid(c))
+
+	return o
+}
+
+// AddDependentRim is like [UnsignedCorim.AddDependentRim], but also rejects an
+// empty href or an invalid locator
+func (o *UnsignedCorimBuilder) AddDependentRim(href string, thumbprint *comid.Digest) *UnsignedCorimBuilder {
+	if href == "" {
+		o.Record("AddDependentRim", errors.New("empty href"))
+		return o
+	}
+
+	l := newLocator(href, thumbprint)
+
+	if err := l.Valid(); err != nil {
+		o.Record("AddDependentRim", err)
+		return o
+	}
+
+	o.corim.addDependentRim(l)
+	o.Record("AddDependentRim", nil)
+
+	return o
+}
+
+// SetProfile is like [UnsignedCorim.SetProfile]
+func (o *UnsignedCorimBuilder) SetProfile(urlOrOID string) *UnsignedCorimBuilder {
+	o.Record("SetProfile", o.corim.setProfile(urlOrOID))
+
+	return o
+}
+
+// SetRimValidity is like [UnsignedCorim.SetRimValidity]
+func (o *UnsignedCorimBuilder) SetRimValidity(notAfter time.Time, notBefore *time.Time) *UnsignedCorimBuilder {
+	o.Record("SetRimValidity", o.corim.setRimValidity(notAfter, notBefore))
+
+	return o
+}
+
+// AddEntity is like [UnsignedCorim.AddEntity]
+func (o *UnsignedCorimBuilder) AddEntity(name string, regID *string, roles ...Role) *UnsignedCorimBuilder {
+	o.Record("AddEntity", o.corim.addEntity(name, regID, roles...))
+
+	return o
+}
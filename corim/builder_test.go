@@ -0,0 +1,106 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/comid"
+)
+
+func testBuilderComid(t *testing.T) *comid.Comid {
+	c, err := comid.NewComidBuilder().
+		SetTagIdentity(comid.TestUUIDString, 0).
+		AddReferenceValue(&comid.ValueTriple{
+			Environment: comid.Environment{
+				Instance: comid.MustNewUUIDInstance(comid.TestUUID),
+			},
+			Measurements: *comid.NewMeasurements().Add(
+				comid.MustNewUUIDMeasurement(comid.TestUUID).
+					AddDigest(1, comid.MustHexDecode(t, "87428fc522803d31065e7bce3cf03fe475096631e5e07bbd7a0fde60c4cf25c7")),
+			),
+		}).
+		Build()
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestUnsignedCorimBuilder_Build_OK(t *testing.T) {
+	regID := comid.TestRegID
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c, err := NewUnsignedCorimBuilder().
+		SetID("test corim id").
+		AddComid(testBuilderComid(t)).
+		AddDependentRim("https://example.org/dep.corim", comid.NewDigestIntAlg(1,
+			comid.MustHexDecode(t, "87428fc522803d31065e7bce3cf03fe475096631e5e07bbd7a0fde60c4cf25c7"))).
+		SetProfile("http://arm.com/psa/iot/1").
+		SetRimValidity(notAfter, nil).
+		AddEntity("ACME Ltd.", &regID, RoleManifestCreator).
+		Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, "test corim id", c.GetID())
+	assert.Len(t, c.Tags, 1)
+	require.NotNil(t, c.DependentRims)
+	assert.Len(t, *c.DependentRims, 1)
+	require.NotNil(t, c.RimValidity)
+	assert.Equal(t, notAfter, c.RimValidity.NotAfter)
+}
+
+func TestUnsignedCorimBuilder_Build_accumulates_errors(t *testing.T) {
+	notBefore := time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := NewUnsignedCorimBuilder().
+		SetID("").
+		AddComid(nil).
+		AddComid(testBuilderComid(t)).
+		AddDependentRim("dep.corim", comid.NewDigestIntAlg(1, []byte{0x01})).
+		SetProfile(":not a uri").
+		SetRimValidity(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), &notBefore).
+		AddEntity("ACME Ltd.", nil, Role(666)).
+		Build()
+	require.Error(t, err)
+
+	assert.ErrorContains(t, err, "SetID (call 1): invalid tag-id: ")
+	assert.ErrorContains(t, err, "AddComid (call 2): nil comid")
+	assert.NotContains(t, err.Error(), "call 3")
+	assert.ErrorContains(t, err, "AddDependentRim (call 4): invalid locator thumbprint at index 0: ")
+	assert.ErrorContains(t, err, "SetProfile (call 5): ")
+	assert.ErrorContains(t, err, "SetRimValidity (call 6): ")
+	assert.ErrorContains(t, err, "AddEntity (call 7): unknown role 666 at index 0")
+}
+
+func TestUnsignedCorimBuilder_AddDependentRim_stricter_than_setter(t *testing.T) {
+	thumbprint := comid.NewDigestIntAlg(1, []byte{0x01})
+
+	// the fluent setter accepts these, only the builder rejects them
+	assert.NotNil(t, NewUnsignedCorim().AddDependentRim("", nil))
+	assert.NotNil(t, NewUnsignedCorim().AddDependentRim("dep.corim", thumbprint))
+
+	_, err := NewUnsignedCorimBuilder().
+		SetID("test corim id").
+		AddDependentRim("", nil).
+		Build()
+	assert.EqualError(t, err, "AddDependentRim (call 2): empty href")
+}
+
+func TestUnsignedCorimBuilder_AddComid_invalid(t *testing.T) {
+	_, err := NewUnsignedCorimBuilder().
+		SetID("test corim id").
+		AddComid(comid.NewComid()).
+		Build()
+	assert.EqualError(t, err, "AddComid (call 2): invalid comid: tag-identity validation failed: empty tag-id")
+}
+
+func TestUnsignedCorimBuilder_Build_invalid_corim(t *testing.T) {
+	_, err := NewUnsignedCorimBuilder().
+		SetID("test corim id").
+		Build()
+	assert.ErrorContains(t, err, "invalid unsigned corim: ")
+}
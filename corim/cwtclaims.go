@@ -0,0 +1,270 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	cbor "github.com/fxamacker/cbor/v2"
+	cose "github.com/veraison/go-cose"
+)
+
+var (
+	// ErrCWTExpired is returned when the exp claim is in the past
+	ErrCWTExpired = errors.New("CWT claims expired")
+	// ErrCWTNotYetValid is returned when the nbf claim is in the future
+	ErrCWTNotYetValid = errors.New("CWT claims not yet valid")
+)
+
+// CWTClaims stores the CWT claims (RFC 8392) that can be carried in the
+// protected header of a signed-corim using the CWT Claims header parameter
+// (RFC 9597), instead of, or alongside, corim-meta.
+//
+// Times are encoded as NumericDate, i.e., with a resolution of one second.
+type CWTClaims struct {
+	Issuer     *string
+	Subject    *string
+	Expiration *time.Time
+	NotBefore  *time.Time
+	IssuedAt   *time.Time
+}
+
+// cwtClaims is the CBOR serialization of CWTClaims
+type cwtClaims struct {
+	Issuer     *string      `cbor:"1,keyasint,omitempty"`
+	Subject    *string      `cbor:"2,keyasint,omitempty"`
+	Expiration *numericDate `cbor:"4,keyasint,omitempty"`
+	NotBefore  *numericDate `cbor:"5,keyasint,omitempty"`
+	IssuedAt   *numericDate `cbor:"6,keyasint,omitempty"`
+}
+
+// numericDate is a NumericDate (RFC 8392, Section 2), i.e., the untagged
+// number of seconds since the UNIX epoch
+type numericDate int64
+
+// UnmarshalCBOR decodes a NumericDate, which can be either an integer or a
+// floating point number (whose fractional part is dropped)
+func (o *numericDate) UnmarshalCBOR(data []byte) error {
+	var v interface{}
+	if err := dm.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	switch t := v.(type) {
+	case int64:
+		*o = numericDate(t)
+	case uint64:
+		if t > math.MaxInt64 {
+			return fmt.Errorf("NumericDate out of range: %d", t)
+		}
+		*o = numericDate(t)
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return fmt.Errorf("invalid NumericDate: %v", t)
+		}
+		*o = numericDate(t)
+	default:
+		return fmt.Errorf("NumericDate: expected a number but got %T", v)
+	}
+
+	return nil
+}
+
+func newNumericDate(t *time.Time) *numericDate {
+	if t == nil {
+		return nil
+	}
+
+	nd := numericDate(t.Unix())
+	return &nd
+}
+
+func (o *numericDate) time() *time.Time {
+	if o == nil {
+		return nil
+	}
+
+	t := time.Unix(int64(*o), 0).UTC()
+	return &t
+}
+
+// NewCWTClaims instantiates an empty CWTClaims
+func NewCWTClaims() *CWTClaims {
+	return &CWTClaims{}
+}
+
+// SetIssuer sets the iss claim
+func (o *CWTClaims) SetIssuer(iss string) *CWTClaims {
+	if o != nil {
+		o.Issuer = &iss
+	}
+	return o
+}
+
+// SetSubject sets the sub claim
+func (o *CWTClaims) SetSubject(sub string) *CWTClaims {
+	if o != nil {
+		o.Subject = &sub
+	}
+	return o
+}
+
+// SetIssuedAt sets the iat claim
+func (o *CWTClaims) SetIssuedAt(iat time.Time) *CWTClaims {
+	if o != nil {
+		o.IssuedAt = &iat
+	}
+	return o
+}
+
+// SetValidity sets the exp and (optionally) nbf claims
+func (o *CWTClaims) SetValidity(exp time.Time, nbf *time.Time) *CWTClaims {
+	if o != nil {
+		if nbf != nil && exp.Before(*nbf) {
+			return nil
+		}
+
+		o.Expiration = &exp
+		o.NotBefore = nbf
+	}
+	return o
+}
+
+// Valid checks for consistency of the claims in the target CWTClaims
+func (o CWTClaims) Valid() error {
+	if o.Issuer != nil && *o.Issuer == "" {
+		return errors.New("empty iss")
+	}
+
+	if o.Subject != nil && *o.Subject == "" {
+		return errors.New("empty sub")
+	}
+
+	if o.Expiration != nil && o.NotBefore != nil && o.Expiration.Before(*o.NotBefore) {
+		return errors.New("exp before nbf")
+	}
+
+	return nil
+}
+
+// CheckAt checks the exp and nbf claims (if present) against the supplied
+// time. It returns an error wrapping ErrCWTExpired or ErrCWTNotYetValid if
+// the supplied time is outside the window.
+func (o CWTClaims) CheckAt(now time.Time) error {
+	if o.NotBefore != nil && now.Before(*o.NotBefore) {
+		return fmt.Errorf("%w: nbf %s", ErrCWTNotYetValid, o.NotBefore.Format(time.RFC3339))
+	}
+
+	if o.Expiration != nil && !now.Before(*o.Expiration) {
+		return fmt.Errorf("%w: exp %s", ErrCWTExpired, o.Expiration.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// ToCBOR serializes the target CWTClaims to CBOR
+func (o CWTClaims) ToCBOR() ([]byte, error) {
+	if err := o.Valid(); err != nil {
+		return nil, err
+	}
+
+	return em.Marshal(o.toWire())
+}
+
+// FromCBOR deserializes the supplied CBOR data into the target CWTClaims
+func (o *CWTClaims) FromCBOR(data []byte) error {
+	var c cwtClaims
+
+	if err := dm.Unmarshal(data, &c); err != nil {
+		return err
+	}
+
+	o.fromWire(c)
+
+	return nil
+}
+
+func (o CWTClaims) toWire() cwtClaims {
+	return cwtClaims{
+		Issuer:     o.Issuer,
+		Subject:    o.Subject,
+		Expiration: newNumericDate(o.Expiration),
+		NotBefore:  newNumericDate(o.NotBefore),
+		IssuedAt:   newNumericDate(o.IssuedAt),
+	}
+}
+
+func (o *CWTClaims) fromWire(c cwtClaims) {
+	*o = CWTClaims{
+		Issuer:     c.Issuer,
+		Subject:    c.Subject,
+		Expiration: c.Expiration.time(),
+		NotBefore:  c.NotBefore.time(),
+		IssuedAt:   c.IssuedAt.time(),
+	}
+}
+
+// ToMeta maps the target CWTClaims to a corim-meta: iss becomes the signer
+// name, and exp and nbf the validity. Since corim-meta validity requires a
+// not-after time, nbf alone is not mapped.
+func (o CWTClaims) ToMeta() *Meta {
+	m := NewMeta()
+
+	if o.Issuer != nil {
+		m.Signer.Name = *o.Issuer
+	}
+
+	if o.Expiration != nil {
+		m.Validity = &Validity{NotAfter: *o.Expiration, NotBefore: o.NotBefore}
+	}
+
+	return m
+}
+
+// ToCWTClaims maps the target Meta to CWT claims: the signer name becomes iss,
+// and the validity exp and nbf. The signer URI has no CWT counterpart and is
+// not mapped.
+func (o Meta) ToCWTClaims() *CWTClaims {
+	c := NewCWTClaims()
+
+	if o.Signer.Name != "" {
+		c.SetIssuer(o.Signer.Name)
+	}
+
+	if o.Validity != nil {
+		c.Expiration = &o.Validity.NotAfter
+		c.NotBefore = o.Validity.NotBefore
+	}
+
+	return c
+}
+
+// decodeCWTClaimsHeader decodes the value of the CWT Claims header parameter
+// as returned by the COSE header decoder
+func decodeCWTClaimsHeader(v interface{}) (*CWTClaims, error) {
+	switch v.(type) {
+	case map[interface{}]interface{}, cose.CWTClaims:
+	default:
+		return nil, fmt.Errorf("expecting a CWT claims map, got %T instead", v)
+	}
+
+	data, err := cbor.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var c CWTClaims
+	if err := c.FromCBOR(data); err != nil {
+		return nil, fmt.Errorf("unable to decode CWT claims: %w", err)
+	}
+
+	if err := c.Valid(); err != nil {
+		return nil, fmt.Errorf("invalid CWT claims: %w", err)
+	}
+
+	return &c, nil
+}
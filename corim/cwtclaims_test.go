@@ -0,0 +1,197 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cose "github.com/veraison/go-cose"
+)
+
+var (
+	testNbf = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	testExp = time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestCWTClaims_CBOR_roundtrip(t *testing.T) {
+	iat := time.Date(2026, 2, 3, 4, 5, 6, 789, time.UTC)
+
+	in := NewCWTClaims().
+		SetIssuer("ACME Ltd.").
+		SetSubject("acme-corim").
+		SetIssuedAt(iat).
+		SetValidity(testExp, &testNbf)
+	require.NotNil(t, in)
+
+	data, err := in.ToCBOR()
+	require.NoError(t, err)
+
+	// a map with iss, sub, exp, nbf and iat, times are untagged integers
+	assert.Equal(t, byte(0xa5), data[0])
+
+	var out CWTClaims
+	require.NoError(t, out.FromCBOR(data))
+
+	assert.Equal(t, "ACME Ltd.", *out.Issuer)
+	assert.Equal(t, "acme-corim", *out.Subject)
+	assert.True(t, testExp.Equal(*out.Expiration))
+	assert.True(t, testNbf.Equal(*out.NotBefore))
+	assert.True(t, iat.Truncate(time.Second).Equal(*out.IssuedAt))
+}
+
+func TestCWTClaims_FromCBOR_float_date(t *testing.T) {
+	// {4: 1700000000.5}
+	data := []byte{0xa1, 0x04, 0xfb, 0x41, 0xd9, 0x54, 0xfc, 0x40, 0x20, 0x00, 0x00}
+
+	var out CWTClaims
+	require.NoError(t, out.FromCBOR(data))
+	assert.Equal(t, int64(1700000000), out.Expiration.Unix())
+
+	// {4: "now"}
+	err := out.FromCBOR([]byte{0xa1, 0x04, 0x63, 0x6e, 0x6f, 0x77})
+	assert.EqualError(t, err, "NumericDate: expected a number but got string")
+}
+
+func TestCWTClaims_Valid(t *testing.T) {
+	assert.NoError(t, CWTClaims{}.Valid())
+
+	empty := ""
+	assert.EqualError(t, CWTClaims{Issuer: &empty}.Valid(), "empty iss")
+	assert.EqualError(t, CWTClaims{Subject: &empty}.Valid(), "empty sub")
+	assert.EqualError(t, CWTClaims{Expiration: &testNbf, NotBefore: &testExp}.Valid(), "exp before nbf")
+
+	assert.Nil(t, NewCWTClaims().SetValidity(testNbf, &testExp))
+}
+
+func TestCWTClaims_CheckAt(t *testing.T) {
+	c := NewCWTClaims().SetValidity(testExp, &testNbf)
+
+	assert.NoError(t, c.CheckAt(testNbf))
+	assert.NoError(t, c.CheckAt(testExp.Add(-time.Second)))
+
+	err := c.CheckAt(testNbf.Add(-time.Second))
+	assert.ErrorIs(t, err, ErrCWTNotYetValid)
+	assert.EqualError(t, err, "CWT claims not yet valid: nbf 2026-01-01T00:00:00Z")
+
+	err = c.CheckAt(testExp)
+	assert.ErrorIs(t, err, ErrCWTExpired)
+	assert.EqualError(t, err, "CWT claims expired: exp 2027-01-01T00:00:00Z")
+
+	assert.NoError(t, CWTClaims{}.CheckAt(time.Now()))
+}
+
+func TestCWTClaims_Meta_mapping(t *testing.T) {
+	m := NewMeta().SetSigner("ACME Ltd.", nil).SetValidity(testExp, &testNbf)
+	require.NotNil(t, m)
+
+	c := m.ToCWTClaims()
+	assert.Equal(t, "ACME Ltd.", *c.Issuer)
+	assert.Equal(t, testExp, *c.Expiration)
+	assert.Equal(t, testNbf, *c.NotBefore)
+	assert.Nil(t, c.Subject)
+
+	assert.Equal(t, m, c.ToMeta())
+
+	// nbf alone has no corim-meta counterpart
+	c = &CWTClaims{NotBefore: &testNbf}
+	assert.Nil(t, c.ToMeta().Validity)
+}
+
+func signWithCWTClaims(t *testing.T, meta Meta, claims *CWTClaims) []byte {
+	t.Helper()
+
+	signer, err := NewSignerFromJWK(testES256Key)
+	require.NoError(t, err)
+
+	in := SignedCorim{
+		UnsignedCorim: *unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR),
+		Meta:          meta,
+		CWTClaims:     claims,
+	}
+
+	data, err := in.Sign(signer)
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestSignedCorim_SignVerify_CWTClaims_only(t *testing.T) {
+	claims := NewCWTClaims().SetIssuer("ACME Ltd.").SetValidity(testExp, &testNbf)
+
+	data := signWithCWTClaims(t, Meta{}, claims)
+
+	var msg cose.Sign1Message
+	require.NoError(t, msg.UnmarshalCBOR(data))
+	assert.NotContains(t, msg.Headers.Protected, HeaderLabelCorimMeta)
+	assert.Contains(t, msg.Headers.Protected, cose.HeaderLabelCWTClaims)
+
+	var out SignedCorim
+	require.NoError(t, out.FromCOSE(data))
+
+	require.NotNil(t, out.CWTClaims)
+	assert.Equal(t, "ACME Ltd.", *out.CWTClaims.Issuer)
+
+	// corim-meta is derived from the CWT claims
+	assert.Equal(t, "ACME Ltd.", out.Meta.Signer.Name)
+	require.NotNil(t, out.Meta.Validity)
+	assert.True(t, testExp.Equal(out.Meta.Validity.NotAfter))
+
+	pk, err := NewPublicKeyFromJWK(testES256Key)
+	require.NoError(t, err)
+	assert.NoError(t, out.Verify(pk))
+
+	assert.NoError(t, out.VerifyCWTClaims(testNbf.Add(time.Hour)))
+	assert.ErrorIs(t, out.VerifyCWTClaims(testExp.Add(time.Hour)), ErrCWTExpired)
+	assert.ErrorIs(t, out.VerifyCWTClaims(testNbf.Add(-time.Hour)), ErrCWTNotYetValid)
+}
+
+func TestSignedCorim_SignVerify_CWTClaims_with_meta(t *testing.T) {
+	meta := *metaGood(t)
+	claims := meta.ToCWTClaims().SetSubject("acme-corim")
+
+	data := signWithCWTClaims(t, meta, claims)
+
+	var out SignedCorim
+	require.NoError(t, out.FromCOSE(data))
+	assert.Equal(t, meta.Signer.Name, out.Meta.Signer.Name)
+	assert.Equal(t, "acme-corim", *out.CWTClaims.Subject)
+
+	// the CWT claims must agree with corim-meta
+	claims.SetIssuer("Other Ltd.")
+	data = signWithCWTClaims(t, meta, claims)
+
+	err := out.FromCOSE(data)
+	assert.ErrorContains(t, err, `CWT claims: iss "Other Ltd." does not match corim.meta signer name`)
+	assert.Nil(t, out.CWTClaims)
+
+	claims = meta.ToCWTClaims().SetValidity(testExp, nil)
+	data = signWithCWTClaims(t, meta, claims)
+
+	err = out.FromCOSE(data)
+	assert.EqualError(t, err, "processing COSE headers: CWT claims: exp does not match corim.meta not-after")
+}
+
+func TestSignedCorim_VerifyCWTClaims_no_claims(t *testing.T) {
+	var sc SignedCorim
+	assert.ErrorIs(t, sc.VerifyCWTClaims(time.Now()), errNoSign1Message)
+
+	data := signWithCWTClaims(t, *metaGood(t), nil)
+	require.NoError(t, sc.FromCOSE(data))
+	assert.Nil(t, sc.CWTClaims)
+	assert.NoError(t, sc.VerifyCWTClaims(time.Now()))
+}
+
+func TestDecodeCWTClaimsHeader_NOK(t *testing.T) {
+	_, err := decodeCWTClaimsHeader([]byte{0xa0})
+	assert.EqualError(t, err, "expecting a CWT claims map, got []uint8 instead")
+
+	_, err = decodeCWTClaimsHeader(map[interface{}]interface{}{int64(1): int64(3)})
+	assert.ErrorContains(t, err, "unable to decode CWT claims")
+
+	_, err = decodeCWTClaimsHeader(cose.CWTClaims{int64(1): ""})
+	assert.EqualError(t, err, "invalid CWT claims: empty iss")
+}
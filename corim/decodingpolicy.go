@@ -0,0 +1,113 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"fmt"
+
+	"github.com/veraison/corim/encoding"
+	"github.com/veraison/go-cose"
+)
+
+// DecodingPolicy controls how the UnmarshalAndValidate* functions decode their
+// input. The zero value selects the default (lenient) decoding.
+type DecodingPolicy struct {
+	// Strict rejects input that a lenient decoder would accept: trailing
+	// data, duplicate map keys, CBOR that is not deterministically encoded
+	// (indefinite lengths, non-preferred arguments or floats, unsorted map
+	// keys), and map keys that are neither part of the base structures nor
+	// of the extensions registered for the CoRIM's profile. It also enforces
+	// Limits. CBOR wrapped in byte strings (the COSE protected header and
+	// payload, and the tags inside the CoRIM) is checked as well.
+	//
+	// Unknown keys are detected only in extensible maps; other maps are
+	// decoded into fixed structures that ignore keys they don't know about.
+	Strict bool
+	// Limits bounds the size, nesting depth and number of array and map
+	// entries of the input, and of each CBOR item wrapped in it. It is
+	// only used if Strict is set. Zero fields select the defaults in the
+	// encoding package.
+	Limits encoding.Limits
+}
+
+// StrictDecoding is a DecodingPolicy that enables strict decoding with the
+// default limits, suitable for untrusted input.
+var StrictDecoding = DecodingPolicy{Strict: true}
+
+func lastDecodingPolicy(policy []DecodingPolicy) DecodingPolicy {
+	if len(policy) == 0 {
+		return DecodingPolicy{}
+	}
+
+	return policy[len(policy)-1]
+}
+
+func (o DecodingPolicy) checkCBOR(data []byte) error {
+	if !o.Strict {
+		return nil
+	}
+
+	if err := encoding.CheckStrictCBOR(data, o.Limits); err != nil {
+		return fmt.Errorf("strict decoding: %w", err)
+	}
+
+	return nil
+}
+
+func (o DecodingPolicy) checkJSON(data []byte) error {
+	if !o.Strict {
+		return nil
+	}
+
+	if err := encoding.CheckStrictJSON(data, o.Limits); err != nil {
+		return fmt.Errorf("strict decoding: %w", err)
+	}
+
+	return nil
+}
+
+func (o DecodingPolicy) checkCOSE(data []byte) error {
+	if !o.Strict {
+		return nil
+	}
+
+	if err := o.checkCBOR(data); err != nil {
+		return err
+	}
+
+	message := cose.NewSign1Message()
+	if err := message.UnmarshalCBOR(data); err != nil {
+		return fmt.Errorf("failed CBOR decoding for COSE-Sign1 signed CoRIM: %w", err)
+	}
+
+	// RawProtected is the byte string that wraps the encoded header map
+	var protected []byte
+	if err := dm.Unmarshal(message.Headers.RawProtected, &protected); err != nil {
+		return fmt.Errorf("protected header: %w", err)
+	}
+
+	if len(protected) != 0 {
+		if err := o.checkCBOR(protected); err != nil {
+			return fmt.Errorf("protected header: %w", err)
+		}
+	}
+
+	if err := o.checkCBOR(message.Payload); err != nil {
+		return fmt.Errorf("payload: %w", err)
+	}
+
+	return nil
+}
+
+func (o DecodingPolicy) checkDecoded(v any) error {
+	if !o.Strict {
+		return nil
+	}
+
+	if err := encoding.CheckNoCachedFields(v); err != nil {
+		return fmt.Errorf("strict decoding: %w", err)
+	}
+
+	return nil
+}
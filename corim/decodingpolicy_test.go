@@ -0,0 +1,157 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/encoding"
+)
+
+func strictTestCorim(t *testing.T) *UnsignedCorim {
+	c := NewUnsignedCorim().SetID("strict").AddComid(testCotlComid("tag-a"))
+	require.NotNil(t, c)
+
+	return c
+}
+
+// addUnknownKey returns the encoding of the CBOR map in data, with an extra
+// entry using a key that is not defined by any structure
+func addUnknownKey(t *testing.T, data []byte) []byte {
+	var m map[int]cbor.RawMessage
+	require.NoError(t, dm.Unmarshal(data, &m))
+
+	m[99] = cbor.RawMessage{0xf5}
+
+	out, err := em.Marshal(m)
+	require.NoError(t, err)
+
+	return out
+}
+
+func TestDecodingPolicy_unsigned_CBOR(t *testing.T) {
+	data, err := strictTestCorim(t).ToCBOR()
+	require.NoError(t, err)
+
+	_, err = UnmarshalAndValidateUnsignedCorimFromCBOR(data, StrictDecoding)
+	assert.NoError(t, err)
+
+	_, err = UnmarshalAndValidateUnsignedCorimFromCBOR(data,
+		DecodingPolicy{Strict: true, Limits: encoding.Limits{MaxNestedLevels: 2}})
+	assert.EqualError(t, err, "strict decoding: offset 13: exceeded max nested levels 2")
+
+	_, err = UnmarshalAndValidateUnsignedCorimFromCBOR(append(bytes.Clone(data), 0x00), StrictDecoding)
+	assert.EqualError(t, err, "strict decoding: 1 trailing bytes after the data item")
+
+	// the last policy wins
+	_, err = UnmarshalAndValidateUnsignedCorimFromCBOR(data,
+		DecodingPolicy{Strict: true, Limits: encoding.Limits{MaxNestedLevels: 2}}, StrictDecoding)
+	assert.NoError(t, err)
+}
+
+func TestDecodingPolicy_unsigned_CBOR_unknown_key(t *testing.T) {
+	data, err := strictTestCorim(t).ToCBOR()
+	require.NoError(t, err)
+
+	data = append(bytes.Clone(UnsignedCorimTag), addUnknownKey(t, data[3:])...)
+
+	_, err = UnmarshalAndValidateUnsignedCorimFromCBOR(data)
+	assert.NoError(t, err)
+
+	_, err = UnmarshalAndValidateUnsignedCorimFromCBOR(data, StrictDecoding)
+	assert.EqualError(t, err, "strict decoding: unknown keys: 99")
+
+	c := strictTestCorim(t)
+	c.Tags[0].Content = addUnknownKey(t, c.Tags[0].Content)
+
+	data, err = c.ToCBOR()
+	require.NoError(t, err)
+
+	_, err = UnmarshalAndValidateUnsignedCorimFromCBOR(data)
+	assert.NoError(t, err)
+
+	_, err = UnmarshalAndValidateUnsignedCorimFromCBOR(data, StrictDecoding)
+	assert.EqualError(t, err, "CoMID tag at index 0: strict decoding: unknown keys: 99")
+}
+
+func TestDecodingPolicy_unsigned_CBOR_indefinite_length_tag(t *testing.T) {
+	c := strictTestCorim(t)
+
+	// re-encode the CoMID map with an indefinite length
+	content := c.Tags[0].Content
+	require.Equal(t, byte(0xa0), content[0]&0xe0)
+	content = append(append([]byte{0xbf}, content[1:]...), 0xff)
+	c.Tags[0].Content = content
+
+	data, err := c.ToCBOR()
+	require.NoError(t, err)
+
+	_, err = UnmarshalAndValidateUnsignedCorimFromCBOR(data)
+	assert.NoError(t, err)
+
+	_, err = UnmarshalAndValidateUnsignedCorimFromCBOR(data, StrictDecoding)
+	assert.EqualError(t, err, "tag at index 0: strict decoding: offset 0: indefinite length encoding")
+}
+
+func TestDecodingPolicy_unsigned_JSON(t *testing.T) {
+	data, err := strictTestCorim(t).ToJSON()
+	require.NoError(t, err)
+
+	_, err = UnmarshalAndValidateUnsignedCorimFromJSON(data, StrictDecoding)
+	assert.NoError(t, err)
+
+	dup := append([]byte(`{"corim-id": "other", `), data[1:]...)
+
+	_, err = UnmarshalAndValidateUnsignedCorimFromJSON(dup)
+	assert.NoError(t, err)
+
+	_, err = UnmarshalAndValidateUnsignedCorimFromJSON(dup, StrictDecoding)
+	assert.ErrorContains(t, err, `strict decoding: offset `)
+	assert.ErrorContains(t, err, `duplicate member name "corim-id"`)
+
+	unknown := append([]byte(`{"foo": "bar", `), data[1:]...)
+
+	_, err = UnmarshalAndValidateUnsignedCorimFromJSON(unknown, StrictDecoding)
+	assert.EqualError(t, err, "strict decoding: unknown keys: foo")
+}
+
+func TestDecodingPolicy_signed_CBOR(t *testing.T) {
+	signer, err := NewSignerFromJWK(testES256Key)
+	require.NoError(t, err)
+
+	sc := SignedCorim{
+		UnsignedCorim: *strictTestCorim(t),
+		Meta:          *metaGood(t),
+	}
+
+	data, err := sc.Sign(signer)
+	require.NoError(t, err)
+
+	_, err = UnmarshalAndValidateSignedCorimFromCBOR(data, StrictDecoding)
+	assert.NoError(t, err)
+
+	_, err = UnmarshalAndValidateSignedCorimFromCBOR(append(bytes.Clone(data), 0x00), StrictDecoding)
+	assert.EqualError(t, err, "strict decoding: 1 trailing bytes after the data item")
+
+	// a CoMID that is not deterministically encoded, inside the signed payload
+	sc.UnsignedCorim.Tags[0].Content = append(
+		[]byte{0xbf}, append(sc.UnsignedCorim.Tags[0].Content[1:], 0xff)...)
+
+	data, err = sc.Sign(signer)
+	require.NoError(t, err)
+
+	_, err = UnmarshalAndValidateSignedCorimFromCBOR(data)
+	assert.NoError(t, err)
+
+	_, err = UnmarshalAndValidateSignedCorimFromCBOR(data, StrictDecoding)
+	assert.EqualError(t, err, "tag at index 0: strict decoding: offset 0: indefinite length encoding")
+
+	_, err = UnmarshalAndValidateSignedCorimFromCBOR(data,
+		DecodingPolicy{Strict: true, Limits: encoding.Limits{MaxBytes: 64}})
+	assert.ErrorContains(t, err, "strict decoding: input size ")
+}
@@ -106,6 +106,28 @@ func (o Entity) Valid() error {
 	return o.validEntity(&o)
 }
 
+// ValidateAll is like Valid, except that, rather than stopping at the first
+// violation, it returns all of them as a comid.ValidationErrors.
+func (o Entity) ValidateAll() error {
+	v := comid.NewValidator()
+
+	if o.Name == nil {
+		v.Field("name", 0).Check(errors.New("empty entity-name"))
+	} else {
+		v.Field("name", 0).Check(o.Name.Valid())
+	}
+
+	if o.RegID != nil && o.RegID.Empty() {
+		v.Field("regid", 1).Check(errors.New("empty reg-id"))
+	}
+
+	v.Field("roles", 2).Check(o.Roles.Valid())
+
+	v.Check(o.validEntity(&o))
+
+	return v.Err()
+}
+
 // UnmarshalCBOR deserializes from CBOR
 func (o *Entity) UnmarshalCBOR(data []byte) error {
 	return encoding.PopulateStructFromCBOR(dm, data, o)
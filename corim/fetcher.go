@@ -0,0 +1,117 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+)
+
+// ErrRimNotFound is returned by a Fetcher when there is no CoRIM at the
+// requested location.
+var ErrRimNotFound = errors.New("CoRIM not found")
+
+// Fetcher retrieves the CBOR-encoded (signed or unsigned) CoRIM found at the
+// supplied href, i.e., one of the hrefs of a corim-locator-map.
+type Fetcher interface {
+	Fetch(href string) ([]byte, error)
+}
+
+// MemoryFetcher is a Fetcher that serves CoRIMs from memory, keyed by href.
+type MemoryFetcher map[string][]byte
+
+// NewMemoryFetcher instantiates an empty MemoryFetcher
+func NewMemoryFetcher() *MemoryFetcher {
+	return &MemoryFetcher{}
+}
+
+// Add associates the supplied CBOR-encoded CoRIM with the supplied href
+func (o *MemoryFetcher) Add(href string, data []byte) *MemoryFetcher {
+	if o != nil {
+		(*o)[href] = data
+	}
+	return o
+}
+
+// Fetch returns the CoRIM associated with the supplied href
+func (o MemoryFetcher) Fetch(href string) ([]byte, error) {
+	data, ok := o[href]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrRimNotFound, href)
+	}
+
+	return data, nil
+}
+
+// DirFetcher is a Fetcher that serves CoRIMs from a local directory. An href
+// is mapped to a file under Root as follows:
+//   - a relative reference (e.g. "deps/fw.cbor") is taken relative to Root
+//   - a file URI (e.g. "file:///deps/fw.cbor") is taken relative to Root,
+//     ignoring the leading "/"
+//   - any other URI (e.g. "https://example.com/deps/fw.cbor") is mapped to a
+//     path made of its host and path (e.g. "example.com/deps/fw.cbor"),
+//     which allows mirroring remote CoRIMs into Root
+//
+// Files outside of Root cannot be accessed.
+type DirFetcher struct {
+	Root string
+}
+
+// NewDirFetcher instantiates a DirFetcher serving CoRIMs from the supplied
+// directory
+func NewDirFetcher(root string) *DirFetcher {
+	return &DirFetcher{Root: root}
+}
+
+// Fetch returns the content of the file the supplied href maps to
+func (o DirFetcher) Fetch(href string) ([]byte, error) {
+	name, err := dirFetcherPath(href)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := os.OpenRoot(o.Root)
+	if err != nil {
+		return nil, fmt.Errorf("opening CoRIM directory: %w", err)
+	}
+	defer root.Close()
+
+	data, err := root.ReadFile(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrRimNotFound, href)
+		}
+		return nil, fmt.Errorf("fetching %s: %w", href, err)
+	}
+
+	return data, nil
+}
+
+func dirFetcherPath(href string) (string, error) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", fmt.Errorf("invalid href %q: %w", href, err)
+	}
+
+	var p string
+
+	switch u.Scheme {
+	case "", "file":
+		p = u.Path
+	default:
+		p = path.Join(u.Host, u.Path)
+	}
+
+	p = strings.TrimPrefix(path.Clean("/"+p), "/")
+	if p == "" {
+		return "", fmt.Errorf("invalid href %q: no path", href)
+	}
+
+	return p, nil
+}
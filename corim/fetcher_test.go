@@ -0,0 +1,64 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryFetcher_Fetch(t *testing.T) {
+	f := NewMemoryFetcher().Add("a", []byte{0x01})
+
+	data, err := f.Fetch("a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x01}, data)
+
+	_, err = f.Fetch("b")
+	assert.ErrorIs(t, err, ErrRimNotFound)
+	assert.EqualError(t, err, "CoRIM not found: b")
+}
+
+func TestDirFetcher_Fetch(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "deps"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "example.com", "rims"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "deps", "a.cbor"), []byte{0x0a}, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "example.com", "rims", "b.cbor"), []byte{0x0b}, 0o600))
+
+	f := NewDirFetcher(root)
+
+	for href, expected := range map[string][]byte{
+		"deps/a.cbor":                        {0x0a},
+		"file:///deps/a.cbor":                {0x0a},
+		"https://example.com/rims/b.cbor":    {0x0b},
+		"../deps/a.cbor":                     {0x0a},
+		"https://example.com/../deps/a.cbor": {0x0a},
+	} {
+		data, err := f.Fetch(href)
+		require.NoError(t, err, href)
+		assert.Equal(t, expected, data, href)
+	}
+
+	_, err := f.Fetch("deps/missing.cbor")
+	assert.ErrorIs(t, err, ErrRimNotFound)
+
+	_, err = f.Fetch("file:///")
+	assert.EqualError(t, err, `invalid href "file:///": no path`)
+}
+
+func TestDirFetcher_Fetch_resolver(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "a.cbor"), testRimCBOR(t, "a", "b.cbor"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "b.cbor"), testRimCBOR(t, "b"), 0o600))
+
+	node, err := Resolver{Fetcher: NewDirFetcher(root), AllowUnsigned: true}.
+		Resolve(testRimCorim(t, "root", "a.cbor"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"root", "a", "b"}, rimIDs(node.All()))
+}
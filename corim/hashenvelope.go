@@ -0,0 +1,141 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+
+	cose "github.com/veraison/go-cose"
+)
+
+// COSE header labels defined by COSE Hash Envelope
+//
+// See https://datatracker.ietf.org/doc/draft-ietf-cose-hash-envelope/
+var (
+	HeaderLabelPayloadHashAlg      = int64(258)
+	HeaderLabelPreimageContentType = int64(259)
+	HeaderLabelPayloadLocation     = int64(260)
+)
+
+// COSE algorithm identifiers of the hash functions that can be used as
+// payload-hash-alg
+const (
+	HashAlgSHA256 = cose.Algorithm(-16)
+	HashAlgSHA384 = cose.Algorithm(-43)
+	HashAlgSHA512 = cose.Algorithm(-44)
+)
+
+// HashEnvelope configures COSE Hash Envelope mode for a SignedCorim: instead
+// of the unsigned-corim, the COSE_Sign1 payload carries its hash, computed
+// with HashAlg. PayloadLocation optionally tells the verifier where the
+// unsigned-corim (the preimage) can be retrieved from.
+type HashEnvelope struct {
+	HashAlg         cose.Algorithm
+	PayloadLocation string
+}
+
+// Valid checks that the hash algorithm is supported
+func (o HashEnvelope) Valid() error {
+	if _, err := hashEnvelopeHash(o.HashAlg); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Digest returns the hash of the supplied preimage using the configured hash
+// algorithm
+func (o HashEnvelope) Digest(preimage []byte) ([]byte, error) {
+	h, err := hashEnvelopeHash(o.HashAlg)
+	if err != nil {
+		return nil, err
+	}
+
+	hh := h.New()
+	hh.Write(preimage)
+
+	return hh.Sum(nil), nil
+}
+
+func hashEnvelopeHash(alg cose.Algorithm) (crypto.Hash, error) {
+	var h crypto.Hash
+
+	switch alg {
+	case HashAlgSHA256:
+		h = crypto.SHA256
+	case HashAlgSHA384:
+		h = crypto.SHA384
+	case HashAlgSHA512:
+		h = crypto.SHA512
+	default:
+		return 0, fmt.Errorf("unsupported payload-hash-alg %d", int64(alg))
+	}
+
+	if !h.Available() {
+		return 0, fmt.Errorf("payload-hash-alg %d not available", int64(alg))
+	}
+
+	return h, nil
+}
+
+// setHeaders populates the protected header of a hash envelope. The
+// content-type of the unsigned-corim moves to the preimage content type, and
+// the content type header is omitted.
+func (o HashEnvelope) setHeaders(hdr cose.ProtectedHeader) {
+	delete(hdr, cose.HeaderLabelContentType)
+
+	hdr[HeaderLabelPayloadHashAlg] = int64(o.HashAlg)
+	hdr[HeaderLabelPreimageContentType] = ContentType
+
+	if o.PayloadLocation != "" {
+		hdr[HeaderLabelPayloadLocation] = o.PayloadLocation
+	}
+}
+
+// decodeHashEnvelopeHeaders extracts the hash envelope parameters from the
+// supplied protected header. It returns nil if the header does not describe
+// a hash envelope.
+func decodeHashEnvelopeHeaders(hdr cose.ProtectedHeader) (*HashEnvelope, error) {
+	v, ok := hdr[HeaderLabelPayloadHashAlg]
+	if !ok {
+		return nil, nil
+	}
+
+	var he HashEnvelope
+
+	switch t := v.(type) {
+	case int64:
+		he.HashAlg = cose.Algorithm(t)
+	default:
+		return nil, fmt.Errorf("payload-hash-alg: expected an integer but got %T", v)
+	}
+
+	if err := he.Valid(); err != nil {
+		return nil, err
+	}
+
+	if _, ok := hdr[cose.HeaderLabelContentType]; ok {
+		return nil, errors.New("content type must not be set in a hash envelope")
+	}
+
+	if v, ok := hdr[HeaderLabelPreimageContentType]; ok {
+		if v != ContentType {
+			return nil, fmt.Errorf("expecting preimage content type %q, got %q instead", ContentType, v)
+		}
+	} else {
+		return nil, errors.New("missing mandatory preimage content type")
+	}
+
+	if v, ok := hdr[HeaderLabelPayloadLocation]; ok {
+		loc, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("payload-location: expected a string but got %T", v)
+		}
+		he.PayloadLocation = loc
+	}
+
+	return &he, nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"crypto/sha512"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cose "github.com/veraison/go-cose"
+)
+
+func TestHashEnvelope_Digest(t *testing.T) {
+	data := []byte("preimage")
+
+	d, err := HashEnvelope{HashAlg: HashAlgSHA384}.Digest(data)
+	require.NoError(t, err)
+	expected := sha512.Sum384(data)
+	assert.Equal(t, expected[:], d)
+
+	for _, alg := range []cose.Algorithm{HashAlgSHA256, HashAlgSHA384, HashAlgSHA512} {
+		assert.NoError(t, HashEnvelope{HashAlg: alg}.Valid())
+	}
+
+	_, err = HashEnvelope{HashAlg: cose.AlgorithmES256}.Digest(data)
+	assert.EqualError(t, err, "unsupported payload-hash-alg -7")
+}
+
+func hashEnvelopeSign(t *testing.T, he HashEnvelope, detached bool) ([]byte, []byte) {
+	t.Helper()
+
+	signer, err := NewSignerFromJWK(testES256Key)
+	require.NoError(t, err)
+
+	in := SignedCorim{
+		UnsignedCorim: *unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR),
+		Meta:          *metaGood(t),
+		Detached:      detached,
+		HashEnvelope:  &he,
+	}
+
+	cbor, err := in.Sign(signer)
+	require.NoError(t, err)
+
+	preimage, err := in.UnsignedCorim.ToCBOR()
+	require.NoError(t, err)
+
+	return cbor, preimage
+}
+
+func TestSignedCorim_SignVerify_hash_envelope_ok(t *testing.T) {
+	pk, err := NewPublicKeyFromJWK(testES256Key)
+	require.NoError(t, err)
+
+	for _, detached := range []bool{false, true} {
+		he := HashEnvelope{HashAlg: HashAlgSHA256, PayloadLocation: "https://example.com/corim.cbor"}
+		cbor, preimage := hashEnvelopeSign(t, he, detached)
+
+		var msg cose.Sign1Message
+		require.NoError(t, msg.UnmarshalCBOR(cbor))
+		assert.NotContains(t, msg.Headers.Protected, cose.HeaderLabelContentType)
+		assert.Equal(t, ContentType, msg.Headers.Protected[HeaderLabelPreimageContentType])
+		assert.Equal(t, int64(HashAlgSHA256), msg.Headers.Protected[HeaderLabelPayloadHashAlg])
+		if detached {
+			assert.Nil(t, msg.Payload)
+		} else {
+			assert.Len(t, msg.Payload, 32)
+		}
+
+		var out SignedCorim
+
+		err = out.FromCOSE(cbor)
+		assert.EqualError(t, err, "hash envelope: preimage not supplied")
+
+		require.NoError(t, out.FromCOSEDetached(cbor, preimage))
+		assert.Equal(t, detached, out.Detached)
+		assert.Equal(t, &he, out.HashEnvelope)
+		assert.Equal(t, "test corim id", out.UnsignedCorim.GetID())
+		assert.Equal(t, metaGood(t).Signer.Name, out.Meta.Signer.Name)
+		assert.NoError(t, out.Verify(pk))
+	}
+}
+
+func TestSignedCorim_FromCOSEDetached_hash_envelope_mismatch(t *testing.T) {
+	cbor, preimage := hashEnvelopeSign(t, HashEnvelope{HashAlg: HashAlgSHA512}, false)
+
+	var out SignedCorim
+
+	err := out.FromCOSEDetached(cbor, preimage[1:])
+	assert.EqualError(t, err, "hash envelope: preimage does not match payload hash")
+	assert.Nil(t, out.HashEnvelope)
+
+	// when detached, a wrong preimage is caught by signature verification
+	cbor, preimage = hashEnvelopeSign(t, HashEnvelope{HashAlg: HashAlgSHA512}, true)
+
+	other := unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR)
+	other.SetID("other corim id")
+	otherPreimage, err := other.ToCBOR()
+	require.NoError(t, err)
+
+	require.NoError(t, out.FromCOSEDetached(cbor, otherPreimage))
+
+	pk, err := NewPublicKeyFromJWK(testES256Key)
+	require.NoError(t, err)
+	assert.EqualError(t, out.Verify(pk), "verification error")
+
+	require.NoError(t, out.FromCOSEDetached(cbor, preimage))
+	assert.NoError(t, out.Verify(pk))
+}
+
+func TestSignedCorim_Sign_hash_envelope_NOK(t *testing.T) {
+	signer, err := NewSignerFromJWK(testES256Key)
+	require.NoError(t, err)
+
+	in := SignedCorim{
+		UnsignedCorim: *unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR),
+		HashEnvelope:  &HashEnvelope{HashAlg: cose.Algorithm(-1)},
+	}
+
+	_, err = in.Sign(signer)
+	assert.EqualError(t, err, "hash envelope: unsupported payload-hash-alg -1")
+}
+
+func TestDecodeHashEnvelopeHeaders(t *testing.T) {
+	he, err := decodeHashEnvelopeHeaders(cose.ProtectedHeader{
+		cose.HeaderLabelContentType: ContentType,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, he)
+
+	for _, tv := range []struct {
+		hdr      cose.ProtectedHeader
+		expected string
+	}{
+		{
+			cose.ProtectedHeader{HeaderLabelPayloadHashAlg: "sha-256"},
+			"payload-hash-alg: expected an integer but got string",
+		},
+		{
+			cose.ProtectedHeader{HeaderLabelPayloadHashAlg: int64(-7)},
+			"unsupported payload-hash-alg -7",
+		},
+		{
+			cose.ProtectedHeader{
+				HeaderLabelPayloadHashAlg:      int64(HashAlgSHA256),
+				HeaderLabelPreimageContentType: ContentType,
+				cose.HeaderLabelContentType:    ContentType,
+			},
+			"content type must not be set in a hash envelope",
+		},
+		{
+			cose.ProtectedHeader{HeaderLabelPayloadHashAlg: int64(HashAlgSHA256)},
+			"missing mandatory preimage content type",
+		},
+		{
+			cose.ProtectedHeader{
+				HeaderLabelPayloadHashAlg:      int64(HashAlgSHA256),
+				HeaderLabelPreimageContentType: "application/cbor",
+			},
+			`expecting preimage content type "application/rim+cbor", got "application/cbor" instead`,
+		},
+		{
+			cose.ProtectedHeader{
+				HeaderLabelPayloadHashAlg:      int64(HashAlgSHA256),
+				HeaderLabelPreimageContentType: ContentType,
+				HeaderLabelPayloadLocation:     []byte("x"),
+			},
+			"payload-location: expected a string but got []uint8",
+		},
+	} {
+		_, err := decodeHashEnvelopeHeaders(tv.hdr)
+		assert.EqualError(t, err, tv.expected)
+	}
+}
@@ -0,0 +1,405 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha1" // nolint:gosec
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/veraison/corim/cots"
+	cose "github.com/veraison/go-cose"
+)
+
+var (
+	// ErrNoKeyID is returned when verifying against a KeySet a signed CoRIM
+	// that has no kid header.
+	ErrNoKeyID = errors.New("no kid in signed CoRIM")
+	// ErrKeyNotFound is returned when the kid of a signed CoRIM does not
+	// identify any key in a KeySet.
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrKeyAlgMismatch is returned when the keys identified by the kid of a
+	// signed CoRIM cannot be used with the algorithm in its alg header.
+	ErrKeyAlgMismatch = errors.New("key algorithm mismatch")
+)
+
+// the algorithms a KeySet key can be restricted to
+var keySetAlgs = []cose.Algorithm{
+	cose.AlgorithmES256,
+	cose.AlgorithmES384,
+	cose.AlgorithmES512,
+	cose.AlgorithmEdDSA,
+	cose.AlgorithmPS256,
+	cose.AlgorithmPS384,
+	cose.AlgorithmPS512,
+	cose.AlgorithmRS256,
+	cose.AlgorithmRS384,
+	cose.AlgorithmRS512,
+}
+
+// VerificationKey is a public key in a KeySet. If Algorithm is not zero, the
+// key may only be used with that algorithm.
+type VerificationKey struct {
+	KeyID     []byte
+	Algorithm cose.Algorithm
+	Key       crypto.PublicKey
+}
+
+// checkAlg checks that the key can be used with the supplied algorithm
+func (o VerificationKey) checkAlg(alg cose.Algorithm) error {
+	if o.Algorithm != 0 && o.Algorithm != alg {
+		return fmt.Errorf("%w: key restricted to %s, got %s", ErrKeyAlgMismatch, o.Algorithm, alg)
+	}
+
+	var ok bool
+
+	switch k := o.Key.(type) {
+	case *ecdsa.PublicKey:
+		ok = ellipticCurveToAlg(k.Curve) == alg
+	case ed25519.PublicKey:
+		ok = alg == cose.AlgorithmEdDSA
+	case *rsa.PublicKey:
+		switch alg {
+		case cose.AlgorithmPS256, cose.AlgorithmPS384, cose.AlgorithmPS512,
+			cose.AlgorithmRS256, cose.AlgorithmRS384, cose.AlgorithmRS512:
+			ok = true
+		}
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: %s key cannot be used with %s", ErrKeyAlgMismatch, reflect.TypeOf(o.Key), alg)
+	}
+
+	return nil
+}
+
+// KeySet is a set of public keys indexed by their key identifier (kid), used
+// to verify signed CoRIMs without knowing the signing key in advance. Several
+// keys may share the same kid. The zero value is an empty KeySet ready to use.
+type KeySet struct {
+	keys map[string][]VerificationKey
+}
+
+// NewKeySet instantiates an empty KeySet
+func NewKeySet() *KeySet {
+	return &KeySet{keys: make(map[string][]VerificationKey)}
+}
+
+// Add adds the supplied public key with the supplied kid to the target KeySet.
+// If alg is not zero, the key may only be used with that algorithm.
+func (o *KeySet) Add(kid []byte, pk crypto.PublicKey, alg cose.Algorithm) error {
+	if len(kid) == 0 {
+		return errors.New("empty kid")
+	}
+
+	switch pk.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+	default:
+		return fmt.Errorf("unsupported public key type %v", reflect.TypeOf(pk))
+	}
+
+	k := VerificationKey{KeyID: kid, Algorithm: alg, Key: pk}
+
+	if alg != 0 {
+		if err := k.checkAlg(alg); err != nil {
+			return err
+		}
+	}
+
+	if o.keys == nil {
+		o.keys = make(map[string][]VerificationKey)
+	}
+
+	id := string(kid)
+	o.keys[id] = append(o.keys[id], k)
+
+	return nil
+}
+
+// Lookup returns the keys identified by the supplied kid
+func (o KeySet) Lookup(kid []byte) []VerificationKey {
+	return o.keys[string(kid)]
+}
+
+// Len returns the number of keys in the target KeySet
+func (o KeySet) Len() int {
+	n := 0
+	for _, ks := range o.keys {
+		n += len(ks)
+	}
+	return n
+}
+
+// NewKeySetFromJWKS creates a KeySet from the public keys in the supplied
+// JWK Set. Every key must have a kid. A key's alg, if present, restricts its
+// use to that algorithm.
+func NewKeySetFromJWKS(data []byte) (*KeySet, error) {
+	set, err := jwk.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing JWKS: %w", err)
+	}
+
+	ks := NewKeySet()
+
+	for i := 0; i < set.Len(); i++ {
+		k, _ := set.Key(i)
+
+		if err := ks.addJWK(k); err != nil {
+			return nil, fmt.Errorf("JWKS key at index %d: %w", i, err)
+		}
+	}
+
+	return ks, nil
+}
+
+func (o *KeySet) addJWK(k jwk.Key) error {
+	if k.KeyID() == "" {
+		return errors.New("missing kid")
+	}
+
+	var alg cose.Algorithm
+
+	if name := k.Algorithm().String(); name != "" {
+		var err error
+		if alg, err = algorithmFromName(name); err != nil {
+			return err
+		}
+	}
+
+	var pk interface{}
+	if err := k.Raw(&pk); err != nil {
+		return err
+	}
+
+	// the public key is also accepted in a private JWK
+	if s, ok := pk.(crypto.Signer); ok {
+		pk = s.Public()
+	}
+
+	return o.Add([]byte(k.KeyID()), pk, alg)
+}
+
+func algorithmFromName(name string) (cose.Algorithm, error) {
+	for _, alg := range keySetAlgs {
+		if alg.String() == name {
+			return alg, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unsupported algorithm %q", name)
+}
+
+// LoadJWKSKeySet reads a JWK Set from the supplied path using readFile, and
+// creates a KeySet from it
+func LoadJWKSKeySet(readFile func(string) ([]byte, error), path string) (*KeySet, error) {
+	data, err := readFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading JWKS from %s: %w", path, err)
+	}
+
+	ks, err := NewKeySetFromJWKS(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return ks, nil
+}
+
+// NewKeySetFromCOSEKeySet creates a KeySet from the public keys in the
+// supplied CBOR-encoded COSE_KeySet. Every key must have a kid. A key's alg,
+// if present, restricts its use to that algorithm.
+func NewKeySetFromCOSEKeySet(data []byte) (*KeySet, error) {
+	var keys []cose.Key
+
+	if err := dm.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("decoding COSE_KeySet: %w", err)
+	}
+
+	ks := NewKeySet()
+
+	for i, k := range keys {
+		if len(k.ID) == 0 {
+			return nil, fmt.Errorf("COSE_Key at index %d: missing kid", i)
+		}
+
+		pk, err := k.PublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("COSE_Key at index %d: %w", i, err)
+		}
+
+		if err := ks.Add(k.ID, pk, k.Algorithm); err != nil {
+			return nil, fmt.Errorf("COSE_Key at index %d: %w", i, err)
+		}
+	}
+
+	return ks, nil
+}
+
+// NewKeySetFromCoTS creates a KeySet from the trust anchors of the supplied
+// CoTS. The kid of certificate trust anchors is their subject key identifier.
+// The kid of TrustAnchorInfo trust anchors is their keyId. The kid of SPKI
+// trust anchors, and of certificates without a subject key identifier, is
+// computed from the public key as per RFC 5280, Section 4.2.1.2, method (1).
+func NewKeySetFromCoTS(ts cots.ConciseTaStore) (*KeySet, error) {
+	if ts.Keys == nil {
+		return nil, errors.New("no keys in CoTS")
+	}
+
+	ks := NewKeySet()
+
+	for i, ta := range ts.Keys.Tas {
+		kid, pk, err := parseCoTSTrustAnchor(ta)
+		if err != nil {
+			return nil, fmt.Errorf("trust anchor at index %d: %w", i, err)
+		}
+
+		if err := ks.Add(kid, pk, 0); err != nil {
+			return nil, fmt.Errorf("trust anchor at index %d: %w", i, err)
+		}
+	}
+
+	return ks, nil
+}
+
+// trustAnchorInfo is the TrustAnchorInfo structure of RFC 5914. Only the
+// fields needed to identify the key are decoded, the trailing ones are
+// ignored by the asn1 decoder.
+type trustAnchorInfo struct {
+	Version int `asn1:"optional,default:1"`
+	PubKey  asn1.RawValue
+	KeyID   []byte
+}
+
+// subjectPublicKeyInfo is the SubjectPublicKeyInfo structure of RFC 5280
+type subjectPublicKeyInfo struct {
+	Algorithm        asn1.RawValue
+	SubjectPublicKey asn1.BitString
+}
+
+func parseCoTSTrustAnchor(ta cots.TrustAnchor) ([]byte, crypto.PublicKey, error) {
+	switch ta.Format {
+	case cots.TaFormatCertificate:
+		cert, err := x509.ParseCertificate(ta.Data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing certificate: %w", err)
+		}
+
+		kid := cert.SubjectKeyId
+		if len(kid) == 0 {
+			if kid, err = spkiKeyID(cert.RawSubjectPublicKeyInfo); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		return kid, cert.PublicKey, nil
+	case cots.TaFormatSubjectPublicKeyInfo:
+		pk, err := x509.ParsePKIXPublicKey(ta.Data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing SPKI: %w", err)
+		}
+
+		kid, err := spkiKeyID(ta.Data)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return kid, pk, nil
+	case cots.TaFormatTrustAnchorInfo:
+		var tai trustAnchorInfo
+
+		rest, err := asn1.Unmarshal(ta.Data, &tai)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing TrustAnchorInfo: %w", err)
+		}
+		if len(rest) != 0 {
+			return nil, nil, errors.New("parsing TrustAnchorInfo: trailing data")
+		}
+
+		pk, err := x509.ParsePKIXPublicKey(tai.PubKey.FullBytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing TrustAnchorInfo public key: %w", err)
+		}
+
+		return tai.KeyID, pk, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported trust anchor format %d", ta.Format)
+	}
+}
+
+// spkiKeyID computes the key identifier of the supplied DER-encoded
+// SubjectPublicKeyInfo as the SHA-1 hash of its subjectPublicKey
+func spkiKeyID(der []byte) ([]byte, error) {
+	var spki subjectPublicKeyInfo
+
+	if _, err := asn1.Unmarshal(der, &spki); err != nil {
+		return nil, fmt.Errorf("parsing SPKI: %w", err)
+	}
+
+	h := sha1.Sum(spki.SubjectPublicKey.Bytes) // nolint:gosec
+
+	return h[:], nil
+}
+
+// VerifyWithKeySet verifies the signature of the target SignedCorim using the
+// key in the supplied KeySet identified by its kid header. The key must be
+// usable with the algorithm in the alg header. If several keys share the kid,
+// verification succeeds if any of them verifies the signature.
+func (o *SignedCorim) VerifyWithKeySet(ks *KeySet) error {
+	if o.message == nil {
+		return errNoSign1Message
+	}
+
+	if ks == nil {
+		return errors.New("nil key set")
+	}
+
+	if len(o.KeyID) == 0 {
+		return ErrNoKeyID
+	}
+
+	keys := ks.Lookup(o.KeyID)
+	if len(keys) == 0 {
+		return fmt.Errorf("%w: kid %s", ErrKeyNotFound, hex.EncodeToString(o.KeyID))
+	}
+
+	alg, err := o.message.Headers.Protected.Algorithm()
+	if err != nil {
+		return fmt.Errorf("unable to get verification algorithm: %w", err)
+	}
+
+	var errs []error
+
+	for _, k := range keys {
+		if err := k.checkAlg(alg); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		err := o.Verify(k.Key)
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// VerifyWithKeySet returns a SignatureVerifier that checks signatures against
+// the keys in the supplied KeySet, selected by kid.
+func VerifyWithKeySet(ks *KeySet) SignatureVerifier {
+	return func(_ string, sc *SignedCorim) error {
+		return sc.VerifyWithKeySet(ks)
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cbor "github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/cots"
+	cose "github.com/veraison/go-cose"
+)
+
+func testJWKS(keys ...[]byte) []byte {
+	jwks := []byte(`{"keys":[`)
+	for i, k := range keys {
+		if i > 0 {
+			jwks = append(jwks, ',')
+		}
+		jwks = append(jwks, k...)
+	}
+	return append(jwks, []byte(`]}`)...)
+}
+
+func signWithKeyID(t *testing.T, key []byte, kid []byte) *SignedCorim {
+	t.Helper()
+
+	signer, err := NewSignerFromJWK(key)
+	require.NoError(t, err)
+
+	in := SignedCorim{
+		UnsignedCorim: *unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR),
+		Meta:          *metaGood(t),
+		KeyID:         kid,
+	}
+
+	data, err := in.Sign(signer)
+	require.NoError(t, err)
+
+	var out SignedCorim
+	require.NoError(t, out.FromCOSE(data))
+
+	return &out
+}
+
+func TestSignedCorim_VerifyWithKeySet_JWKS(t *testing.T) {
+	ks, err := NewKeySetFromJWKS(testJWKS(testES256Key, testES512Key, testEdDSAKey, testPS256Key))
+	require.NoError(t, err)
+	assert.Equal(t, 4, ks.Len())
+
+	for _, tv := range []struct {
+		key []byte
+		kid string
+	}{
+		{testES256Key, "1"},
+		{testES512Key, "Xt7n2MSHsgErmf1Uq-UZV451DhzlSPVuH75Rj9adAZ0"},
+		{testEdDSAKey, "RBx2781Ag7Sd1vmuVbxpe0LzWT94pmB3GPtNx6m_gsQ"},
+		{testPS256Key, "jYGw-iPMi7AxzdJPMHYh_gb9YI-BQGAVAvf6hgZndzw"},
+	} {
+		sc := signWithKeyID(t, tv.key, []byte(tv.kid))
+		assert.NoError(t, sc.VerifyWithKeySet(ks), tv.kid)
+	}
+
+	// signed with a key that is not the one identified by the kid
+	sc := signWithKeyID(t, testES512Key, []byte("1"))
+	err = sc.VerifyWithKeySet(ks)
+	assert.ErrorIs(t, err, ErrKeyAlgMismatch)
+	assert.EqualError(t, err, "key algorithm mismatch: *ecdsa.PublicKey key cannot be used with ES512")
+
+	sc = signWithKeyID(t, testES256Key, []byte("unknown"))
+	err = sc.VerifyWithKeySet(ks)
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	assert.EqualError(t, err, "key not found: kid 756e6b6e6f776e")
+
+	sc = signWithKeyID(t, testES256Key, nil)
+	assert.ErrorIs(t, sc.VerifyWithKeySet(ks), ErrNoKeyID)
+
+	assert.EqualError(t, sc.VerifyWithKeySet(nil), "nil key set")
+	assert.ErrorIs(t, (&SignedCorim{}).VerifyWithKeySet(ks), errNoSign1Message)
+}
+
+func TestSignedCorim_VerifyWithKeySet_rotation(t *testing.T) {
+	kid := []byte("acme-signing")
+
+	oldPK, err := NewPublicKeyFromJWK(testES384Key)
+	require.NoError(t, err)
+	newPK, err := NewPublicKeyFromJWK(testES256Key)
+	require.NoError(t, err)
+	otherPK, err := NewPublicKeyFromJWK(testEdDSAKey)
+	require.NoError(t, err)
+
+	// during the rotation both keys share the same kid
+	ks := NewKeySet()
+	require.NoError(t, ks.Add(kid, oldPK, cose.AlgorithmES384))
+	require.NoError(t, ks.Add(kid, newPK, 0))
+
+	assert.NoError(t, signWithKeyID(t, testES384Key, kid).VerifyWithKeySet(ks))
+	assert.NoError(t, signWithKeyID(t, testES256Key, kid).VerifyWithKeySet(ks))
+
+	// an ES256 key restricted to ES384 is rejected
+	err = ks.Add(kid, newPK, cose.AlgorithmES384)
+	assert.ErrorIs(t, err, ErrKeyAlgMismatch)
+
+	// the right algorithm, but the wrong key
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	err = signWithKeyID(t, mustECPrivateKeyJWK(t, otherKey), kid).VerifyWithKeySet(ks)
+	assert.ErrorIs(t, err, cose.ErrVerification)
+
+	// no key with the kid can be used with the algorithm
+	ks = NewKeySet()
+	require.NoError(t, ks.Add(kid, otherPK, 0))
+
+	err = signWithKeyID(t, testES256Key, kid).VerifyWithKeySet(ks)
+	assert.ErrorIs(t, err, ErrKeyAlgMismatch)
+}
+
+func TestKeySet_Add_zero_value(t *testing.T) {
+	var ks KeySet
+
+	pk, err := NewPublicKeyFromJWK(testES256Key)
+	require.NoError(t, err)
+
+	require.NoError(t, ks.Add([]byte("1"), pk, cose.AlgorithmES256))
+	assert.Equal(t, 1, ks.Len())
+	assert.Len(t, ks.Lookup([]byte("1")), 1)
+}
+
+func TestKeySet_Add_NOK(t *testing.T) {
+	ks := NewKeySet()
+
+	pk, err := NewPublicKeyFromJWK(testES256Key)
+	require.NoError(t, err)
+
+	assert.EqualError(t, ks.Add(nil, pk, 0), "empty kid")
+	assert.EqualError(t, ks.Add([]byte("1"), "key", 0), "unsupported public key type string")
+	assert.EqualError(t, ks.Add([]byte("1"), pk, cose.AlgorithmPS256),
+		"key algorithm mismatch: *ecdsa.PublicKey key cannot be used with PS256")
+	assert.Equal(t, 0, ks.Len())
+}
+
+func TestNewKeySetFromJWKS_NOK(t *testing.T) {
+	_, err := NewKeySetFromJWKS([]byte(`{`))
+	assert.ErrorContains(t, err, "parsing JWKS")
+
+	_, err = NewKeySetFromJWKS(testJWKS(testES384Key))
+	assert.EqualError(t, err, "JWKS key at index 0: missing kid")
+
+	_, err = NewKeySetFromJWKS(testJWKS(
+		[]byte(`{"kty":"oct","k":"c2VjcmV0","kid":"sym"}`),
+	))
+	assert.EqualError(t, err, "JWKS key at index 0: unsupported public key type []uint8")
+
+	_, err = NewKeySetFromJWKS(testJWKS(
+		[]byte(`{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo","kid":"k","alg":"HS256"}`),
+	))
+	assert.EqualError(t, err, `JWKS key at index 0: unsupported algorithm "HS256"`)
+}
+
+func TestLoadJWKSKeySet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.jwks")
+	require.NoError(t, os.WriteFile(path, testJWKS(testES256Key), 0600))
+
+	ks, err := LoadJWKSKeySet(os.ReadFile, path)
+	require.NoError(t, err)
+	assert.Len(t, ks.Lookup([]byte("1")), 1)
+
+	_, err = LoadJWKSKeySet(os.ReadFile, path+".missing")
+	assert.ErrorContains(t, err, "loading JWKS from")
+}
+
+func TestNewKeySetFromCOSEKeySet(t *testing.T) {
+	var keys []*cose.Key
+
+	for _, tv := range []struct {
+		key []byte
+		kid string
+	}{
+		{testES256Key, "ec"},
+		{testEdDSAKey, "ed"},
+	} {
+		pk, err := NewPublicKeyFromJWK(tv.key)
+		require.NoError(t, err)
+
+		k, err := cose.NewKeyFromPublic(pk)
+		require.NoError(t, err)
+		k.ID = []byte(tv.kid)
+
+		keys = append(keys, k)
+	}
+
+	data, err := cbor.Marshal(keys)
+	require.NoError(t, err)
+
+	ks, err := NewKeySetFromCOSEKeySet(data)
+	require.NoError(t, err)
+	assert.Equal(t, 2, ks.Len())
+
+	assert.NoError(t, signWithKeyID(t, testES256Key, []byte("ec")).VerifyWithKeySet(ks))
+	assert.NoError(t, signWithKeyID(t, testEdDSAKey, []byte("ed")).VerifyWithKeySet(ks))
+
+	keys[1].ID = nil
+	data, err = cbor.Marshal(keys)
+	require.NoError(t, err)
+
+	_, err = NewKeySetFromCOSEKeySet(data)
+	assert.EqualError(t, err, "COSE_Key at index 1: missing kid")
+
+	_, err = NewKeySetFromCOSEKeySet([]byte{0xa0})
+	assert.ErrorContains(t, err, "decoding COSE_KeySet")
+}
+
+func TestNewKeySetFromCoTS(t *testing.T) {
+	pki := buildTestPKI(t)
+
+	spki, err := x509.MarshalPKIXPublicKey(&pki.leafKey.PublicKey)
+	require.NoError(t, err)
+
+	tai, err := asn1.Marshal(struct {
+		PubKey asn1.RawValue
+		KeyID  []byte
+		Title  string `asn1:"utf8"`
+	}{
+		PubKey: asn1.RawValue{FullBytes: pki.intermediate.RawSubjectPublicKeyInfo},
+		KeyID:  []byte("intermediate"),
+		Title:  "ACME intermediate",
+	})
+	require.NoError(t, err)
+
+	ts := cots.ConciseTaStore{
+		Keys: &cots.TasAndCas{
+			Tas: []cots.TrustAnchor{
+				{Format: cots.TaFormatCertificate, Data: pki.leafDER},
+				{Format: cots.TaFormatSubjectPublicKeyInfo, Data: spki},
+				{Format: cots.TaFormatTrustAnchorInfo, Data: tai},
+			},
+		},
+	}
+
+	ks, err := NewKeySetFromCoTS(ts)
+	require.NoError(t, err)
+	assert.Equal(t, 3, ks.Len())
+
+	leafKID, err := spkiKeyID(spki)
+	require.NoError(t, err)
+	if len(pki.leaf.SubjectKeyId) != 0 {
+		leafKID = pki.leaf.SubjectKeyId
+	}
+
+	assert.NotEmpty(t, ks.Lookup(leafKID))
+	assert.Len(t, ks.Lookup([]byte("intermediate")), 1)
+
+	signer, err := NewSignerFromJWK(mustECPrivateKeyJWK(t, pki.leafKey))
+	require.NoError(t, err)
+
+	in := SignedCorim{
+		UnsignedCorim: *unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR),
+		KeyID:         leafKID,
+	}
+
+	data, err := in.Sign(signer)
+	require.NoError(t, err)
+
+	var out SignedCorim
+	require.NoError(t, out.FromCOSE(data))
+	assert.NoError(t, out.VerifyWithKeySet(ks))
+
+	_, err = NewKeySetFromCoTS(cots.ConciseTaStore{})
+	assert.EqualError(t, err, "no keys in CoTS")
+
+	ts.Keys.Tas = append(ts.Keys.Tas, cots.TrustAnchor{Format: cots.TaFormatTrustAnchorInfo, Data: []byte{0x30}})
+	_, err = NewKeySetFromCoTS(ts)
+	assert.ErrorContains(t, err, "trust anchor at index 3: parsing TrustAnchorInfo")
+}
@@ -85,6 +85,11 @@ func (o Meta) Valid() error {
 	return nil
 }
 
+// isEmpty returns true if neither signer nor validity are set
+func (o Meta) isEmpty() bool {
+	return o.Signer.Name == "" && o.Signer.URI == nil && o.Validity == nil
+}
+
 // ToCBOR serializes the target Meta to CBOR
 func (o Meta) ToCBOR() ([]byte, error) {
 	return em.Marshal(&o)
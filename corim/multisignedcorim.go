@@ -0,0 +1,477 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	cose "github.com/veraison/go-cose"
+)
+
+var errNoSignMessage = errors.New("no Sign message found")
+
+// SignerInfo holds the per-signer protected headers of a MultiSignedCorim,
+// i.e., the corim-meta, and the optional key identifier and x5chain of one of
+// the signers.
+type SignerInfo struct {
+	Meta              Meta
+	KeyID             []byte
+	SigningCert       *x509.Certificate
+	IntermediateCerts []*x509.Certificate
+	signature         *cose.Signature
+}
+
+// AddSigningCert adds a DER-encoded X.509 certificate to be included in the
+// protected header of the signer's COSE_Signature as the leaf certificate in
+// X5Chain.
+func (o *SignerInfo) AddSigningCert(der []byte) error {
+	cert, err := parseSigningCert(der)
+	if err != nil {
+		return err
+	}
+
+	o.SigningCert = cert
+	return nil
+}
+
+// AddIntermediateCerts adds DER-encoded X.509 certificates to be included in
+// the protected header of the signer's COSE_Signature as part of the X5Chain.
+// The certificates must be concatenated with no intermediate padding, as per
+// X.509 convention.
+func (o *SignerInfo) AddIntermediateCerts(der []byte) error {
+	certs, err := parseIntermediateCerts(der)
+	if err != nil {
+		return err
+	}
+
+	o.IntermediateCerts = certs
+	return nil
+}
+
+func (o *SignerInfo) processHdrs() error {
+	hdr := o.signature.Headers.Protected
+
+	if hdr == nil {
+		return errors.New("missing mandatory protected header")
+	}
+
+	if _, err := hdr.Algorithm(); err != nil {
+		return fmt.Errorf("alg: %w", err)
+	}
+
+	if v, ok := hdr[cose.HeaderLabelKeyID]; ok {
+		switch t := v.(type) {
+		case []byte:
+			o.KeyID = t
+		default:
+			return fmt.Errorf("kid: expected a []byte but got %v (%T)", t, t)
+		}
+	}
+
+	v, ok := hdr[HeaderLabelCorimMeta]
+	if !ok {
+		return errors.New("missing mandatory corim.meta")
+	}
+
+	meta, err := decodeMetaHeader(v)
+	if err != nil {
+		return err
+	}
+
+	o.Meta = *meta
+
+	if v, ok := hdr[cose.HeaderLabelX5Chain]; ok {
+		if o.SigningCert, o.IntermediateCerts, err = DecodeX5ChainHeader(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MultiSignedCorim encodes a CoRIM wrapped in a COSE_Sign message, i.e., a
+// CoRIM signed by several signers, each with its own corim-meta, key
+// identifier and x5chain. This is useful when the same CoRIM is endorsed by
+// several parties, e.g., the silicon vendor and the OEM.
+//
+// Note that the CoRIM spec only defines COSE_Sign1-wrapped signed CoRIMs (see
+// SignedCorim): this is an extension for deployments where all parties agree
+// on it.
+type MultiSignedCorim struct {
+	UnsignedCorim UnsignedCorim
+	Signers       []SignerInfo
+	message       *cose.SignMessage
+}
+
+// NewMultiSignedCorim instantiates an empty MultiSignedCorim
+func NewMultiSignedCorim() *MultiSignedCorim {
+	return &MultiSignedCorim{}
+}
+
+// AddSigner appends the supplied signer information to the target
+// MultiSignedCorim. The signature is produced by Sign.
+func (o *MultiSignedCorim) AddSigner(si SignerInfo) *MultiSignedCorim {
+	if o != nil {
+		o.Signers = append(o.Signers, si)
+	}
+	return o
+}
+
+// Sign returns the serialized COSE_Sign-wrapped CoRIM, signed by the supplied
+// cose Signers. There must be exactly one signer for each of the target's
+// Signers, in the same order. The target MultiSignedCorim must have its
+// UnsignedCorim field correctly populated.
+func (o *MultiSignedCorim) Sign(signers ...cose.Signer) ([]byte, error) {
+	if len(o.Signers) == 0 {
+		return nil, errors.New("no signer info")
+	}
+
+	if len(signers) != len(o.Signers) {
+		return nil, fmt.Errorf("%d signers for %d signer infos", len(signers), len(o.Signers))
+	}
+
+	if err := o.UnsignedCorim.Valid(); err != nil {
+		return nil, fmt.Errorf("failed validation of unsigned CoRIM: %w", err)
+	}
+
+	msg := cose.NewSignMessage()
+
+	var err error
+	msg.Payload, err = o.UnsignedCorim.ToCBOR()
+	if err != nil {
+		return nil, fmt.Errorf("failed CBOR encoding of unsigned CoRIM: %w", err)
+	}
+
+	msg.Headers.Protected[cose.HeaderLabelContentType] = ContentType
+
+	for i, signer := range signers {
+		sig, err := o.Signers[i].newSignature(signer)
+		if err != nil {
+			return nil, fmt.Errorf("signer at index %d: %w", i, err)
+		}
+
+		msg.Signatures = append(msg.Signatures, sig)
+	}
+
+	if err := msg.Sign(rand.Reader, NoExternalData, signers...); err != nil {
+		return nil, fmt.Errorf("COSE Sign signature failed: %w", err)
+	}
+
+	wrap, err := msg.MarshalCBOR()
+	if err != nil {
+		return nil, fmt.Errorf("multi-signed-corim marshaling failed: %w", err)
+	}
+
+	o.message = msg
+	for i := range o.Signers {
+		o.Signers[i].signature = msg.Signatures[i]
+	}
+
+	return wrap, nil
+}
+
+func (o SignerInfo) newSignature(signer cose.Signer) (*cose.Signature, error) {
+	if signer == nil {
+		return nil, errors.New("nil signer")
+	}
+
+	alg := signer.Algorithm()
+	if strings.Contains(alg.String(), "unknown algorithm value") {
+		return nil, errors.New("signer has no algorithm")
+	}
+
+	metaCBOR, err := o.Meta.ToCBOR()
+	if err != nil {
+		return nil, fmt.Errorf("failed CBOR encoding of CoRIM Meta: %w", err)
+	}
+
+	sig := cose.NewSignature()
+	sig.Headers.Protected.SetAlgorithm(alg)
+	sig.Headers.Protected[HeaderLabelCorimMeta] = metaCBOR
+
+	if o.KeyID != nil {
+		sig.Headers.Protected[cose.HeaderLabelKeyID] = o.KeyID
+	}
+
+	if o.SigningCert != nil {
+		sig.Headers.Protected[cose.HeaderLabelX5Chain] = EncodeX5ChainHeader(o.SigningCert, o.IntermediateCerts)
+	} else if o.IntermediateCerts != nil {
+		return nil, errors.New("intermediate certificates supplied but no signing certificate")
+	}
+
+	return sig, nil
+}
+
+// FromCOSE decodes and effects syntactic validation on the supplied
+// COSE_Sign-wrapped CoRIM, including the embedded unsigned-corim and the
+// protected headers of each signature. On success, the unsigned-corim-map is
+// made available via the UnsignedCorim field while the per-signer headers are
+// decoded into the Signers field.
+func (o *MultiSignedCorim) FromCOSE(buf []byte) error {
+	msg := cose.NewSignMessage()
+
+	if err := msg.UnmarshalCBOR(buf); err != nil {
+		return fmt.Errorf("failed CBOR decoding for COSE-Sign signed CoRIM: %w", err)
+	}
+
+	v, ok := msg.Headers.Protected[cose.HeaderLabelContentType]
+	if !ok {
+		return errors.New("processing COSE headers: missing mandatory content type")
+	}
+
+	if v != ContentType {
+		return fmt.Errorf("processing COSE headers: expecting content type %q, got %q instead", ContentType, v)
+	}
+
+	signers := make([]SignerInfo, len(msg.Signatures))
+
+	for i, sig := range msg.Signatures {
+		signers[i].signature = sig
+		if err := signers[i].processHdrs(); err != nil {
+			return fmt.Errorf("processing COSE headers of signature at index %d: %w", i, err)
+		}
+	}
+
+	if err := o.UnsignedCorim.FromCBOR(msg.Payload); err != nil {
+		return fmt.Errorf("failed CBOR decoding of unsigned CoRIM: %w", err)
+	}
+
+	if err := o.UnsignedCorim.Valid(); err != nil {
+		return fmt.Errorf("failed validation of unsigned CoRIM: %w", err)
+	}
+
+	o.Signers = signers
+	o.message = msg
+
+	return nil
+}
+
+// VerifySignature verifies the signature at the specified index using the
+// supplied public key
+func (o *MultiSignedCorim) VerifySignature(index int, pk crypto.PublicKey) error {
+	if o.message == nil {
+		return errNoSignMessage
+	}
+
+	if index < 0 || index >= len(o.Signers) {
+		return fmt.Errorf("no signature at index %d", index)
+	}
+
+	sig := o.Signers[index].signature
+
+	alg, err := sig.Headers.Protected.Algorithm()
+	if err != nil {
+		return fmt.Errorf("unable to get verification algorithm: %w", err)
+	}
+
+	verifier, err := cose.NewVerifier(alg, pk)
+	if err != nil {
+		return fmt.Errorf("unable to instantiate verifier: %w", err)
+	}
+
+	protected, err := o.message.Headers.MarshalProtected()
+	if err != nil {
+		return err
+	}
+
+	return sig.Verify(verifier, protected, o.message.Payload, NoExternalData)
+}
+
+// VerifySignatureWithX5Chain validates the x5chain of the signature at the
+// specified index against the supplied trust anchors, then verifies the
+// signature using the public key of the signing certificate. See
+// SignedCorim.VerifyWithX5Chain for the certificate policy.
+func (o *MultiSignedCorim) VerifySignatureWithX5Chain(index int, anchors TrustAnchors) error {
+	if o.message == nil {
+		return errNoSignMessage
+	}
+
+	if index < 0 || index >= len(o.Signers) {
+		return fmt.Errorf("no signature at index %d", index)
+	}
+
+	si := o.Signers[index]
+
+	if si.SigningCert == nil {
+		return errors.New("x5chain: header not set in signature")
+	}
+
+	pk, err := VerifyX5Chain(si.SigningCert, si.IntermediateCerts, anchors, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := o.VerifySignature(index, pk); err != nil {
+		return fmt.Errorf("x5chain: COSE signature verification failed: %w", err)
+	}
+
+	return nil
+}
+
+// SignaturePolicy states how many of the expected signers must have validly
+// signed a MultiSignedCorim.
+type SignaturePolicy struct {
+	// Threshold is the number of expected signers that must be matched by
+	// a valid signature. Zero means all of them.
+	Threshold int
+}
+
+// AllOf returns a SignaturePolicy requiring all the expected signers
+func AllOf() SignaturePolicy {
+	return SignaturePolicy{}
+}
+
+// AnyOf returns a SignaturePolicy requiring at least one of the expected
+// signers
+func AnyOf() SignaturePolicy {
+	return SignaturePolicy{Threshold: 1}
+}
+
+// KOfN returns a SignaturePolicy requiring at least k of the expected signers
+func KOfN(k int) SignaturePolicy {
+	return SignaturePolicy{Threshold: k}
+}
+
+func (o SignaturePolicy) required(n int) (int, error) {
+	switch {
+	case o.Threshold < 0:
+		return 0, fmt.Errorf("invalid signature policy threshold %d", o.Threshold)
+	case o.Threshold == 0:
+		return n, nil
+	case o.Threshold > n:
+		return 0, fmt.Errorf("signature policy requires %d signers, only %d expected", o.Threshold, n)
+	default:
+		return o.Threshold, nil
+	}
+}
+
+// VerifyWithKeys verifies the target MultiSignedCorim against the supplied
+// public keys, one for each expected signer, according to the policy. Each
+// signature can vouch for at most one expected signer.
+func (o *MultiSignedCorim) VerifyWithKeys(policy SignaturePolicy, pks ...crypto.PublicKey) error {
+	checks := make([]func(int) error, len(pks))
+	for i, pk := range pks {
+		checks[i] = func(index int) error { return o.VerifySignature(index, pk) }
+	}
+
+	return o.verifyPolicy(policy, checks)
+}
+
+// VerifyWithX5Chains verifies the target MultiSignedCorim against the supplied
+// trust anchors, one set for each expected signer, according to the policy.
+// An expected signer is matched by a signature whose x5chain validates
+// against its trust anchors, and whose signature verifies with the signing
+// certificate. Each signature can vouch for at most one expected signer, so
+// that, e.g., AllOf cannot be satisfied by a single signature even if the
+// trust anchors of the expected signers overlap.
+func (o *MultiSignedCorim) VerifyWithX5Chains(policy SignaturePolicy, anchors ...TrustAnchors) error {
+	checks := make([]func(int) error, len(anchors))
+	for i, ta := range anchors {
+		checks[i] = func(index int) error { return o.VerifySignatureWithX5Chain(index, ta) }
+	}
+
+	return o.verifyPolicy(policy, checks)
+}
+
+// verifyPolicy checks that enough of the expected signers, each represented by
+// a function checking whether the signature at the supplied index is theirs,
+// are matched by distinct signatures.
+func (o *MultiSignedCorim) verifyPolicy(policy SignaturePolicy, checks []func(int) error) error {
+	if o.message == nil {
+		return errNoSignMessage
+	}
+
+	if len(checks) == 0 {
+		return errors.New("no expected signers")
+	}
+
+	required, err := policy.required(len(checks))
+	if err != nil {
+		return err
+	}
+
+	// valid[i][j] records whether signature j is valid for expected signer i
+	valid := make([][]bool, len(checks))
+	errs := make([][]error, len(checks))
+
+	for i, check := range checks {
+		valid[i] = make([]bool, len(o.Signers))
+		for j := range o.Signers {
+			if err := check(j); err != nil {
+				errs[i] = append(errs[i], fmt.Errorf("signature at index %d: %w", j, err))
+			} else {
+				valid[i][j] = true
+			}
+		}
+	}
+
+	matched, unmatched := matchSigners(valid)
+	if matched >= required {
+		return nil
+	}
+
+	var reasons []error
+	for _, i := range unmatched {
+		if len(errs[i]) == 0 {
+			// all the signatures valid for this signer vouch for others
+			reasons = append(reasons, fmt.Errorf("expected signer %d: no distinct valid signature", i))
+		} else {
+			reasons = append(reasons, fmt.Errorf("expected signer %d: %w", i, errors.Join(errs[i]...)))
+		}
+	}
+
+	return fmt.Errorf("signature policy not satisfied: %d of %d required signers matched: %w",
+		matched, required, errors.Join(reasons...))
+}
+
+// matchSigners computes a maximum matching between expected signers and the
+// signatures valid for them (see valid in verifyPolicy). It returns the size
+// of the matching, and the expected signers left unmatched.
+func matchSigners(valid [][]bool) (int, []int) {
+	if len(valid) == 0 {
+		return 0, nil
+	}
+
+	// owner[j] is the expected signer signature j vouches for, or -1
+	owner := make([]int, len(valid[0]))
+	for j := range owner {
+		owner[j] = -1
+	}
+
+	var assign func(i int, seen []bool) bool
+	assign = func(i int, seen []bool) bool {
+		for j, ok := range valid[i] {
+			if !ok || seen[j] {
+				continue
+			}
+			seen[j] = true
+
+			if owner[j] == -1 || assign(owner[j], seen) {
+				owner[j] = i
+				return true
+			}
+		}
+		return false
+	}
+
+	var (
+		matched   int
+		unmatched []int
+	)
+
+	for i := range valid {
+		if assign(i, make([]bool, len(owner))) {
+			matched++
+		} else {
+			unmatched = append(unmatched, i)
+		}
+	}
+
+	return matched, unmatched
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cose "github.com/veraison/go-cose"
+)
+
+func pkiSignerInfo(t *testing.T, pki testPKI) (SignerInfo, cose.Signer) {
+	t.Helper()
+
+	signer, err := NewSignerFromJWK(mustECPrivateKeyJWK(t, pki.leafKey))
+	require.NoError(t, err)
+
+	si := SignerInfo{Meta: *metaGood(t)}
+	require.NoError(t, si.AddSigningCert(pki.leafDER))
+	require.NoError(t, si.AddIntermediateCerts(pki.intermediateDER))
+
+	return si, signer
+}
+
+func pkiTrustAnchors(pki testPKI) TrustAnchors {
+	pool := x509.NewCertPool()
+	pool.AddCert(pki.root)
+
+	return TrustAnchors{Pool: pool}
+}
+
+func multiSign(t *testing.T, infos []SignerInfo, signers []cose.Signer) *MultiSignedCorim {
+	t.Helper()
+
+	in := NewMultiSignedCorim()
+	in.UnsignedCorim = *unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR)
+	for _, si := range infos {
+		in.AddSigner(si)
+	}
+
+	data, err := in.Sign(signers...)
+	require.NoError(t, err)
+
+	var out MultiSignedCorim
+	require.NoError(t, out.FromCOSE(data))
+
+	return &out
+}
+
+func TestMultiSignedCorim_SignVerify_ok(t *testing.T) {
+	vendor, oem := buildTestPKI(t), buildTestPKI(t)
+
+	vendorInfo, vendorSigner := pkiSignerInfo(t, vendor)
+	oemInfo, oemSigner := pkiSignerInfo(t, oem)
+	oemInfo.KeyID = []byte("oem")
+
+	out := multiSign(t, []SignerInfo{vendorInfo, oemInfo}, []cose.Signer{vendorSigner, oemSigner})
+
+	assert.Equal(t, "test corim id", out.UnsignedCorim.GetID())
+	require.Len(t, out.Signers, 2)
+	assert.Equal(t, vendor.leaf.Raw, out.Signers[0].SigningCert.Raw)
+	assert.Nil(t, out.Signers[0].KeyID)
+	assert.Equal(t, oem.leaf.Raw, out.Signers[1].SigningCert.Raw)
+	assert.Len(t, out.Signers[1].IntermediateCerts, 1)
+	assert.Equal(t, []byte("oem"), out.Signers[1].KeyID)
+	assert.Equal(t, metaGood(t).Signer.Name, out.Signers[1].Meta.Signer.Name)
+
+	assert.NoError(t, out.VerifySignature(0, &vendor.leafKey.PublicKey))
+	assert.NoError(t, out.VerifySignature(1, &oem.leafKey.PublicKey))
+	assert.Error(t, out.VerifySignature(0, &oem.leafKey.PublicKey))
+	assert.EqualError(t, out.VerifySignature(2, &oem.leafKey.PublicKey), "no signature at index 2")
+
+	assert.NoError(t, out.VerifySignatureWithX5Chain(1, pkiTrustAnchors(oem)))
+	assert.ErrorContains(t, out.VerifySignatureWithX5Chain(1, pkiTrustAnchors(vendor)),
+		"x5chain verification failed")
+
+	// the expected signers need not be in the same order as the signatures
+	anchors := []TrustAnchors{pkiTrustAnchors(oem), pkiTrustAnchors(vendor)}
+	assert.NoError(t, out.VerifyWithX5Chains(AllOf(), anchors...))
+	assert.NoError(t, out.VerifyWithX5Chains(AnyOf(), anchors...))
+	assert.NoError(t, out.VerifyWithX5Chains(KOfN(2), anchors...))
+
+	assert.NoError(t, out.VerifyWithKeys(AllOf(), &oem.leafKey.PublicKey, &vendor.leafKey.PublicKey))
+}
+
+func TestMultiSignedCorim_VerifyWithX5Chains_policies(t *testing.T) {
+	vendor, oem, other := buildTestPKI(t), buildTestPKI(t), buildTestPKI(t)
+
+	vendorInfo, vendorSigner := pkiSignerInfo(t, vendor)
+
+	// only the vendor signed
+	out := multiSign(t, []SignerInfo{vendorInfo}, []cose.Signer{vendorSigner})
+
+	anchors := []TrustAnchors{pkiTrustAnchors(vendor), pkiTrustAnchors(oem), pkiTrustAnchors(other)}
+
+	assert.NoError(t, out.VerifyWithX5Chains(AnyOf(), anchors...))
+	assert.NoError(t, out.VerifyWithX5Chains(KOfN(1), anchors...))
+
+	err := out.VerifyWithX5Chains(KOfN(2), anchors...)
+	assert.ErrorContains(t, err, "signature policy not satisfied: 1 of 2 required signers matched")
+	assert.ErrorContains(t, err, "expected signer 1: signature at index 0: x5chain verification failed")
+	assert.ErrorContains(t, err, "expected signer 2: signature at index 0: x5chain verification failed")
+
+	err = out.VerifyWithX5Chains(AllOf(), anchors...)
+	assert.ErrorContains(t, err, "1 of 3 required signers matched")
+
+	err = out.VerifyWithX5Chains(KOfN(4), anchors...)
+	assert.EqualError(t, err, "signature policy requires 4 signers, only 3 expected")
+
+	err = out.VerifyWithX5Chains(KOfN(-1), anchors...)
+	assert.EqualError(t, err, "invalid signature policy threshold -1")
+
+	err = out.VerifyWithX5Chains(AllOf())
+	assert.EqualError(t, err, "no expected signers")
+}
+
+func TestMultiSignedCorim_VerifyWithX5Chains_distinct_signatures(t *testing.T) {
+	vendor := buildTestPKI(t)
+	vendorInfo, vendorSigner := pkiSignerInfo(t, vendor)
+
+	out := multiSign(t, []SignerInfo{vendorInfo}, []cose.Signer{vendorSigner})
+
+	// two expected signers sharing the same trust anchors cannot both be
+	// satisfied by a single signature
+	anchors := []TrustAnchors{pkiTrustAnchors(vendor), pkiTrustAnchors(vendor)}
+
+	err := out.VerifyWithX5Chains(AllOf(), anchors...)
+	assert.ErrorContains(t, err, "expected signer 1: no distinct valid signature")
+	assert.NoError(t, out.VerifyWithX5Chains(AnyOf(), anchors...))
+}
+
+func TestMatchSigners(t *testing.T) {
+	// a greedy assignment of signature 0 to signer 0 would leave signer 1
+	// unmatched
+	valid := [][]bool{
+		{true, true},
+		{true, false},
+	}
+
+	matched, unmatched := matchSigners(valid)
+	assert.Equal(t, 2, matched)
+	assert.Empty(t, unmatched)
+
+	matched, unmatched = matchSigners([][]bool{{true}, {true}, {false}})
+	assert.Equal(t, 1, matched)
+	assert.Equal(t, []int{1, 2}, unmatched)
+}
+
+func TestMultiSignedCorim_Sign_NOK(t *testing.T) {
+	pki := buildTestPKI(t)
+	si, signer := pkiSignerInfo(t, pki)
+
+	msc := NewMultiSignedCorim()
+	msc.UnsignedCorim = *unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR)
+
+	_, err := msc.Sign(signer)
+	assert.EqualError(t, err, "no signer info")
+
+	msc.AddSigner(si)
+
+	_, err = msc.Sign()
+	assert.EqualError(t, err, "0 signers for 1 signer infos")
+
+	_, err = msc.Sign(nil)
+	assert.EqualError(t, err, "signer at index 0: nil signer")
+
+	msc.Signers[0].SigningCert = nil
+	_, err = msc.Sign(signer)
+	assert.EqualError(t, err, "signer at index 0: intermediate certificates supplied but no signing certificate")
+
+	msc.UnsignedCorim = UnsignedCorim{}
+	_, err = msc.Sign(signer)
+	assert.ErrorContains(t, err, "failed validation of unsigned CoRIM")
+}
+
+func TestMultiSignedCorim_FromCOSE_NOK(t *testing.T) {
+	var msc MultiSignedCorim
+
+	err := msc.FromCOSE([]byte{0xd2, 0x84})
+	assert.ErrorContains(t, err, "failed CBOR decoding for COSE-Sign signed CoRIM")
+
+	assert.ErrorIs(t, msc.VerifySignature(0, nil), errNoSignMessage)
+	assert.ErrorIs(t, msc.VerifyWithKeys(AnyOf()), errNoSignMessage)
+
+	// a signature without corim-meta
+	signer, err := NewSignerFromJWK(testES256Key)
+	require.NoError(t, err)
+
+	msg := cose.NewSignMessage()
+	msg.Payload = testGoodUnsignedCorimCBOR
+	msg.Headers.Protected[cose.HeaderLabelContentType] = ContentType
+	sig := cose.NewSignature()
+	sig.Headers.Protected.SetAlgorithm(signer.Algorithm())
+	msg.Signatures = append(msg.Signatures, sig)
+	require.NoError(t, msg.Sign(rand.Reader, NoExternalData, signer))
+
+	data, err := msg.MarshalCBOR()
+	require.NoError(t, err)
+
+	err = msc.FromCOSE(data)
+	assert.EqualError(t, err,
+		"processing COSE headers of signature at index 0: missing mandatory corim.meta")
+
+	// wrong content type
+	msg.Headers.Protected[cose.HeaderLabelContentType] = "application/cbor"
+	data, err = msg.MarshalCBOR()
+	require.NoError(t, err)
+
+	err = msc.FromCOSE(data)
+	assert.EqualError(t, err,
+		`processing COSE headers: expecting content type "application/rim+cbor", got "application/cbor" instead`)
+}
+
+func TestUnmarshalMultiSignedCorimFromCBOR(t *testing.T) {
+	pki := buildTestPKI(t)
+	si, signer := pkiSignerInfo(t, pki)
+
+	in := NewMultiSignedCorim().AddSigner(si)
+	in.UnsignedCorim = *unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR)
+
+	data, err := in.Sign(signer)
+	require.NoError(t, err)
+
+	out, err := UnmarshalMultiSignedCorimFromCBOR(data)
+	require.NoError(t, err)
+	assert.NoError(t, out.VerifyWithX5Chains(AllOf(), pkiTrustAnchors(pki)))
+
+	_, err = UnmarshalMultiSignedCorimFromCBOR(testGoodUnsignedCorimCBOR)
+	assert.ErrorContains(t, err, "failed CBOR decoding for COSE-Sign signed CoRIM")
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// HeaderLabelOCSPResponses is the (private use) label of the unprotected
+// header parameter carrying OCSP responses stapled to a signed-corim. Its
+// value is an array of DER-encoded OCSP responses.
+var HeaderLabelOCSPResponses = int64(-70001)
+
+// OcspPolicy selects how missing OCSP responses are handled when OCSP
+// responses are supplied (in TrustAnchors or stapled to the signed-corim).
+type OcspPolicy int
+
+const (
+	// OcspPolicyStrict requires a valid OCSP response with "good" status for
+	// the signing certificate. Intermediate certificates are checked if a
+	// response is supplied for them. A response with a zero nextUpdate is
+	// rejected. This is the default (zero value).
+	OcspPolicyStrict OcspPolicy = iota
+	// OcspPolicyPermissive only checks the certificates for which an OCSP
+	// response is supplied, and treats "unknown" status as not checked.
+	OcspPolicyPermissive
+)
+
+// checkChainOCSP checks the revocation status of the certificates in the
+// verified chain against the supplied OCSP responses. It returns the
+// certificates whose "good" status has been confirmed, which need no CRL.
+// Revocations are only fatal if effective at signingTime (see
+// [isRevocationEffective]).
+func checkChainOCSP(
+	chain []*x509.Certificate,
+	responses [][]byte,
+	policy OcspPolicy,
+	now time.Time,
+	signingTime time.Time,
+) (map[*x509.Certificate]bool, error) {
+	good := make(map[*x509.Certificate]bool)
+
+	if len(responses) == 0 {
+		return good, nil
+	}
+
+	for i, cert := range chain {
+		if i+1 >= len(chain) {
+			break
+		}
+
+		resp, err := findOCSPResponse(cert, chain[i+1], responses, now)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp == nil {
+			if i == 0 && policy == OcspPolicyStrict {
+				return nil, fmt.Errorf("x5chain: no valid OCSP response for certificate %q", cert.Subject)
+			}
+			continue
+		}
+
+		if err := checkOCSPValidity(resp, now, policy); err != nil {
+			return nil, fmt.Errorf("x5chain: OCSP response for certificate %q %w", cert.Subject, err)
+		}
+
+		switch resp.Status {
+		case ocsp.Good:
+			good[cert] = true
+		case ocsp.Revoked:
+			if isRevocationEffective(resp.RevokedAt, resp.RevocationReason, signingTime) {
+				return nil, fmt.Errorf("x5chain: certificate %q is revoked (OCSP)", cert.Subject)
+			}
+			// revoked after the signature was made
+			good[cert] = true
+		default:
+			if policy == OcspPolicyStrict {
+				return nil, fmt.Errorf("x5chain: certificate %q has unknown OCSP status", cert.Subject)
+			}
+		}
+	}
+
+	return good, nil
+}
+
+// findOCSPResponse returns the first response about cert that is correctly
+// signed by its issuer, or by a responder delegated by the issuer. Responses
+// about other certificates, or not signed on behalf of issuer, are ignored.
+func findOCSPResponse(
+	cert *x509.Certificate,
+	issuer *x509.Certificate,
+	responses [][]byte,
+	now time.Time,
+) (*ocsp.Response, error) {
+	for _, der := range responses {
+		// match the serial number first, ignoring the signature
+		if _, err := ocsp.ParseResponseForCert(der, cert, nil); err != nil {
+			continue
+		}
+
+		resp, err := ocsp.ParseResponseForCert(der, cert, issuer)
+		if err != nil {
+			// same serial number, but from another issuer
+			continue
+		}
+
+		if resp.Certificate != nil {
+			if err := checkOCSPResponder(resp.Certificate, now); err != nil {
+				return nil, err
+			}
+		}
+
+		return resp, nil
+	}
+
+	return nil, nil
+}
+
+// checkOCSPResponder checks the certificate of a delegated OCSP responder,
+// which the OCSP library has already checked is signed by the issuer
+func checkOCSPResponder(responder *x509.Certificate, now time.Time) error {
+	if !slices.Contains(responder.ExtKeyUsage, x509.ExtKeyUsageOCSPSigning) {
+		return fmt.Errorf("x5chain: OCSP responder %q lacks OCSPSigning extended key usage", responder.Subject)
+	}
+
+	if now.Before(responder.NotBefore) || now.After(responder.NotAfter) {
+		return fmt.Errorf("x5chain: OCSP responder certificate %q is not valid", responder.Subject)
+	}
+
+	return nil
+}
+
+func checkOCSPValidity(resp *ocsp.Response, now time.Time, policy OcspPolicy) error {
+	if now.Before(resp.ThisUpdate) {
+		return errors.New("is not yet valid")
+	}
+
+	if policy == OcspPolicyStrict && resp.NextUpdate.IsZero() {
+		return errors.New("has no nextUpdate")
+	}
+
+	if !resp.NextUpdate.IsZero() && now.After(resp.NextUpdate) {
+		return errors.New("has expired")
+	}
+
+	return nil
+}
+
+// LoadOCSPResponses loads pre-fetched OCSP responses from files, to be used
+// as TrustAnchors.OCSPResponses. Files may be DER-encoded, or PEM-encoded with
+// one or more "OCSP RESPONSE" blocks. Each response must be about a single
+// certificate.
+func LoadOCSPResponses(readFile func(string) ([]byte, error), paths []string) ([][]byte, error) {
+	var responses [][]byte
+
+	for _, path := range paths {
+		data, err := readFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("loading OCSP response from %s: %w", path, err)
+		}
+
+		ders, err := ocspResponsesFromDEROrPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing OCSP response from %s: %w", path, err)
+		}
+
+		responses = append(responses, ders...)
+	}
+
+	return responses, nil
+}
+
+func ocspResponsesFromDEROrPEM(data []byte) ([][]byte, error) {
+	var ders [][]byte
+
+	for rest := data; ; {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "OCSP RESPONSE" {
+			return nil, fmt.Errorf("invalid PEM block type %q", block.Type)
+		}
+
+		ders = append(ders, block.Bytes)
+	}
+
+	if len(ders) == 0 {
+		ders = [][]byte{data}
+	}
+
+	for _, der := range ders {
+		if _, err := ocsp.ParseResponse(der, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ders, nil
+}
+
+// decodeOCSPHeader decodes the stapled OCSP responses header
+func decodeOCSPHeader(v interface{}) ([][]byte, error) {
+	switch t := v.(type) {
+	case []byte:
+		return [][]byte{t}, nil
+	case [][]byte:
+		return t, nil
+	case []interface{}:
+		ret := make([][]byte, len(t))
+		for i, elem := range t {
+			der, ok := elem.([]byte)
+			if !ok {
+				return nil, fmt.Errorf("OCSP response at index %d: got %T, want []byte", i, elem)
+			}
+			ret[i] = der
+		}
+		return ret, nil
+	default:
+		return nil, fmt.Errorf("got %T, want []interface{}, [][]byte, or []byte", t)
+	}
+}
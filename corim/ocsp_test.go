@@ -0,0 +1,372 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+func makeOCSPResponse(
+	t *testing.T,
+	cert, issuer, responder *x509.Certificate,
+	responderKey crypto.Signer,
+	status int,
+	nextUpdate time.Time,
+) []byte {
+	t.Helper()
+
+	tmpl := ocsp.Response{
+		Status:       status,
+		SerialNumber: cert.SerialNumber,
+		ThisUpdate:   time.Now().Add(-time.Minute),
+		NextUpdate:   nextUpdate,
+	}
+
+	if status == ocsp.Revoked {
+		tmpl.RevokedAt = time.Now().Add(-time.Minute)
+	}
+
+	if responder != issuer {
+		tmpl.Certificate = responder
+	}
+
+	der, err := ocsp.CreateResponse(issuer, responder, tmpl, responderKey)
+	require.NoError(t, err)
+
+	return der
+}
+
+func makeLeafOCSPResponse(t *testing.T, pki testPKI, status int) []byte {
+	t.Helper()
+
+	return makeOCSPResponse(
+		t, pki.leaf, pki.intermediate, pki.intermediate, pki.intermediateKey,
+		status, time.Now().Add(time.Hour),
+	)
+}
+
+func makeOCSPResponder(t *testing.T, pki testPKI, eku []x509.ExtKeyUsage) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(4),
+		Subject:      pkix.Name{CommonName: "OCSP Responder"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  eku,
+	}, pki.intermediate, &key.PublicKey, pki.intermediateKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+func pkiSignedCorim(t *testing.T, pki testPKI) SignedCorim {
+	t.Helper()
+
+	_, _, out := signWithChain(t, mustECPrivateKeyJWK(t, pki.leafKey), pki.leafDER, pki.intermediateDER)
+
+	return out
+}
+
+func TestSignedCorim_VerifyWithX5Chain_OCSP_good(t *testing.T) {
+	pki := buildTestPKI(t)
+	sc := pkiSignedCorim(t, pki)
+
+	anchors := pkiTrustAnchors(pki)
+	anchors.OCSPResponses = [][]byte{makeLeafOCSPResponse(t, pki, ocsp.Good)}
+
+	assert.NoError(t, sc.VerifyWithX5Chain(anchors))
+}
+
+func TestSignedCorim_VerifyWithX5Chain_OCSP_revoked(t *testing.T) {
+	pki := buildTestPKI(t)
+	sc := pkiSignedCorim(t, pki)
+
+	anchors := pkiTrustAnchors(pki)
+	anchors.OCSPResponses = [][]byte{makeLeafOCSPResponse(t, pki, ocsp.Revoked)}
+
+	err := sc.VerifyWithX5Chain(anchors)
+	assert.EqualError(t, err, `x5chain: certificate "CN=Leaf" is revoked (OCSP)`)
+}
+
+func TestSignedCorim_VerifyWithX5Chain_OCSP_revokedIntermediate(t *testing.T) {
+	pki := buildTestPKI(t)
+	sc := pkiSignedCorim(t, pki)
+
+	anchors := pkiTrustAnchors(pki)
+	anchors.OCSPResponses = [][]byte{
+		makeLeafOCSPResponse(t, pki, ocsp.Good),
+		makeOCSPResponse(
+			t, pki.intermediate, pki.root, pki.root, pki.rootKey,
+			ocsp.Revoked, time.Now().Add(time.Hour),
+		),
+	}
+
+	err := sc.VerifyWithX5Chain(anchors)
+	assert.EqualError(t, err, `x5chain: certificate "CN=Intermediate CA" is revoked (OCSP)`)
+}
+
+func TestSignedCorim_VerifyWithX5Chain_OCSP_unknown(t *testing.T) {
+	pki := buildTestPKI(t)
+	sc := pkiSignedCorim(t, pki)
+
+	anchors := pkiTrustAnchors(pki)
+	anchors.OCSPResponses = [][]byte{makeLeafOCSPResponse(t, pki, ocsp.Unknown)}
+
+	err := sc.VerifyWithX5Chain(anchors)
+	assert.EqualError(t, err, `x5chain: certificate "CN=Leaf" has unknown OCSP status`)
+
+	anchors.OcspPolicy = OcspPolicyPermissive
+	assert.NoError(t, sc.VerifyWithX5Chain(anchors))
+}
+
+func TestSignedCorim_VerifyWithX5Chain_OCSP_missingLeafResponse(t *testing.T) {
+	pki := buildTestPKI(t)
+	sc := pkiSignedCorim(t, pki)
+
+	anchors := pkiTrustAnchors(pki)
+	anchors.OCSPResponses = [][]byte{
+		makeOCSPResponse(
+			t, pki.intermediate, pki.root, pki.root, pki.rootKey,
+			ocsp.Good, time.Now().Add(time.Hour),
+		),
+	}
+
+	err := sc.VerifyWithX5Chain(anchors)
+	assert.EqualError(t, err, `x5chain: no valid OCSP response for certificate "CN=Leaf"`)
+
+	anchors.OcspPolicy = OcspPolicyPermissive
+	assert.NoError(t, sc.VerifyWithX5Chain(anchors))
+}
+
+func TestSignedCorim_VerifyWithX5Chain_OCSP_wrongSigner(t *testing.T) {
+	pki := buildTestPKI(t)
+	sc := pkiSignedCorim(t, pki)
+
+	// same serial number, but signed by an unrelated CA
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherCA, err := x509.ParseCertificate(mustCreateCA(t, otherKey, "Other CA"))
+	require.NoError(t, err)
+
+	anchors := pkiTrustAnchors(pki)
+	anchors.OCSPResponses = [][]byte{
+		makeOCSPResponse(t, pki.leaf, otherCA, otherCA, otherKey, ocsp.Good, time.Now().Add(time.Hour)),
+	}
+
+	err = sc.VerifyWithX5Chain(anchors)
+	assert.EqualError(t, err, `x5chain: no valid OCSP response for certificate "CN=Leaf"`)
+}
+
+func TestSignedCorim_VerifyWithX5Chain_OCSP_expired(t *testing.T) {
+	pki := buildTestPKI(t)
+	sc := pkiSignedCorim(t, pki)
+
+	anchors := pkiTrustAnchors(pki)
+	anchors.OCSPResponses = [][]byte{
+		makeOCSPResponse(
+			t, pki.leaf, pki.intermediate, pki.intermediate, pki.intermediateKey,
+			ocsp.Good, time.Now().Add(-time.Second),
+		),
+	}
+
+	err := sc.VerifyWithX5Chain(anchors)
+	assert.EqualError(t, err, `x5chain: OCSP response for certificate "CN=Leaf" has expired`)
+}
+
+func TestSignedCorim_VerifyWithX5Chain_OCSP_noNextUpdate(t *testing.T) {
+	pki := buildTestPKI(t)
+	sc := pkiSignedCorim(t, pki)
+
+	anchors := pkiTrustAnchors(pki)
+	anchors.OCSPResponses = [][]byte{
+		makeOCSPResponse(
+			t, pki.leaf, pki.intermediate, pki.intermediate, pki.intermediateKey,
+			ocsp.Good, time.Time{},
+		),
+	}
+
+	err := sc.VerifyWithX5Chain(anchors)
+	assert.EqualError(t, err, `x5chain: OCSP response for certificate "CN=Leaf" has no nextUpdate`)
+
+	anchors.OcspPolicy = OcspPolicyPermissive
+	assert.NoError(t, sc.VerifyWithX5Chain(anchors))
+}
+
+func TestSignedCorim_VerifyWithX5Chain_OCSP_notYetValid(t *testing.T) {
+	pki := buildTestPKI(t)
+	sc := pkiSignedCorim(t, pki)
+
+	anchors := pkiTrustAnchors(pki)
+	anchors.OCSPResponses = [][]byte{makeLeafOCSPResponse(t, pki, ocsp.Good)}
+	anchors.CurrentTime = time.Now().Add(-10 * time.Minute)
+
+	err := sc.VerifyWithX5Chain(anchors)
+	assert.EqualError(t, err, `x5chain: OCSP response for certificate "CN=Leaf" is not yet valid`)
+}
+
+func TestSignedCorim_VerifyWithX5Chain_OCSP_delegatedResponder(t *testing.T) {
+	pki := buildTestPKI(t)
+	sc := pkiSignedCorim(t, pki)
+
+	responder, responderKey := makeOCSPResponder(t, pki, []x509.ExtKeyUsage{x509.ExtKeyUsageOCSPSigning})
+
+	anchors := pkiTrustAnchors(pki)
+	anchors.OCSPResponses = [][]byte{
+		makeOCSPResponse(
+			t, pki.leaf, pki.intermediate, responder, responderKey,
+			ocsp.Good, time.Now().Add(time.Hour),
+		),
+	}
+
+	assert.NoError(t, sc.VerifyWithX5Chain(anchors))
+}
+
+func TestSignedCorim_VerifyWithX5Chain_OCSP_delegatedResponderWithoutEKU(t *testing.T) {
+	pki := buildTestPKI(t)
+	sc := pkiSignedCorim(t, pki)
+
+	responder, responderKey := makeOCSPResponder(t, pki, nil)
+
+	anchors := pkiTrustAnchors(pki)
+	anchors.OCSPResponses = [][]byte{
+		makeOCSPResponse(
+			t, pki.leaf, pki.intermediate, responder, responderKey,
+			ocsp.Good, time.Now().Add(time.Hour),
+		),
+	}
+
+	err := sc.VerifyWithX5Chain(anchors)
+	assert.EqualError(t, err, `x5chain: OCSP responder "CN=OCSP Responder" lacks OCSPSigning extended key usage`)
+}
+
+func TestSignedCorim_VerifyWithX5Chain_OCSP_replacesLeafCRL(t *testing.T) {
+	pki := buildTestPKI(t)
+	sc := pkiSignedCorim(t, pki)
+
+	// only the root CRL is supplied: the leaf is covered by OCSP
+	anchors := pkiTrustAnchors(pki)
+	anchors.CRLs = []*x509.RevocationList{makeValidCRL(t, pki.root, pki.rootKey)}
+
+	err := sc.VerifyWithX5Chain(anchors)
+	assert.EqualError(t, err, "x5chain verification failed: unable to get certificate CRL")
+
+	anchors.OCSPResponses = [][]byte{makeLeafOCSPResponse(t, pki, ocsp.Good)}
+	assert.NoError(t, sc.VerifyWithX5Chain(anchors))
+}
+
+func TestSignedCorim_stapledOCSP_roundtrip(t *testing.T) {
+	pki := buildTestPKI(t)
+
+	signer, err := NewSignerFromJWK(mustECPrivateKeyJWK(t, pki.leafKey))
+	require.NoError(t, err)
+
+	in := NewSignedCorim()
+	in.UnsignedCorim = *unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR)
+	in.Meta = *metaGood(t)
+	require.NoError(t, in.AddSigningCert(pki.leafDER))
+	require.NoError(t, in.AddIntermediateCerts(pki.intermediateDER))
+	in.OCSPResponses = [][]byte{makeLeafOCSPResponse(t, pki, ocsp.Revoked)}
+
+	data, err := in.Sign(signer)
+	require.NoError(t, err)
+
+	var out SignedCorim
+	require.NoError(t, out.FromCOSE(data))
+	assert.Equal(t, in.OCSPResponses, out.OCSPResponses)
+
+	err = out.VerifyWithX5Chain(pkiTrustAnchors(pki))
+	assert.EqualError(t, err, `x5chain: certificate "CN=Leaf" is revoked (OCSP)`)
+
+	// the stapled response is not protected by the signature, and can be
+	// stripped; the trust anchors can still require one
+	anchors := pkiTrustAnchors(pki)
+	anchors.OCSPResponses = [][]byte{
+		makeOCSPResponse(
+			t, pki.intermediate, pki.root, pki.root, pki.rootKey,
+			ocsp.Good, time.Now().Add(time.Hour),
+		),
+	}
+	out.OCSPResponses = nil
+
+	err = out.VerifyWithX5Chain(anchors)
+	assert.EqualError(t, err, `x5chain: no valid OCSP response for certificate "CN=Leaf"`)
+}
+
+func TestSignedCorim_FromCOSE_badStapledOCSP(t *testing.T) {
+	pki := buildTestPKI(t)
+
+	signer, err := NewSignerFromJWK(mustECPrivateKeyJWK(t, pki.leafKey))
+	require.NoError(t, err)
+
+	in := NewSignedCorim()
+	in.UnsignedCorim = *unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR)
+	in.Meta = *metaGood(t)
+	_, err = in.Sign(signer)
+	require.NoError(t, err)
+
+	in.message.Headers.Unprotected[HeaderLabelOCSPResponses] = []interface{}{"not a bstr"}
+	data, err := in.message.MarshalCBOR()
+	require.NoError(t, err)
+
+	var out SignedCorim
+	err = out.FromCOSE(data)
+	assert.EqualError(t, err,
+		"processing COSE headers: stapled OCSP responses: OCSP response at index 0: got string, want []byte")
+	assert.Nil(t, out.OCSPResponses)
+}
+
+func TestLoadOCSPResponses(t *testing.T) {
+	pki := buildTestPKI(t)
+
+	good := makeLeafOCSPResponse(t, pki, ocsp.Good)
+	revoked := makeLeafOCSPResponse(t, pki, ocsp.Revoked)
+
+	files := map[string][]byte{
+		"good.der": good,
+		"both.pem": append(pemEncode("OCSP RESPONSE", good), pemEncode("OCSP RESPONSE", revoked)...),
+		"cert.pem": pemEncode("CERTIFICATE", pki.leafDER),
+		"junk.der": []byte("junk"),
+	}
+	readFile := func(path string) ([]byte, error) {
+		data, ok := files[path]
+		if !ok {
+			return nil, errors.New("no such file")
+		}
+		return data, nil
+	}
+
+	responses, err := LoadOCSPResponses(readFile, []string{"good.der", "both.pem"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{good, good, revoked}, responses)
+
+	_, err = LoadOCSPResponses(readFile, []string{"missing.der"})
+	assert.EqualError(t, err, "loading OCSP response from missing.der: no such file")
+
+	_, err = LoadOCSPResponses(readFile, []string{"cert.pem"})
+	assert.EqualError(t, err, `parsing OCSP response from cert.pem: invalid PEM block type "CERTIFICATE"`)
+
+	_, err = LoadOCSPResponses(readFile, []string{"junk.der"})
+	assert.ErrorContains(t, err, "parsing OCSP response from junk.der")
+}
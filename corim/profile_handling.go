@@ -10,6 +10,7 @@ import (
 	"reflect"
 
 	"github.com/veraison/corim/comid"
+	"github.com/veraison/corim/cotl"
 	"github.com/veraison/corim/extensions"
 	"github.com/veraison/go-cose"
 )
@@ -74,34 +75,57 @@ func UnmarshalSignedCorimFromCBOR(buf []byte) (*SignedCorim, error) {
 	return ret, nil
 }
 
+// UnmarshalMultiSignedCorimFromCBOR unmarshals a MultiSignedCorim from
+// provided CBOR data. If there are extensions associated with the profile
+// specified by the data, they will be registered with the UnsignedCorim before
+// it is unmarshaled.
+func UnmarshalMultiSignedCorimFromCBOR(buf []byte) (*MultiSignedCorim, error) {
+	message := cose.NewSignMessage()
+
+	if err := message.UnmarshalCBOR(buf); err != nil {
+		return nil, fmt.Errorf("failed CBOR decoding for COSE-Sign signed CoRIM: %w", err)
+	}
+
+	profiled := struct {
+		Profile *Profile `cbor:"3,keyasint,omitempty"`
+	}{}
+
+	if err := dm.Unmarshal(message.Payload, &profiled); err != nil {
+		return nil, err
+	}
+
+	ret := &MultiSignedCorim{UnsignedCorim: *GetUnsignedCorim(profiled.Profile)}
+	if err := ret.FromCOSE(buf); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 // UnmarshalAndValidateSignedCorimFromCBOR unmarshals and validates a
 // SignedCorim from provided CBOR data. If there are extensions associated
 // with the profile specified by the data, they will be registered with the
 // UnsignedCorim before it is unmarshaled. This also validates any embedded
-// CoMIDs.
-func UnmarshalAndValidateSignedCorimFromCBOR(data []byte) (*SignedCorim, error) {
+// CoMIDs. An optional DecodingPolicy (e.g., StrictDecoding) selects how the
+// data is decoded; if more than one is supplied, the last one is used.
+func UnmarshalAndValidateSignedCorimFromCBOR(data []byte, policy ...DecodingPolicy) (*SignedCorim, error) {
+	p := lastDecodingPolicy(policy)
+
+	if err := p.checkCOSE(data); err != nil {
+		return nil, err
+	}
+
 	sc, err := UnmarshalSignedCorimFromCBOR(data)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := sc.UnsignedCorim.Valid(); err != nil {
+	if err := p.checkDecoded(&sc.Meta); err != nil {
 		return nil, err
 	}
 
-	for i, tag := range sc.UnsignedCorim.Tags {
-		if tag.Number != ComidTag {
-			continue
-		}
-
-		cm, err := UnmarshalComidFromCBOR(tag.Content, sc.UnsignedCorim.Profile)
-		if err != nil {
-			return nil, fmt.Errorf("CoMID tag at index %d: %w", i, err)
-		}
-
-		if err := cm.Valid(); err != nil {
-			return nil, fmt.Errorf("CoMID tag at index %d: %w", i, err)
-		}
+	if err := validateUnsignedCorim(&sc.UnsignedCorim, p); err != nil {
+		return nil, err
 	}
 
 	return sc, nil
@@ -157,14 +181,21 @@ func UnmarshalUnsignedCorimFromJSON(buf []byte) (*UnsignedCorim, error) {
 // UnsignedCorim from provided CBOR data. If there are extensions associated
 // with the profile specified by the data, they will be registered with the
 // UnsignedCorim before it is unmarshaled. This also validates any embedded
-// CoRIMs.
-func UnmarshalAndValidateUnsignedCorimFromCBOR(data []byte) (*UnsignedCorim, error) {
+// CoRIMs. An optional DecodingPolicy (e.g., StrictDecoding) selects how the
+// data is decoded; if more than one is supplied, the last one is used.
+func UnmarshalAndValidateUnsignedCorimFromCBOR(data []byte, policy ...DecodingPolicy) (*UnsignedCorim, error) {
+	p := lastDecodingPolicy(policy)
+
+	if err := p.checkCBOR(data); err != nil {
+		return nil, err
+	}
+
 	uc, err := UnmarshalUnsignedCorimFromCBOR(data)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := validateUnsignedCorim(uc); err != nil {
+	if err := validateUnsignedCorim(uc, p); err != nil {
 		return nil, err
 	}
 
@@ -175,14 +206,21 @@ func UnmarshalAndValidateUnsignedCorimFromCBOR(data []byte) (*UnsignedCorim, err
 // UnsignedCorim from provided JSON data. If there are extensions associated
 // with the profile specified by the data, they will be registered with the
 // UnsignedCorim before it is unmarshaled. This also validates any embedded
-// CoRIMs.
-func UnmarshalAndValidateUnsignedCorimFromJSON(data []byte) (*UnsignedCorim, error) {
+// CoRIMs. An optional DecodingPolicy (e.g., StrictDecoding) selects how the
+// data is decoded; if more than one is supplied, the last one is used.
+func UnmarshalAndValidateUnsignedCorimFromJSON(data []byte, policy ...DecodingPolicy) (*UnsignedCorim, error) {
+	p := lastDecodingPolicy(policy)
+
+	if err := p.checkJSON(data); err != nil {
+		return nil, err
+	}
+
 	uc, err := UnmarshalUnsignedCorimFromJSON(data)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := validateUnsignedCorim(uc); err != nil {
+	if err := validateUnsignedCorim(uc, p); err != nil {
 		return nil, err
 	}
 
@@ -396,12 +434,27 @@ func GetProfileManifest(id *Profile) (ProfileManifest, bool) {
 	return prof, ok
 }
 
-func validateUnsignedCorim(uc *UnsignedCorim) error {
+func validateUnsignedCorim(uc *UnsignedCorim, p DecodingPolicy) error {
+	if err := p.checkDecoded(uc); err != nil {
+		return err
+	}
+
 	if err := uc.Valid(); err != nil {
 		return err
 	}
 
 	for i, tag := range uc.Tags {
+		if err := p.checkCBOR(tag.Content); err != nil {
+			return fmt.Errorf("tag at index %d: %w", i, err)
+		}
+
+		if tag.Number == cotl.CotlTag {
+			if err := validateCotl(tag.Content); err != nil {
+				return fmt.Errorf("CoTL tag at index %d: %w", i, err)
+			}
+			continue
+		}
+
 		if tag.Number != ComidTag {
 			continue
 		}
@@ -411,6 +464,10 @@ func validateUnsignedCorim(uc *UnsignedCorim) error {
 			return fmt.Errorf("CoMID tag at index %d: %w", i, err)
 		}
 
+		if err := p.checkDecoded(cm); err != nil {
+			return fmt.Errorf("CoMID tag at index %d: %w", i, err)
+		}
+
 		if err := cm.Valid(); err != nil {
 			return fmt.Errorf("CoMID tag at index %d: %w", i, err)
 		}
@@ -438,3 +495,13 @@ func init() {
 		AllExtensionPoints[p] = true
 	}
 }
+
+func validateCotl(data []byte) error {
+	var tl cotl.ConciseTagList
+
+	if err := tl.FromCBOR(data); err != nil {
+		return err
+	}
+
+	return tl.Valid()
+}
@@ -0,0 +1,304 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/veraison/corim/comid"
+)
+
+// DefaultMaxRimDepth is the maximum depth of the dependency graph used by a
+// Resolver with no MaxDepth.
+const DefaultMaxRimDepth = 16
+
+var (
+	// ErrThumbprintMismatch is returned when a fetched CoRIM does not match
+	// the thumbprint of its locator.
+	ErrThumbprintMismatch = errors.New("thumbprint mismatch")
+	// ErrRimCycle is returned when a CoRIM (transitively) depends on itself.
+	ErrRimCycle = errors.New("dependency cycle")
+	// ErrRimDepthExceeded is returned when the dependency graph is deeper
+	// than allowed.
+	ErrRimDepthExceeded = errors.New("maximum dependency depth exceeded")
+)
+
+// SignatureVerifier verifies the signature of a signed CoRIM fetched from
+// the supplied href.
+type SignatureVerifier func(href string, sc *SignedCorim) error
+
+// VerifyWithKey returns a SignatureVerifier that checks signatures against
+// the supplied public key.
+func VerifyWithKey(pk crypto.PublicKey) SignatureVerifier {
+	return func(_ string, sc *SignedCorim) error {
+		return sc.Verify(pk)
+	}
+}
+
+// VerifyWithTrustAnchors returns a SignatureVerifier that checks signatures
+// against the x5chain of the signed CoRIMs, which must chain up to the
+// supplied trust anchors.
+func VerifyWithTrustAnchors(anchors TrustAnchors) SignatureVerifier {
+	return func(_ string, sc *SignedCorim) error {
+		return sc.VerifyWithX5Chain(anchors)
+	}
+}
+
+// Resolver follows the dependent RIMs (corim-locator-map entries) of CoRIMs,
+// and builds their dependency graph.
+type Resolver struct {
+	// Fetcher retrieves the dependent CoRIMs
+	Fetcher Fetcher
+	// Verifier verifies the signature of fetched signed CoRIMs. If nil,
+	// signed CoRIMs cannot be resolved.
+	Verifier SignatureVerifier
+	// AllowUnsigned allows fetched CoRIMs to be unsigned
+	AllowUnsigned bool
+	// MaxDepth is the maximum depth of the dependency graph, the root CoRIM
+	// being at depth 0. If zero, DefaultMaxRimDepth is used.
+	MaxDepth int
+}
+
+// RimNode is a node of the dependency graph built by a Resolver
+type RimNode struct {
+	// Href is the location the CoRIM was fetched from. It is empty for a
+	// root CoRIM that was not fetched.
+	Href string
+	// Corim is the (unsigned) CoRIM
+	Corim *UnsignedCorim
+	// Signed is the signed CoRIM wrapping Corim, or nil if the CoRIM was
+	// unsigned
+	Signed *SignedCorim
+	// Dependencies holds the nodes of the dependent RIMs of Corim, in the
+	// order they appear in. A CoRIM that is depended upon by several
+	// others is represented by a single, shared, node.
+	Dependencies []*RimNode
+}
+
+// All returns the target node, followed by all the nodes it (transitively)
+// depends on, in depth-first order. Each node appears only once.
+func (o *RimNode) All() []*RimNode {
+	var ret []*RimNode
+
+	seen := make(map[*RimNode]bool)
+
+	var walk func(n *RimNode)
+	walk = func(n *RimNode) {
+		if seen[n] {
+			return
+		}
+		seen[n] = true
+		ret = append(ret, n)
+
+		for _, d := range n.Dependencies {
+			walk(d)
+		}
+	}
+
+	walk(o)
+
+	return ret
+}
+
+// Resolve builds the dependency graph rooted at the supplied CoRIM, fetching
+// its dependent RIMs recursively.
+func (o Resolver) Resolve(root *UnsignedCorim) (*RimNode, error) {
+	if root == nil {
+		return nil, errors.New("nil CoRIM")
+	}
+
+	r := o.newResolution()
+	node := &RimNode{Corim: root}
+
+	if err := r.resolveDependencies(node, 0); err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// ResolveLocator fetches the CoRIM pointed to by the supplied locator, and
+// builds the dependency graph rooted at it.
+func (o Resolver) ResolveLocator(loc Locator) (*RimNode, error) {
+	return o.newResolution().resolveLocator(loc, 0)
+}
+
+type resolution struct {
+	Resolver
+	// nodes holds the nodes resolved so far, keyed by href
+	nodes map[string]*RimNode
+	// data holds the bytes fetched for each node, keyed by href, so that
+	// the thumbprints of later locators can be checked against them
+	data map[string][]byte
+	// path holds the hrefs being resolved, from the root down
+	path []string
+}
+
+func (o Resolver) newResolution() *resolution {
+	if o.MaxDepth == 0 {
+		o.MaxDepth = DefaultMaxRimDepth
+	}
+
+	return &resolution{
+		Resolver: o,
+		nodes:    make(map[string]*RimNode),
+		data:     make(map[string][]byte),
+	}
+}
+
+func (o *resolution) resolveDependencies(node *RimNode, depth int) error {
+	if node.Corim.DependentRims == nil {
+		return nil
+	}
+
+	for i, loc := range *node.Corim.DependentRims {
+		dep, err := o.resolveLocator(loc, depth+1)
+		if err != nil {
+			return fmt.Errorf("dependent RIM at index %d: %w", i, err)
+		}
+
+		node.Dependencies = append(node.Dependencies, dep)
+	}
+
+	return nil
+}
+
+func (o *resolution) resolveLocator(loc Locator, depth int) (*RimNode, error) {
+	if o.Fetcher == nil {
+		return nil, errors.New("no fetcher")
+	}
+
+	if err := loc.Valid(); err != nil {
+		return nil, fmt.Errorf("invalid locator: %w", err)
+	}
+
+	if depth > o.MaxDepth {
+		return nil, fmt.Errorf("%w (%d)", ErrRimDepthExceeded, o.MaxDepth)
+	}
+
+	var errs []error
+
+	// the hrefs are alternative locations of the same CoRIM
+	for _, uri := range loc.Href {
+		href := string(uri)
+
+		for _, p := range o.path {
+			if p == href {
+				return nil, fmt.Errorf("%w: %s -> %s",
+					ErrRimCycle, strings.Join(o.path, " -> "), href)
+			}
+		}
+
+		if node, ok := o.nodes[href]; ok {
+			// the CoRIM may have been fetched through a locator with no, or
+			// different, thumbprints
+			if err := checkThumbprint(href, o.data[href], loc.Thumbprint); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			return node, nil
+		}
+
+		node, data, err := o.fetch(href, loc.Thumbprint)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		o.nodes[href] = node
+		o.data[href] = data
+		o.path = append(o.path, href)
+
+		err = o.resolveDependencies(node, depth)
+
+		o.path = o.path[:len(o.path)-1]
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", href, err)
+		}
+
+		return node, nil
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+func checkThumbprint(href string, data []byte, thumbprint *OneOrMore[comid.Digest]) error {
+	if thumbprint == nil {
+		return nil
+	}
+
+	for i, tp := range *thumbprint {
+		ok, err := tp.Matches(data)
+		if err != nil {
+			return fmt.Errorf("%s: thumbprint at index %d: %w", href, i, err)
+		}
+		if !ok {
+			return fmt.Errorf("%s: %w (%s)", href, ErrThumbprintMismatch, tp.Algorithm)
+		}
+	}
+
+	return nil
+}
+
+func (o *resolution) fetch(href string, thumbprint *OneOrMore[comid.Digest]) (*RimNode, []byte, error) {
+	data, err := o.Fetcher.Fetch(href)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = checkThumbprint(href, data, thumbprint); err != nil {
+		return nil, nil, err
+	}
+
+	node, err := o.decode(href, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return node, data, nil
+}
+
+func (o *resolution) decode(href string, data []byte) (*RimNode, error) {
+	var err error
+
+	node := &RimNode{Href: href}
+
+	if bytes.HasPrefix(data, UnsignedCorimTag) {
+		if !o.AllowUnsigned {
+			return nil, fmt.Errorf("%s: unsigned CoRIM not allowed", href)
+		}
+
+		if node.Corim, err = UnmarshalUnsignedCorimFromCBOR(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", href, err)
+		}
+
+		if err = node.Corim.Valid(); err != nil {
+			return nil, fmt.Errorf("%s: %w", href, err)
+		}
+
+		return node, nil
+	}
+
+	if node.Signed, err = UnmarshalSignedCorimFromCBOR(data); err != nil {
+		return nil, fmt.Errorf("%s: %w", href, err)
+	}
+
+	if o.Verifier == nil {
+		return nil, fmt.Errorf("%s: no signature verifier", href)
+	}
+
+	if err = o.Verifier(href, node.Signed); err != nil {
+		return nil, fmt.Errorf("%s: signature verification failed: %w", href, err)
+	}
+
+	node.Corim = &node.Signed.UnsignedCorim
+
+	return node, nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/comid"
+)
+
+func testRimCorim(t *testing.T, id string, deps ...string) *UnsignedCorim {
+	uc := unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR).SetID(id)
+	for _, d := range deps {
+		uc.AddDependentRim(d, nil)
+	}
+	return uc
+}
+
+func testRimCBOR(t *testing.T, id string, deps ...string) []byte {
+	data, err := testRimCorim(t, id, deps...).ToCBOR()
+	require.NoError(t, err)
+	return data
+}
+
+func testSignedRimCBOR(t *testing.T, id string, deps ...string) []byte {
+	signer, err := NewSignerFromJWK(testES256Key)
+	require.NoError(t, err)
+
+	sc := SignedCorim{UnsignedCorim: *testRimCorim(t, id, deps...), Meta: *metaGood(t)}
+	data, err := sc.Sign(signer)
+	require.NoError(t, err)
+	return data
+}
+
+func testThumbprint(t *testing.T, data []byte) *comid.Digest {
+	sum, err := comid.IntDigestAlgorithm(comid.Sha256).Compute(data)
+	require.NoError(t, err)
+	return comid.NewDigestIntAlg(comid.Sha256, sum)
+}
+
+func rimIDs(nodes []*RimNode) []string {
+	ret := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		ret = append(ret, n.Corim.GetID())
+	}
+	return ret
+}
+
+func TestResolver_Resolve_OK(t *testing.T) {
+	// root -> a -> c
+	//      -> b -> c
+	fetcher := NewMemoryFetcher().
+		Add("https://example.com/a", testRimCBOR(t, "a", "https://example.com/c")).
+		Add("https://example.com/b", testRimCBOR(t, "b", "https://example.com/c")).
+		Add("https://example.com/c", testRimCBOR(t, "c"))
+
+	resolver := Resolver{Fetcher: fetcher, AllowUnsigned: true}
+
+	root, err := resolver.Resolve(testRimCorim(t, "root", "https://example.com/a", "https://example.com/b"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"root", "a", "c", "b"}, rimIDs(root.All()))
+	require.Len(t, root.Dependencies, 2)
+	assert.Equal(t, "https://example.com/a", root.Dependencies[0].Href)
+	assert.Nil(t, root.Dependencies[0].Signed)
+	// c is shared by a and b
+	assert.Same(t, root.Dependencies[0].Dependencies[0], root.Dependencies[1].Dependencies[0])
+}
+
+func TestResolver_Resolve_signed(t *testing.T) {
+	fetcher := NewMemoryFetcher().
+		Add("a.cbor", testSignedRimCBOR(t, "a"))
+
+	pk, err := NewPublicKeyFromJWK(testES256Key)
+	require.NoError(t, err)
+
+	resolver := Resolver{Fetcher: fetcher, Verifier: VerifyWithKey(pk)}
+	root, err := resolver.Resolve(testRimCorim(t, "root", "a.cbor"))
+	require.NoError(t, err)
+	require.Len(t, root.Dependencies, 1)
+	assert.NotNil(t, root.Dependencies[0].Signed)
+	assert.Equal(t, "a", root.Dependencies[0].Corim.GetID())
+
+	otherPK, err := NewPublicKeyFromJWK(testES384Key)
+	require.NoError(t, err)
+	resolver.Verifier = VerifyWithKey(otherPK)
+	_, err = resolver.Resolve(testRimCorim(t, "root", "a.cbor"))
+	assert.ErrorContains(t, err, "a.cbor: signature verification failed")
+
+	resolver.Verifier = nil
+	_, err = resolver.Resolve(testRimCorim(t, "root", "a.cbor"))
+	assert.ErrorContains(t, err, "a.cbor: no signature verifier")
+}
+
+func TestResolver_Resolve_unsigned_not_allowed(t *testing.T) {
+	fetcher := NewMemoryFetcher().Add("a.cbor", testRimCBOR(t, "a"))
+
+	_, err := Resolver{Fetcher: fetcher}.Resolve(testRimCorim(t, "root", "a.cbor"))
+	assert.EqualError(t, err, "dependent RIM at index 0: a.cbor: unsigned CoRIM not allowed")
+}
+
+func TestResolver_Resolve_thumbprint(t *testing.T) {
+	a := testRimCBOR(t, "a")
+	fetcher := NewMemoryFetcher().Add("a.cbor", a)
+	resolver := Resolver{Fetcher: fetcher, AllowUnsigned: true}
+
+	root := testRimCorim(t, "root").AddDependentRim("a.cbor", testThumbprint(t, a))
+	_, err := resolver.Resolve(root)
+	assert.NoError(t, err)
+
+	root = testRimCorim(t, "root").AddDependentRim("a.cbor", testThumbprint(t, []byte("other")))
+	_, err = resolver.Resolve(root)
+	assert.ErrorIs(t, err, ErrThumbprintMismatch)
+	assert.EqualError(t, err, "dependent RIM at index 0: a.cbor: thumbprint mismatch (sha-256)")
+
+	root = testRimCorim(t, "root").AddDependentRim("a.cbor",
+		comid.NewDigestStringAlg("md5", []byte{0x01}))
+	_, err = resolver.Resolve(root)
+	assert.EqualError(t, err,
+		"dependent RIM at index 0: a.cbor: thumbprint at index 0: unsupported hash algorithm md5")
+}
+
+func TestResolver_Resolve_thumbprint_cached(t *testing.T) {
+	a := testRimCBOR(t, "a")
+
+	pinned := func(tp *comid.Digest) []byte {
+		data, err := testRimCorim(t, "b").AddDependentRim("a.cbor", tp).ToCBOR()
+		require.NoError(t, err)
+		return data
+	}
+
+	// root -> a.cbor (no thumbprint)
+	//      -> b.cbor -> a.cbor (pinned)
+	fetcher := NewMemoryFetcher().
+		Add("a.cbor", a).
+		Add("b.cbor", pinned(testThumbprint(t, a)))
+	resolver := Resolver{Fetcher: fetcher, AllowUnsigned: true}
+
+	node, err := resolver.Resolve(testRimCorim(t, "root", "a.cbor", "b.cbor"))
+	require.NoError(t, err)
+	assert.Same(t, node.Dependencies[0], node.Dependencies[1].Dependencies[0])
+
+	// the thumbprint pinned by b.cbor is checked against the cached a.cbor
+	fetcher.Add("b.cbor", pinned(testThumbprint(t, []byte("other"))))
+
+	_, err = resolver.Resolve(testRimCorim(t, "root", "a.cbor", "b.cbor"))
+	assert.ErrorIs(t, err, ErrThumbprintMismatch)
+	assert.EqualError(t, err,
+		"dependent RIM at index 1: b.cbor: dependent RIM at index 0: a.cbor: thumbprint mismatch (sha-256)")
+}
+
+func TestResolver_Resolve_alternative_hrefs(t *testing.T) {
+	fetcher := NewMemoryFetcher().Add("mirror/a.cbor", testRimCBOR(t, "a"))
+	resolver := Resolver{Fetcher: fetcher, AllowUnsigned: true}
+
+	root := testRimCorim(t, "root")
+	root.DependentRims = &[]Locator{{
+		Href: OneOrMore[comid.TaggedURI]{"primary/a.cbor", "mirror/a.cbor"},
+	}}
+
+	node, err := resolver.Resolve(root)
+	require.NoError(t, err)
+	assert.Equal(t, "mirror/a.cbor", node.Dependencies[0].Href)
+
+	root.DependentRims = &[]Locator{{
+		Href: OneOrMore[comid.TaggedURI]{"primary/a.cbor", "other/a.cbor"},
+	}}
+	_, err = resolver.Resolve(root)
+	assert.ErrorIs(t, err, ErrRimNotFound)
+	assert.ErrorContains(t, err, "primary/a.cbor")
+	assert.ErrorContains(t, err, "other/a.cbor")
+}
+
+func TestResolver_Resolve_cycle(t *testing.T) {
+	fetcher := NewMemoryFetcher().
+		Add("a", testRimCBOR(t, "a", "b")).
+		Add("b", testRimCBOR(t, "b", "c")).
+		Add("c", testRimCBOR(t, "c", "a"))
+
+	_, err := Resolver{Fetcher: fetcher, AllowUnsigned: true}.
+		Resolve(testRimCorim(t, "root", "a"))
+	assert.ErrorIs(t, err, ErrRimCycle)
+	assert.ErrorContains(t, err, "dependency cycle: a -> b -> c -> a")
+}
+
+func TestResolver_Resolve_depth(t *testing.T) {
+	fetcher := NewMemoryFetcher().
+		Add("a", testRimCBOR(t, "a", "b")).
+		Add("b", testRimCBOR(t, "b", "c")).
+		Add("c", testRimCBOR(t, "c"))
+
+	resolver := Resolver{Fetcher: fetcher, AllowUnsigned: true, MaxDepth: 3}
+	_, err := resolver.Resolve(testRimCorim(t, "root", "a"))
+	assert.NoError(t, err)
+
+	resolver.MaxDepth = 2
+	_, err = resolver.Resolve(testRimCorim(t, "root", "a"))
+	assert.ErrorIs(t, err, ErrRimDepthExceeded)
+}
+
+func TestResolver_ResolveLocator(t *testing.T) {
+	fetcher := NewMemoryFetcher().
+		Add("a", testRimCBOR(t, "a", "b")).
+		Add("b", testRimCBOR(t, "b"))
+
+	node, err := Resolver{Fetcher: fetcher, AllowUnsigned: true}.
+		ResolveLocator(Locator{Href: OneOrMore[comid.TaggedURI]{"a"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, rimIDs(node.All()))
+
+	_, err = Resolver{AllowUnsigned: true}.ResolveLocator(Locator{Href: OneOrMore[comid.TaggedURI]{"a"}})
+	assert.EqualError(t, err, "no fetcher")
+
+	_, err = Resolver{Fetcher: fetcher}.ResolveLocator(Locator{})
+	assert.EqualError(t, err, "invalid locator: href: must have at least one")
+}
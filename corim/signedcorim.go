@@ -10,6 +10,7 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -23,16 +24,37 @@ var (
 	HeaderLabelCorimMeta = int64(8)
 
 	errNoSign1Message = errors.New("no Sign1 message found")
+	errNoPayload      = errors.New("detached payload not supplied")
 )
 
 // SignedCorim encodes a signed-corim message (i.e., a COSE Sign1 wrapped CoRIM)
-// with signature and verification methods
+// with signature and verification methods.
+//
+// If Detached is set, the payload is not carried in the COSE_Sign1 message and
+// must be conveyed to the verifier separately. If HashEnvelope is set, the
+// payload is the hash of the unsigned-corim rather than the unsigned-corim
+// itself. The two can be combined.
+//
+// CWTClaims, if set, are carried in the CWT Claims header parameter (RFC 9597)
+// alongside corim-meta. If Meta is empty, corim-meta is omitted and the CWT
+// claims are used instead.
+//
+// OCSPResponses, if set, are stapled to the unprotected header and checked by
+// [SignedCorim.VerifyWithX5Chain] alongside those in the trust anchors.
+//
+// TimestampToken, if set, is an RFC 3161 timestamp token over the signature,
+// added with [SignedCorim.AddTimestamp] after signing.
 type SignedCorim struct {
 	UnsignedCorim     UnsignedCorim
 	Meta              Meta
+	CWTClaims         *CWTClaims
 	KeyID             []byte
 	SigningCert       *x509.Certificate
 	IntermediateCerts []*x509.Certificate
+	Detached          bool
+	HashEnvelope      *HashEnvelope
+	OCSPResponses     [][]byte
+	TimestampToken    []byte
 	message           *cose.Sign1Message
 }
 
@@ -66,12 +88,21 @@ func (o *SignedCorim) processHdrs() error {
 		return errors.New("missing mandatory protected header")
 	}
 
-	if v, ok := hdr.Protected[cose.HeaderLabelContentType]; ok {
-		if v != ContentType {
-			return fmt.Errorf("expecting content type %q, got %q instead", ContentType, v)
+	he, err := decodeHashEnvelopeHeaders(hdr.Protected)
+	if err != nil {
+		return fmt.Errorf("hash envelope: %w", err)
+	}
+
+	o.HashEnvelope = he
+
+	if he == nil {
+		if v, ok := hdr.Protected[cose.HeaderLabelContentType]; ok {
+			if v != ContentType {
+				return fmt.Errorf("expecting content type %q, got %q instead", ContentType, v)
+			}
+		} else {
+			return errors.New("missing mandatory content type")
 		}
-	} else {
-		return errors.New("missing mandatory content type")
 	}
 
 	if v, ok := hdr.Protected[cose.HeaderLabelKeyID]; ok {
@@ -83,12 +114,30 @@ func (o *SignedCorim) processHdrs() error {
 		}
 	}
 
+	if v, ok := hdr.Protected[cose.HeaderLabelCWTClaims]; ok {
+		claims, err := decodeCWTClaimsHeader(v)
+		if err != nil {
+			return fmt.Errorf("CWT claims: %w", err)
+		}
+		o.CWTClaims = claims
+	}
+
 	if v, ok := hdr.Protected[HeaderLabelCorimMeta]; ok {
 		if err := o.extractMeta(v); err != nil {
 			return err
 		}
+	} else if o.CWTClaims != nil {
+		// keep any registered signer extensions
+		m := o.CWTClaims.ToMeta()
+		o.Meta.Signer.Name = m.Signer.Name
+		o.Meta.Signer.URI = nil
+		o.Meta.Validity = m.Validity
 	} else {
-		return errors.New("missing mandatory corim.meta")
+		return errors.New("missing mandatory corim.meta or CWT claims")
+	}
+
+	if err := o.checkCWTClaimsMeta(); err != nil {
+		return err
 	}
 
 	// Process optional x5chain
@@ -98,63 +147,152 @@ func (o *SignedCorim) processHdrs() error {
 		}
 	}
 
+	// Process optional stapled OCSP responses
+	if v, ok := hdr.Unprotected[HeaderLabelOCSPResponses]; ok {
+		responses, err := decodeOCSPHeader(v)
+		if err != nil {
+			return fmt.Errorf("stapled OCSP responses: %w", err)
+		}
+		o.OCSPResponses = responses
+	}
+
+	// Process optional timestamp token
+	if v, ok := hdr.Unprotected[HeaderLabelTimestampToken]; ok {
+		token, ok := v.([]byte)
+		if !ok {
+			return fmt.Errorf("timestamp token: expected a []byte but got %T", v)
+		}
+		o.TimestampToken = token
+	}
+
+	return nil
+}
+
+// checkCWTClaimsMeta checks that the CWT claims, if present, do not
+// contradict corim-meta
+func (o *SignedCorim) checkCWTClaimsMeta() error {
+	if o.CWTClaims == nil {
+		return nil
+	}
+
+	c := o.CWTClaims
+
+	if c.Issuer != nil && o.Meta.Signer.Name != "" && *c.Issuer != o.Meta.Signer.Name {
+		return fmt.Errorf("CWT claims: iss %q does not match corim.meta signer name %q",
+			*c.Issuer, o.Meta.Signer.Name)
+	}
+
+	if v := o.Meta.Validity; v != nil {
+		if c.Expiration != nil && !c.Expiration.Equal(v.NotAfter.Truncate(time.Second)) {
+			return errors.New("CWT claims: exp does not match corim.meta not-after")
+		}
+
+		if c.NotBefore != nil && v.NotBefore != nil && !c.NotBefore.Equal(v.NotBefore.Truncate(time.Second)) {
+			return errors.New("CWT claims: nbf does not match corim.meta not-before")
+		}
+	}
+
 	return nil
 }
 
+// VerifyCWTClaims checks the exp and nbf CWT claims (if any) against the
+// supplied time. It returns an error wrapping ErrCWTExpired or
+// ErrCWTNotYetValid if the signed-corim is not valid at that time.
+func (o *SignedCorim) VerifyCWTClaims(now time.Time) error {
+	if o.message == nil {
+		return errNoSign1Message
+	}
+
+	if o.CWTClaims == nil {
+		return nil
+	}
+
+	return o.CWTClaims.CheckAt(now)
+}
+
 func (o *SignedCorim) extractMeta(v interface{}) error {
+	meta, err := decodeMetaHeader(v)
+	if err != nil {
+		return err
+	}
+
+	o.Meta = *meta
+
+	return nil
+}
+
+func decodeMetaHeader(v interface{}) (*Meta, error) {
 	metaCBOR, ok := v.([]byte)
 	if !ok {
-		return fmt.Errorf("expecting CBOR-encoded CoRIM Meta, got %T instead", v)
+		return nil, fmt.Errorf("expecting CBOR-encoded CoRIM Meta, got %T instead", v)
 	}
 
 	var meta Meta
 
 	err := meta.FromCBOR(metaCBOR)
 	if err != nil {
-		return fmt.Errorf("unable to decode CoRIM Meta: %w", err)
+		return nil, fmt.Errorf("unable to decode CoRIM Meta: %w", err)
 	}
 
-	o.Meta = meta
-
-	return nil
+	return &meta, nil
 }
 
 func (o *SignedCorim) extractX5Chain(x5chain interface{}) error {
-	var (
-		signingCert       *x509.Certificate
-		intermediateCerts []*x509.Certificate
-		err               error
-	)
+	signingCert, intermediateCerts, err := DecodeX5ChainHeader(x5chain)
+	if err != nil {
+		return err
+	}
+
+	o.SigningCert = signingCert
+	o.IntermediateCerts = intermediateCerts
 
+	return nil
+}
+
+// DecodeX5ChainHeader returns the signing (leaf) and intermediate
+// certificates carried in the value of an x5chain COSE header parameter, as
+// decoded by go-cose.
+func DecodeX5ChainHeader(x5chain interface{}) (leaf *x509.Certificate, intermediates []*x509.Certificate, err error) {
 	switch t := x5chain.(type) {
 	case []interface{}:
 		elems := make([][]byte, len(t))
 		for i, elem := range t {
 			certDER, ok := elem.([]byte)
 			if !ok {
-				return fmt.Errorf("accessing x5chain[%d]: got %T, want []byte", i, elem)
+				return nil, nil, fmt.Errorf("accessing x5chain[%d]: got %T, want []byte", i, elem)
 			}
 
 			elems[i] = certDER
 		}
 
-		signingCert, intermediateCerts, err = parseX5ChainFromCertDERs(elems)
+		return parseX5ChainFromCertDERs(elems)
 	case [][]byte:
-		signingCert, intermediateCerts, err = parseX5ChainFromCertDERs(t)
+		return parseX5ChainFromCertDERs(t)
 	case []byte:
-		signingCert, err = parseX5ChainLeafDER(t)
+		leaf, err = parseX5ChainLeafDER(t)
+		return leaf, nil, err
 	default:
-		return fmt.Errorf("decoding x5chain: got %T, want []interface{}, [][]byte, or []byte", t)
+		return nil, nil, fmt.Errorf("decoding x5chain: got %T, want []interface{}, [][]byte, or []byte", t)
 	}
+}
 
-	if err != nil {
-		return err
+// EncodeX5ChainHeader returns the value of the x5chain COSE header parameter
+// for the supplied certificates
+func EncodeX5ChainHeader(leaf *x509.Certificate, intermediates []*x509.Certificate) interface{} {
+	// COSE_X509 = bstr / [ 2*certs: bstr ]
+	//
+	// handle alt (1): bstr
+	if len(intermediates) == 0 {
+		return leaf.Raw
 	}
 
-	o.SigningCert = signingCert
-	o.IntermediateCerts = intermediateCerts
+	// handle alt (2): [ 2*certs: bstr ]
+	certChain := [][]byte{leaf.Raw}
+	for _, cert := range intermediates {
+		certChain = append(certChain, cert.Raw)
+	}
 
-	return nil
+	return certChain
 }
 
 func parseX5ChainFromCertDERs(elems [][]byte) (leaf *x509.Certificate, intermediates []*x509.Certificate, err error) {
@@ -218,10 +356,31 @@ func parseX5ChainIntermediateDER(der []byte, index int) (*x509.Certificate, erro
 // signed-corim message, including the embedded unsigned-corim and corim-meta.
 // On success, the unsigned-corim-map is made available via the UnsignedCorim
 // field while the corim-meta-map is decoded into the Meta field.
+//
+// Messages with a detached payload, or hash envelopes, do not embed the
+// unsigned-corim: use [SignedCorim.FromCOSEDetached] for those.
 func (o *SignedCorim) FromCOSE(buf []byte) error {
+	return o.FromCOSEDetached(buf, nil)
+}
+
+// FromCOSEDetached is like [SignedCorim.FromCOSE], but takes the
+// unsigned-corim separately. The supplied payload is the CBOR-encoded
+// unsigned-corim, also when the message is a hash envelope, in which case its
+// hash must match the one carried (or, if detached, signed) by the message.
+// The payload may be nil if the message embeds the unsigned-corim.
+//
+// On success, the Detached and HashEnvelope fields reflect the mode of the
+// decoded message, and the message can be verified as if the payload were
+// embedded.
+func (o *SignedCorim) FromCOSEDetached(buf []byte, payload []byte) error {
 	o.message = cose.NewSign1Message()
+	o.CWTClaims = nil
 	o.SigningCert = nil
 	o.IntermediateCerts = nil
+	o.Detached = false
+	o.HashEnvelope = nil
+	o.OCSPResponses = nil
+	o.TimestampToken = nil
 
 	var err error
 	// Roll back partial decode on any failure. Later steps must assign to err (not :=)
@@ -229,8 +388,13 @@ func (o *SignedCorim) FromCOSE(buf []byte) error {
 	defer func() {
 		if err != nil {
 			o.message = nil
+			o.CWTClaims = nil
 			o.SigningCert = nil
 			o.IntermediateCerts = nil
+			o.Detached = false
+			o.HashEnvelope = nil
+			o.OCSPResponses = nil
+			o.TimestampToken = nil
 		}
 	}()
 
@@ -248,7 +412,12 @@ func (o *SignedCorim) FromCOSE(buf []byte) error {
 		return fmt.Errorf("processing COSE headers: %w", err)
 	}
 
-	if err = o.UnsignedCorim.FromCBOR(o.message.Payload); err != nil {
+	var preimage []byte
+	if preimage, err = o.attachPayload(payload); err != nil {
+		return err
+	}
+
+	if err = o.UnsignedCorim.FromCBOR(preimage); err != nil {
 		return fmt.Errorf("failed CBOR decoding of unsigned CoRIM: %w", err)
 	}
 
@@ -259,16 +428,49 @@ func (o *SignedCorim) FromCOSE(buf []byte) error {
 	return nil
 }
 
+// attachPayload reconciles the supplied payload with the decoded message. On
+// success the message payload is populated (so that the signature can be
+// verified), and the CBOR-encoded unsigned-corim is returned.
+func (o *SignedCorim) attachPayload(payload []byte) ([]byte, error) {
+	o.Detached = o.message.Payload == nil
+
+	if o.HashEnvelope == nil {
+		switch {
+		case o.Detached && payload == nil:
+			return nil, errNoPayload
+		case o.Detached:
+			o.message.Payload = payload
+		case payload != nil && !bytes.Equal(payload, o.message.Payload):
+			return nil, errors.New("supplied payload does not match the embedded payload")
+		}
+
+		return o.message.Payload, nil
+	}
+
+	if payload == nil {
+		return nil, errors.New("hash envelope: preimage not supplied")
+	}
+
+	digest, err := o.HashEnvelope.Digest(payload)
+	if err != nil {
+		return nil, fmt.Errorf("hash envelope: %w", err)
+	}
+
+	if o.Detached {
+		o.message.Payload = digest
+	} else if !bytes.Equal(digest, o.message.Payload) {
+		return nil, errors.New("hash envelope: preimage does not match payload hash")
+	}
+
+	return payload, nil
+}
+
 // AddSigningCert adds a DER-encoded X.509 certificate to be included in the
 // protected header of the COSE Sign1 message as the leaf certificate in X5Chain.
 func (o *SignedCorim) AddSigningCert(der []byte) error {
-	if der == nil {
-		return errors.New("nil signing cert")
-	}
-
-	cert, err := x509.ParseCertificate(der)
+	cert, err := parseSigningCert(der)
 	if err != nil {
-		return fmt.Errorf("invalid signing certificate: %w", err)
+		return err
 	}
 
 	o.SigningCert = cert
@@ -279,25 +481,51 @@ func (o *SignedCorim) AddSigningCert(der []byte) error {
 // header of the COSE Sign1 message as part of the X5Chain.
 // The certificates must be concatenated with no intermediate padding, as per X.509 convention.
 func (o *SignedCorim) AddIntermediateCerts(der []byte) error {
+	certs, err := parseIntermediateCerts(der)
+	if err != nil {
+		return err
+	}
+
+	o.IntermediateCerts = certs
+	return nil
+}
+
+func parseSigningCert(der []byte) (*x509.Certificate, error) {
+	if der == nil {
+		return nil, errors.New("nil signing cert")
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing certificate: %w", err)
+	}
+
+	return cert, nil
+}
+
+func parseIntermediateCerts(der []byte) ([]*x509.Certificate, error) {
 	if len(der) == 0 {
-		return errors.New("nil or empty intermediate certs")
+		return nil, errors.New("nil or empty intermediate certs")
 	}
 
 	certs, err := x509.ParseCertificates(der)
 	if err != nil {
-		return fmt.Errorf("invalid intermediate certificates: %w", err)
+		return nil, fmt.Errorf("invalid intermediate certificates: %w", err)
 	}
 
 	if len(certs) == 0 {
-		return errors.New("no certificates found in intermediate cert data")
+		return nil, errors.New("no certificates found in intermediate cert data")
 	}
 
-	o.IntermediateCerts = certs
-	return nil
+	return certs, nil
 }
 
 // Sign returns the serialized signed-corim, signed by the supplied cose Signer.
 // The target SignedCorim must have its UnsignedCorim field correctly populated.
+//
+// If Detached is set, the payload is omitted from the returned message and
+// must be supplied to the verifier out of band: this is the unsigned-corim,
+// which can be obtained with [UnsignedCorim.ToCBOR].
 func (o *SignedCorim) Sign(signer cose.Signer) ([]byte, error) {
 	if signer == nil {
 		return nil, errors.New("nil signer")
@@ -308,6 +536,8 @@ func (o *SignedCorim) Sign(signer cose.Signer) ([]byte, error) {
 	}
 
 	o.message = cose.NewSign1Message()
+	// a timestamp over a previous signature does not apply to the new one
+	o.TimestampToken = nil
 
 	var err error
 	o.message.Payload, err = o.UnsignedCorim.ToCBOR()
@@ -315,9 +545,25 @@ func (o *SignedCorim) Sign(signer cose.Signer) ([]byte, error) {
 		return nil, fmt.Errorf("failed CBOR encoding of unsigned CoRIM: %w", err)
 	}
 
-	metaCBOR, err := o.Meta.ToCBOR()
-	if err != nil {
-		return nil, fmt.Errorf("failed CBOR encoding of CoRIM Meta: %w", err)
+	if o.HashEnvelope != nil {
+		o.message.Payload, err = o.HashEnvelope.Digest(o.message.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("hash envelope: %w", err)
+		}
+	}
+
+	var metaCBOR []byte
+	if o.CWTClaims == nil || !o.Meta.isEmpty() {
+		metaCBOR, err = o.Meta.ToCBOR()
+		if err != nil {
+			return nil, fmt.Errorf("failed CBOR encoding of CoRIM Meta: %w", err)
+		}
+	}
+
+	if o.CWTClaims != nil {
+		if err = o.CWTClaims.Valid(); err != nil {
+			return nil, fmt.Errorf("invalid CWT claims: %w", err)
+		}
 	}
 
 	alg := signer.Algorithm()
@@ -328,35 +574,52 @@ func (o *SignedCorim) Sign(signer cose.Signer) ([]byte, error) {
 
 	o.message.Headers.Protected.SetAlgorithm(alg)
 	o.message.Headers.Protected[cose.HeaderLabelContentType] = ContentType
-	o.message.Headers.Protected[HeaderLabelCorimMeta] = metaCBOR
+	if metaCBOR != nil {
+		o.message.Headers.Protected[HeaderLabelCorimMeta] = metaCBOR
+	}
+
+	if o.CWTClaims != nil {
+		o.message.Headers.Protected[cose.HeaderLabelCWTClaims] = o.CWTClaims.toWire()
+	}
+
+	if o.HashEnvelope != nil {
+		o.HashEnvelope.setHeaders(o.message.Headers.Protected)
+	}
 
 	if o.KeyID != nil {
 		o.message.Headers.Protected[cose.HeaderLabelKeyID] = o.KeyID
 	}
 
 	if o.SigningCert != nil {
-		// COSE_X509 = bstr / [ 2*certs: bstr ]
-		//
-		// handle alt (1): bstr
-		if len(o.IntermediateCerts) == 0 {
-			o.message.Headers.Protected[cose.HeaderLabelX5Chain] = o.SigningCert.Raw
-		} else { // handle alt (2): [ 2*certs: bstr ]
-			certChain := [][]byte{o.SigningCert.Raw}
-			for _, cert := range o.IntermediateCerts {
-				certChain = append(certChain, cert.Raw)
-			}
-			o.message.Headers.Protected[cose.HeaderLabelX5Chain] = certChain
-		}
+		o.message.Headers.Protected[cose.HeaderLabelX5Chain] = EncodeX5ChainHeader(o.SigningCert, o.IntermediateCerts)
 	} else if o.IntermediateCerts != nil {
 		return nil, errors.New("intermediate certificates supplied but no signing certificate")
 	}
 
+	if len(o.OCSPResponses) > 0 {
+		o.message.Headers.Unprotected[HeaderLabelOCSPResponses] = o.OCSPResponses
+	}
+
 	err = o.message.Sign(rand.Reader, NoExternalData, signer)
 	if err != nil {
 		return nil, fmt.Errorf("COSE Sign1 signature failed: %w", err)
 	}
 
-	wrap, err := o.message.MarshalCBOR()
+	return o.marshalMessage()
+}
+
+// marshalMessage serializes the signed COSE Sign1 message, leaving out the
+// payload if detached
+func (o *SignedCorim) marshalMessage() ([]byte, error) {
+	msg := o.message
+	if o.Detached {
+		// the payload is kept in the message so that it can still be verified
+		detached := *o.message
+		detached.Payload = nil
+		msg = &detached
+	}
+
+	wrap, err := msg.MarshalCBOR()
 	if err != nil {
 		return nil, fmt.Errorf("signed-corim marshaling failed: %w", err)
 	}
@@ -394,10 +657,18 @@ func (o *SignedCorim) Verify(pk crypto.PublicKey) error {
 // VerifyWithX5Chain validates the embedded x5chain and CoRIM COSE signature.
 // Call [SignedCorim.FromCOSE] first. For external-key verify without PKIX, use [SignedCorim.Verify].
 // Load trust material via [LoadTrustAnchors] when reading anchors/CRLs from files.
+// OCSP responses stapled to the signed-corim are checked together with those
+// in the trust anchors (see [OcspPolicy]).
 //
 // Leaf policy rejects CA certificates. keyUsage is optional; when present,
 // digitalSignature is required. PKIX validation uses ExtKeyUsageAny.
 func (o *SignedCorim) VerifyWithX5Chain(anchors TrustAnchors) error {
+	return o.verifyWithX5Chain(anchors, time.Time{})
+}
+
+// verifyWithX5Chain is [SignedCorim.VerifyWithX5Chain] for a signature made at
+// signingTime (see [verifyX5Chain]).
+func (o *SignedCorim) verifyWithX5Chain(anchors TrustAnchors, signingTime time.Time) error {
 	if o.message == nil {
 		return errNoSign1Message
 	}
@@ -406,31 +677,79 @@ func (o *SignedCorim) VerifyWithX5Chain(anchors TrustAnchors) error {
 		return errors.New("x5chain: header not set in CoRIM")
 	}
 
-	chain := make([]*x509.Certificate, 0, 1+len(o.IntermediateCerts))
-	chain = append(chain, o.SigningCert)
-	chain = append(chain, o.IntermediateCerts...)
+	pk, err := verifyX5Chain(o.SigningCert, o.IntermediateCerts, anchors, o.OCSPResponses, signingTime)
+	if err != nil {
+		return err
+	}
+
+	if err := o.Verify(pk); err != nil {
+		return fmt.Errorf("x5chain: COSE signature verification failed: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyX5Chain validates the supplied x5chain against the trust anchors, and
+// returns the public key of the signing certificate. The stapled OCSP
+// responses are used in addition to those in the trust anchors. The same
+// policies as [SignedCorim.VerifyWithX5Chain] apply, so that other COSE
+// signed objects (e.g., signed evidence) can be checked against the same trust
+// anchors.
+func VerifyX5Chain(
+	signingCert *x509.Certificate,
+	intermediateCerts []*x509.Certificate,
+	anchors TrustAnchors,
+	stapled [][]byte,
+) (crypto.PublicKey, error) {
+	return verifyX5Chain(signingCert, intermediateCerts, anchors, stapled, time.Time{})
+}
+
+// verifyX5Chain implements [VerifyX5Chain]. When signingTime is set, the PKIX
+// path is validated at signingTime, while revocation information is checked
+// at the current time: only the revocations that predate signingTime, or that
+// are due to a key compromise, are fatal.
+func verifyX5Chain(
+	signingCert *x509.Certificate,
+	intermediateCerts []*x509.Certificate,
+	anchors TrustAnchors,
+	stapled [][]byte,
+	signingTime time.Time,
+) (crypto.PublicKey, error) {
+	chain := make([]*x509.Certificate, 0, 1+len(intermediateCerts))
+	chain = append(chain, signingCert)
+	chain = append(chain, intermediateCerts...)
 
 	now := anchors.CurrentTime
 	if now.IsZero() {
 		now = time.Now()
 	}
 
-	if err := validateLeafSigningCert(o.SigningCert); err != nil {
-		return err
+	pathTime := now
+	if !signingTime.IsZero() {
+		pathTime = signingTime
 	}
 
-	verifiedChain, err := verifyPKIXChain(chain, anchors, now)
+	if err := validateLeafSigningCert(signingCert); err != nil {
+		return nil, err
+	}
+
+	verifiedChain, err := verifyPKIXChain(chain, anchors, pathTime)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := checkChainRevocation(verifiedChain, anchors.CRLs, anchors.CrlPolicy, now); err != nil {
-		return err
+	responses := append(slices.Clip(anchors.OCSPResponses), stapled...)
+
+	ocspGood, err := checkChainOCSP(verifiedChain, responses, anchors.OcspPolicy, now, signingTime)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := o.Verify(verifiedChain[0].PublicKey); err != nil {
-		return fmt.Errorf("x5chain: COSE signature verification failed: %w", err)
+	if err := checkChainRevocation(
+		verifiedChain, anchors.CRLs, anchors.CrlPolicy, now, signingTime, ocspGood,
+	); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return verifiedChain[0].PublicKey, nil
 }
@@ -849,3 +849,68 @@ func TestSignedCorim_extractX5Chain_rejectsMultipleCertsPerElement(t *testing.T)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "expected 1 certificate at index 1, got 2")
 }
+
+func TestSignedCorim_SignVerify_detached_ok(t *testing.T) {
+	signer, err := NewSignerFromJWK(testES256Key)
+	require.NoError(t, err)
+
+	in := SignedCorim{
+		UnsignedCorim: *unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR),
+		Meta:          *metaGood(t),
+		Detached:      true,
+	}
+
+	cbor, err := in.Sign(signer)
+	require.NoError(t, err)
+
+	// the message can still be verified by the signer
+	pk, err := NewPublicKeyFromJWK(testES256Key)
+	require.NoError(t, err)
+	assert.NoError(t, in.Verify(pk))
+
+	payload, err := in.UnsignedCorim.ToCBOR()
+	require.NoError(t, err)
+	assert.NotContains(t, string(cbor), string(payload))
+
+	var out SignedCorim
+
+	err = out.FromCOSE(cbor)
+	assert.EqualError(t, err, "detached payload not supplied")
+
+	require.NoError(t, out.FromCOSEDetached(cbor, payload))
+	assert.True(t, out.Detached)
+	assert.Nil(t, out.HashEnvelope)
+	assert.Equal(t, "test corim id", out.UnsignedCorim.GetID())
+	assert.NoError(t, out.Verify(pk))
+
+	// a different payload does not verify
+	other := unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR)
+	other.SetID("other corim id")
+	otherPayload, err := other.ToCBOR()
+	require.NoError(t, err)
+
+	require.NoError(t, out.FromCOSEDetached(cbor, otherPayload))
+	assert.EqualError(t, out.Verify(pk), "verification error")
+}
+
+func TestSignedCorim_FromCOSEDetached_embedded(t *testing.T) {
+	signer, err := NewSignerFromJWK(testES256Key)
+	require.NoError(t, err)
+
+	in := SignedCorim{UnsignedCorim: *unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR)}
+
+	cbor, err := in.Sign(signer)
+	require.NoError(t, err)
+
+	payload, err := in.UnsignedCorim.ToCBOR()
+	require.NoError(t, err)
+
+	var out SignedCorim
+
+	require.NoError(t, out.FromCOSEDetached(cbor, payload))
+	assert.False(t, out.Detached)
+
+	err = out.FromCOSEDetached(cbor, testGoodUnsignedCorimCBOR[1:])
+	assert.EqualError(t, err, "supplied payload does not match the embedded payload")
+	assert.False(t, out.Detached)
+}
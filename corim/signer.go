@@ -8,6 +8,8 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"reflect"
@@ -145,7 +147,10 @@ func getAlgAndKeyFromJWK(j []byte) (cose.Algorithm, crypto.Signer, error) {
 	case *rsa.PrivateKey:
 		alg = rsaJWKToAlg(k)
 		if alg == noAlg {
-			return noAlg, nil, fmt.Errorf("unknown RSA algorithm %q", k.Algorithm().String())
+			if k.Algorithm().String() != "" {
+				return noAlg, nil, fmt.Errorf("unknown RSA algorithm %q", k.Algorithm().String())
+			}
+			alg = rsaKeyToPSSAlg(&v.PublicKey)
 		}
 	default:
 		return noAlg, nil, fmt.Errorf("unknown private key type %v", reflect.TypeOf(key))
@@ -180,6 +185,224 @@ func rsaJWKToAlg(k jwk.Key) cose.Algorithm {
 	}
 }
 
+// rsaKeyToPSSAlg selects the RSASSA-PSS algorithm whose hash strength
+// matches the size of the supplied RSA key
+func rsaKeyToPSSAlg(k *rsa.PublicKey) cose.Algorithm {
+	switch bits := k.N.BitLen(); {
+	case bits >= 4096:
+		return cose.AlgorithmPS512
+	case bits >= 3072:
+		return cose.AlgorithmPS384
+	default:
+		return cose.AlgorithmPS256
+	}
+}
+
+// algorithmForPublicKey returns the default signature algorithm for the
+// supplied public key: ES256, ES384 or ES512 depending on the curve for EC
+// keys, EdDSA for Ed25519 keys, and RSASSA-PSS depending on the key size for
+// RSA keys
+func algorithmForPublicKey(pk crypto.PublicKey) (cose.Algorithm, error) {
+	switch v := pk.(type) {
+	case *ecdsa.PublicKey:
+		alg := ellipticCurveToAlg(v.Curve)
+		if alg == noAlg {
+			return noAlg, fmt.Errorf("unknown elliptic curve %v", v.Curve.Params().Name)
+		}
+		return alg, nil
+	case ed25519.PublicKey:
+		return cose.AlgorithmEdDSA, nil
+	case *rsa.PublicKey:
+		return rsaKeyToPSSAlg(v), nil
+	default:
+		return noAlg, fmt.Errorf("unknown public key type %v", reflect.TypeOf(pk))
+	}
+}
+
+// selectAlgorithm returns alg if it is set and can be used with the supplied
+// public key, or the default algorithm for the key if alg is zero
+func selectAlgorithm(pk crypto.PublicKey, alg cose.Algorithm) (cose.Algorithm, error) {
+	if alg == 0 {
+		return algorithmForPublicKey(pk)
+	}
+
+	if err := (VerificationKey{Key: pk}).checkAlg(alg); err != nil {
+		return noAlg, err
+	}
+
+	return alg, nil
+}
+
+// NewSignerFromCryptoSigner returns a cose.Signer using the supplied
+// crypto.Signer, which can be an in-memory private key or, e.g., a handle to
+// a key held in an HSM. If alg is zero, the algorithm is selected from the
+// key type (see NewSignerFromJWK).
+func NewSignerFromCryptoSigner(key crypto.Signer, alg cose.Algorithm) (cose.Signer, error) {
+	if key == nil {
+		return nil, errors.New("nil crypto.Signer")
+	}
+
+	alg, err := selectAlgorithm(key.Public(), alg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cose.NewSigner(alg, key)
+}
+
+// NewSignerFromPEM returns a cose.Signer using the private key in the
+// supplied PEM data, which can be a PKCS#8 ("PRIVATE KEY"), SEC1 ("EC PRIVATE
+// KEY") or PKCS#1 ("RSA PRIVATE KEY") block. If alg is zero, the algorithm is
+// selected from the key type (see NewSignerFromJWK).
+func NewSignerFromPEM(data []byte, alg cose.Algorithm) (cose.Signer, error) {
+	key, err := privateKeyFromPEM(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSignerFromCryptoSigner(key, alg)
+}
+
+// NewSignerFromDER is like NewSignerFromPEM, but takes a DER-encoded PKCS#8,
+// SEC1 or PKCS#1 private key
+func NewSignerFromDER(der []byte, alg cose.Algorithm) (cose.Signer, error) {
+	key, err := privateKeyFromDER(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSignerFromCryptoSigner(key, alg)
+}
+
+// NewPublicKeyFromPEM returns the public key in the supplied PEM data, which
+// can be a PKIX ("PUBLIC KEY"), PKCS#1 ("RSA PUBLIC KEY") or certificate
+// ("CERTIFICATE") block, or any of the private key blocks accepted by
+// NewSignerFromPEM
+func NewPublicKeyFromPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	}
+
+	key, err := privateKeyFromPEMBlock(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.Public(), nil
+}
+
+// NewPublicKeyFromDER is like NewPublicKeyFromPEM, but takes a DER-encoded
+// PKIX public key, certificate, or private key
+func NewPublicKeyFromDER(der []byte) (crypto.PublicKey, error) {
+	if pk, err := x509.ParsePKIXPublicKey(der); err == nil {
+		return pk, nil
+	}
+
+	if cert, err := x509.ParseCertificate(der); err == nil {
+		return cert.PublicKey, nil
+	}
+
+	if pk, err := x509.ParsePKCS1PublicKey(der); err == nil {
+		return pk, nil
+	}
+
+	key, err := privateKeyFromDER(der)
+	if err != nil {
+		return nil, errors.New("no public key, certificate or private key found in DER data")
+	}
+
+	return key.Public(), nil
+}
+
+// NewVerifierFromPublicKey returns a cose.Verifier for the supplied public key
+// (e.g., to be used with Coserv.Verify). If alg is zero, the algorithm is
+// selected from the key type (see NewSignerFromJWK).
+func NewVerifierFromPublicKey(pk crypto.PublicKey, alg cose.Algorithm) (cose.Verifier, error) {
+	alg, err := selectAlgorithm(pk, alg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cose.NewVerifier(alg, pk)
+}
+
+func privateKeyFromPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	return privateKeyFromPEMBlock(block)
+}
+
+func privateKeyFromPEMBlock(block *pem.Block) (crypto.Signer, error) {
+	var (
+		key interface{}
+		err error
+	)
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", block.Type, err)
+	}
+
+	return toCryptoSigner(key)
+}
+
+func privateKeyFromDER(der []byte) (crypto.Signer, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return toCryptoSigner(key)
+	}
+
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+
+	return nil, errors.New("no PKCS#8, SEC1 or PKCS#1 private key found in DER data")
+}
+
+func toCryptoSigner(key interface{}) (crypto.Signer, error) {
+	switch v := key.(type) {
+	case *ecdsa.PrivateKey, ed25519.PrivateKey, *rsa.PrivateKey:
+		return v.(crypto.Signer), nil
+	default:
+		return nil, fmt.Errorf("unknown private key type %v", reflect.TypeOf(key))
+	}
+}
+
+// NewSignerFromJWK returns a cose.Signer using the private key in the
+// supplied JWK. The algorithm is ES256, ES384 or ES512 depending on the curve
+// for EC keys, and EdDSA for OKP (Ed25519) keys. RSA keys use the RSASSA-PSS
+// algorithm in their alg parameter or, if there is none, the one matching
+// their size (PS256 up to 3071 bits, PS384 up to 4095 bits, PS512 otherwise).
 func NewSignerFromJWK(j []byte) (cose.Signer, error) {
 	alg, key, err := getAlgAndKeyFromJWK(j)
 	if err != nil {
@@ -189,11 +412,22 @@ func NewSignerFromJWK(j []byte) (cose.Signer, error) {
 	return cose.NewSigner(alg, key)
 }
 
+// NewPublicKeyFromJWK returns the public key of the supplied JWK, which can
+// be either a public or a private key
 func NewPublicKeyFromJWK(j []byte) (crypto.PublicKey, error) {
-	_, key, err := getAlgAndKeyFromJWK(j)
+	k, err := jwk.ParseKey(j)
 	if err != nil {
 		return nil, err
 	}
 
-	return key.Public(), nil
+	pk, err := jwk.PublicRawKeyOf(k)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := algorithmForPublicKey(pk); err != nil {
+		return nil, err
+	}
+
+	return pk, nil
 }
@@ -3,11 +3,23 @@
 package corim
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/veraison/corim/comid"
 	"github.com/veraison/corim/extensions"
+	cose "github.com/veraison/go-cose"
 )
 
 type signerExtensions struct {
@@ -59,3 +71,220 @@ func TestSigner_JSON(t *testing.T) {
 	assert.Equal(t, signer.Name, other.Name)
 	assert.Equal(t, signer.URI, other.URI)
 }
+
+func signAndVerifyWith(t *testing.T, signer cose.Signer, pk crypto.PublicKey) {
+	t.Helper()
+
+	in := SignedCorim{UnsignedCorim: *unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR)}
+
+	data, err := in.Sign(signer)
+	require.NoError(t, err)
+
+	var out SignedCorim
+	require.NoError(t, out.FromCOSE(data))
+	assert.NoError(t, out.Verify(pk))
+}
+
+func pemEncode(typ string, der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der})
+}
+
+func TestNewSignerFromPEM_Ed25519(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+
+	signer, err := NewSignerFromPEM(pemEncode("PRIVATE KEY", der), 0)
+	require.NoError(t, err)
+	assert.Equal(t, cose.AlgorithmEdDSA, signer.Algorithm())
+
+	spki, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+
+	pk, err := NewPublicKeyFromPEM(pemEncode("PUBLIC KEY", spki))
+	require.NoError(t, err)
+	assert.Equal(t, pub, pk)
+
+	signAndVerifyWith(t, signer, pk)
+
+	signer, err = NewSignerFromDER(der, cose.AlgorithmEdDSA)
+	require.NoError(t, err)
+
+	pk, err = NewPublicKeyFromDER(spki)
+	require.NoError(t, err)
+
+	signAndVerifyWith(t, signer, pk)
+
+	_, err = NewSignerFromDER(der, cose.AlgorithmES256)
+	assert.ErrorIs(t, err, ErrKeyAlgMismatch)
+}
+
+func TestNewSignerFromPEM_EC_SEC1(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	data := pemEncode("EC PRIVATE KEY", der)
+
+	signer, err := NewSignerFromPEM(data, 0)
+	require.NoError(t, err)
+	assert.Equal(t, cose.AlgorithmES384, signer.Algorithm())
+
+	// the public key can be extracted from the private key
+	pk, err := NewPublicKeyFromPEM(data)
+	require.NoError(t, err)
+
+	signAndVerifyWith(t, signer, pk)
+
+	signer, err = NewSignerFromDER(der, 0)
+	require.NoError(t, err)
+
+	pk, err = NewPublicKeyFromDER(der)
+	require.NoError(t, err)
+
+	signAndVerifyWith(t, signer, pk)
+}
+
+func TestNewSignerFromPEM_RSA_PKCS1(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	data := pemEncode("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key))
+
+	signer, err := NewSignerFromPEM(data, 0)
+	require.NoError(t, err)
+	assert.Equal(t, cose.AlgorithmPS256, signer.Algorithm())
+
+	signer, err = NewSignerFromPEM(data, cose.AlgorithmPS512)
+	require.NoError(t, err)
+	assert.Equal(t, cose.AlgorithmPS512, signer.Algorithm())
+
+	pk, err := NewPublicKeyFromPEM(pemEncode("RSA PUBLIC KEY", x509.MarshalPKCS1PublicKey(&key.PublicKey)))
+	require.NoError(t, err)
+
+	signAndVerifyWith(t, signer, pk)
+
+	_, err = NewSignerFromPEM(data, cose.AlgorithmEdDSA)
+	assert.EqualError(t, err, "key algorithm mismatch: *rsa.PublicKey key cannot be used with EdDSA")
+}
+
+func TestNewPublicKeyFromPEM_certificate(t *testing.T) {
+	pki := buildTestPKI(t)
+
+	pk, err := NewPublicKeyFromPEM(pemEncode("CERTIFICATE", pki.leafDER))
+	require.NoError(t, err)
+	assert.True(t, pki.leafKey.PublicKey.Equal(pk))
+
+	pk, err = NewPublicKeyFromDER(pki.leafDER)
+	require.NoError(t, err)
+	assert.True(t, pki.leafKey.PublicKey.Equal(pk))
+}
+
+func TestNewSignerFromPEM_NOK(t *testing.T) {
+	_, err := NewSignerFromPEM([]byte("not PEM"), 0)
+	assert.EqualError(t, err, "no PEM block found")
+
+	_, err = NewSignerFromPEM(pemEncode("PUBLIC KEY", []byte{0x30}), 0)
+	assert.EqualError(t, err, `unsupported PEM block type "PUBLIC KEY"`)
+
+	_, err = NewSignerFromPEM(pemEncode("EC PRIVATE KEY", []byte{0x30}), 0)
+	assert.ErrorContains(t, err, "parsing EC PRIVATE KEY")
+
+	_, err = NewSignerFromDER([]byte{0x30}, 0)
+	assert.EqualError(t, err, "no PKCS#8, SEC1 or PKCS#1 private key found in DER data")
+
+	_, err = NewPublicKeyFromPEM([]byte("not PEM"))
+	assert.EqualError(t, err, "no PEM block found")
+
+	_, err = NewPublicKeyFromDER([]byte{0x30})
+	assert.EqualError(t, err, "no public key, certificate or private key found in DER data")
+}
+
+// hsmSigner hides the concrete type of the private key, like a crypto.Signer
+// backed by an HSM would
+type hsmSigner struct {
+	crypto.Signer
+}
+
+func TestNewSignerFromCryptoSigner(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := NewSignerFromCryptoSigner(hsmSigner{key}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, cose.AlgorithmES256, signer.Algorithm())
+
+	signAndVerifyWith(t, signer, key.Public())
+
+	_, err = NewSignerFromCryptoSigner(nil, 0)
+	assert.EqualError(t, err, "nil crypto.Signer")
+
+	_, err = NewSignerFromCryptoSigner(hsmSigner{key}, cose.AlgorithmES512)
+	assert.ErrorIs(t, err, ErrKeyAlgMismatch)
+
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	require.NoError(t, err)
+
+	_, err = NewSignerFromCryptoSigner(p224, 0)
+	assert.EqualError(t, err, "unknown elliptic curve P-224")
+}
+
+func TestRsaKeyToPSSAlg(t *testing.T) {
+	for _, tv := range []struct {
+		bits int
+		alg  cose.Algorithm
+	}{
+		{2048, cose.AlgorithmPS256},
+		{3072, cose.AlgorithmPS384},
+		{4096, cose.AlgorithmPS512},
+	} {
+		n := new(big.Int).Lsh(big.NewInt(1), uint(tv.bits-1))
+		assert.Equal(t, tv.alg, rsaKeyToPSSAlg(&rsa.PublicKey{N: n, E: 65537}))
+	}
+}
+
+func TestNewSignerFromJWK_RSA_without_alg(t *testing.T) {
+	var k map[string]interface{}
+	require.NoError(t, json.Unmarshal(testPS384Key, &k))
+	delete(k, "alg")
+
+	j, err := json.Marshal(k)
+	require.NoError(t, err)
+
+	signer, err := NewSignerFromJWK(j)
+	require.NoError(t, err)
+
+	pk, err := NewPublicKeyFromJWK(j)
+	require.NoError(t, err)
+	assert.Equal(t, rsaKeyToPSSAlg(pk.(*rsa.PublicKey)), signer.Algorithm())
+
+	signAndVerifyWith(t, signer, pk)
+}
+
+func TestNewPublicKeyFromJWK_public(t *testing.T) {
+	var k map[string]interface{}
+	require.NoError(t, json.Unmarshal(testEdDSAKey, &k))
+	delete(k, "d")
+
+	j, err := json.Marshal(k)
+	require.NoError(t, err)
+
+	pk, err := NewPublicKeyFromJWK(j)
+	require.NoError(t, err)
+
+	signer, err := NewSignerFromJWK(testEdDSAKey)
+	require.NoError(t, err)
+
+	signAndVerifyWith(t, signer, pk)
+
+	verifier, err := NewVerifierFromPublicKey(pk, 0)
+	require.NoError(t, err)
+	assert.Equal(t, cose.AlgorithmEdDSA, verifier.Algorithm())
+
+	_, err = NewVerifierFromPublicKey(pk, cose.AlgorithmES256)
+	assert.ErrorIs(t, err, ErrKeyAlgMismatch)
+}
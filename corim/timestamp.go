@@ -0,0 +1,444 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// HeaderLabelTimestampToken is the (private use) label of the unprotected
+// header parameter carrying an RFC 3161 timestamp token over the signature of
+// a signed-corim, in the spirit of the "3161-ttc" header parameter of
+// draft-ietf-cose-tsa-tst-header-parameter. Its value is the DER-encoded
+// TimeStampToken.
+var HeaderLabelTimestampToken = int64(-70002)
+
+// Timestamper obtains RFC 3161 timestamp tokens from a time-stamping
+// authority (TSA), e.g., using the HTTP transport in RFC 3161, Section 3.4.
+type Timestamper interface {
+	// Timestamp returns the DER-encoded TimeStampToken for the supplied
+	// digest, computed with the supplied hash function
+	Timestamp(digest []byte, hash crypto.Hash) ([]byte, error)
+}
+
+var (
+	oidSignedData    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidTSTInfo       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 1, 4}
+	oidContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidRSASSAPSS     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}
+
+	oidHashes = map[crypto.Hash]asn1.ObjectIdentifier{
+		crypto.SHA256: {2, 16, 840, 1, 101, 3, 4, 2, 1},
+		crypto.SHA384: {2, 16, 840, 1, 101, 3, 4, 2, 2},
+		crypto.SHA512: {2, 16, 840, 1, 101, 3, 4, 2, 3},
+	}
+)
+
+// cmsContentInfo is the CMS ContentInfo (RFC 5652, Section 3)
+type cmsContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	// [0] EXPLICIT, which encoding/asn1 does not apply to RawValues
+	Content asn1.RawValue
+}
+
+// cmsSignedData is the CMS SignedData (RFC 5652, Section 5.1)
+type cmsSignedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	EncapContentInfo cmsEncapContentInfo
+	Certificates     asn1.RawValue   `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue   `asn1:"optional,tag:1"`
+	SignerInfos      []cmsSignerInfo `asn1:"set"`
+}
+
+type cmsEncapContentInfo struct {
+	EContentType asn1.ObjectIdentifier
+	EContent     []byte `asn1:"explicit,optional,tag:0"`
+}
+
+// cmsSignerInfo is the CMS SignerInfo (RFC 5652, Section 5.3)
+type cmsSignerInfo struct {
+	Version            int
+	SID                asn1.RawValue
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttrs        asn1.RawValue `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+	UnsignedAttrs      asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type cmsIssuerAndSerialNumber struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type cmsAttribute struct {
+	Type   asn1.ObjectIdentifier
+	Values []asn1.RawValue `asn1:"set"`
+}
+
+// tstInfo is the RFC 3161 TSTInfo (Section 2.4.2)
+type tstInfo struct {
+	Version        int
+	Policy         asn1.ObjectIdentifier
+	MessageImprint tstMessageImprint
+	SerialNumber   *big.Int
+	GenTime        time.Time     `asn1:"generalized"`
+	Accuracy       tstAccuracy   `asn1:"optional"`
+	Ordering       bool          `asn1:"optional"`
+	Nonce          *big.Int      `asn1:"optional"`
+	TSA            asn1.RawValue `asn1:"optional,tag:0"`
+	Extensions     asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type tstMessageImprint struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	HashedMessage []byte
+}
+
+type tstAccuracy struct {
+	Seconds int `asn1:"optional"`
+	Millis  int `asn1:"optional,tag:0"`
+	Micros  int `asn1:"optional,tag:1"`
+}
+
+// Timestamp is a verified RFC 3161 timestamp
+type Timestamp struct {
+	// GenTime is the time at which the TSA attests the signature existed
+	GenTime time.Time
+	// TSACert is the certificate of the TSA that issued the timestamp
+	TSACert *x509.Certificate
+}
+
+// AddTimestamp obtains a timestamp token over the signature of the target
+// SignedCorim from the supplied Timestamper, using the supplied hash function
+// for the message imprint. The token is stored in TimestampToken and carried
+// in the unprotected header. AddTimestamp must be called after
+// [SignedCorim.Sign] (or [SignedCorim.FromCOSE]) and returns the re-encoded
+// signed-corim.
+func (o *SignedCorim) AddTimestamp(tsa Timestamper, hash crypto.Hash) ([]byte, error) {
+	if o.message == nil || len(o.message.Signature) == 0 {
+		return nil, errNoSign1Message
+	}
+
+	if tsa == nil {
+		return nil, errors.New("nil timestamper")
+	}
+
+	if _, ok := oidHashes[hash]; !ok {
+		return nil, fmt.Errorf("unsupported timestamp hash function %s", hash)
+	}
+
+	h := hash.New()
+	h.Write(o.message.Signature)
+
+	token, err := tsa.Timestamp(h.Sum(nil), hash)
+	if err != nil {
+		return nil, fmt.Errorf("obtaining timestamp token: %w", err)
+	}
+
+	o.TimestampToken = token
+	o.message.Headers.Unprotected[HeaderLabelTimestampToken] = token
+
+	return o.marshalMessage()
+}
+
+// VerifyTimestamp verifies the timestamp token of the target SignedCorim: the
+// token must be signed by a TSA certificate chaining to tsaRoots and valid at
+// the attested time, and its message imprint must match the signature of the
+// target SignedCorim. If tsaRoots is nil, the OS trust store is used.
+//
+// Note that this does not verify the signature of the signed-corim itself.
+func (o *SignedCorim) VerifyTimestamp(tsaRoots *x509.CertPool) (*Timestamp, error) {
+	if o.message == nil {
+		return nil, errNoSign1Message
+	}
+
+	if o.TimestampToken == nil {
+		return nil, errors.New("timestamp: token not set in CoRIM")
+	}
+
+	if tsaRoots == nil {
+		var err error
+		if tsaRoots, err = newSystemCertPool(); err != nil {
+			return nil, fmt.Errorf("timestamp: %w", err)
+		}
+	}
+
+	ts, err := verifyTimestampToken(o.TimestampToken, o.message.Signature, tsaRoots)
+	if err != nil {
+		return nil, fmt.Errorf("timestamp: %w", err)
+	}
+
+	return ts, nil
+}
+
+// VerifyWithX5ChainAtTimestamp is like [SignedCorim.VerifyWithX5Chain], but
+// validates the x5chain at the signing time attested by the timestamp token
+// (see [SignedCorim.VerifyTimestamp]) rather than at the current time. This
+// allows verifying signed CoRIMs that outlive their signing certificates.
+// Revocation information (CRLs and OCSP responses) is checked at the current
+// time, so that it may postdate the signature: a certificate revoked after the
+// signing time is still accepted, unless it was revoked because of a key
+// compromise.
+func (o *SignedCorim) VerifyWithX5ChainAtTimestamp(anchors TrustAnchors, tsaRoots *x509.CertPool) error {
+	ts, err := o.VerifyTimestamp(tsaRoots)
+	if err != nil {
+		return err
+	}
+
+	now := anchors.CurrentTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	if ts.GenTime.After(now) {
+		return fmt.Errorf("timestamp: time %s is in the future", ts.GenTime.Format(time.RFC3339))
+	}
+
+	return o.verifyWithX5Chain(anchors, ts.GenTime)
+}
+
+// verifyTimestampToken verifies the supplied TimeStampToken over the supplied
+// data, and returns the attested time and the TSA certificate
+func verifyTimestampToken(token []byte, data []byte, roots *x509.CertPool) (*Timestamp, error) {
+	sd, err := parseSignedData(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sd.EncapContentInfo.EContentType.Equal(oidTSTInfo) {
+		return nil, fmt.Errorf("unexpected content type %s", sd.EncapContentInfo.EContentType)
+	}
+
+	var info tstInfo
+	if rest, err := asn1.Unmarshal(sd.EncapContentInfo.EContent, &info); err != nil {
+		return nil, fmt.Errorf("decoding TSTInfo: %w", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after TSTInfo")
+	}
+
+	if info.Version != 1 {
+		return nil, fmt.Errorf("unsupported TSTInfo version %d", info.Version)
+	}
+
+	if err := checkMessageImprint(info.MessageImprint, data); err != nil {
+		return nil, err
+	}
+
+	if len(sd.SignerInfos) != 1 {
+		return nil, fmt.Errorf("expecting one signer, got %d", len(sd.SignerInfos))
+	}
+
+	certs, err := x509.ParseCertificates(sd.Certificates.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("decoding certificates: %w", err)
+	}
+
+	si := sd.SignerInfos[0]
+
+	tsaCert, err := findSignerCert(si.SID, certs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifySignerInfo(si, sd.EncapContentInfo, tsaCert); err != nil {
+		return nil, err
+	}
+
+	if err := verifyTSACert(tsaCert, certs, roots, info.GenTime); err != nil {
+		return nil, err
+	}
+
+	return &Timestamp{GenTime: info.GenTime, TSACert: tsaCert}, nil
+}
+
+func parseSignedData(token []byte) (*cmsSignedData, error) {
+	var ci cmsContentInfo
+	if rest, err := asn1.Unmarshal(token, &ci); err != nil {
+		return nil, fmt.Errorf("decoding token: %w", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after token")
+	}
+
+	if !ci.ContentType.Equal(oidSignedData) {
+		return nil, fmt.Errorf("unexpected token content type %s", ci.ContentType)
+	}
+
+	if ci.Content.Class != asn1.ClassContextSpecific || ci.Content.Tag != 0 {
+		return nil, errors.New("decoding token: missing [0] content")
+	}
+
+	var sd cmsSignedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil {
+		return nil, fmt.Errorf("decoding signed data: %w", err)
+	}
+
+	return &sd, nil
+}
+
+func checkMessageImprint(mi tstMessageImprint, data []byte) error {
+	hash, err := hashFromOID(mi.HashAlgorithm.Algorithm)
+	if err != nil {
+		return fmt.Errorf("message imprint: %w", err)
+	}
+
+	h := hash.New()
+	h.Write(data)
+
+	if !bytes.Equal(h.Sum(nil), mi.HashedMessage) {
+		return errors.New("message imprint does not match the signature")
+	}
+
+	return nil
+}
+
+func hashFromOID(oid asn1.ObjectIdentifier) (crypto.Hash, error) {
+	for h, o := range oidHashes {
+		if o.Equal(oid) {
+			return h, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unsupported hash algorithm %s", oid)
+}
+
+func findSignerCert(sid asn1.RawValue, certs []*x509.Certificate) (*x509.Certificate, error) {
+	match := func(cert *x509.Certificate) bool {
+		return len(cert.SubjectKeyId) > 0 && bytes.Equal(cert.SubjectKeyId, sid.Bytes)
+	}
+
+	// the signer is identified either by subjectKeyIdentifier [0] or by
+	// issuerAndSerialNumber
+	if sid.Class != asn1.ClassContextSpecific || sid.Tag != 0 {
+		var ias cmsIssuerAndSerialNumber
+		if _, err := asn1.Unmarshal(sid.FullBytes, &ias); err != nil {
+			return nil, fmt.Errorf("decoding signer identifier: %w", err)
+		}
+
+		match = func(cert *x509.Certificate) bool {
+			return bytes.Equal(cert.RawIssuer, ias.Issuer.FullBytes) && cert.SerialNumber.Cmp(ias.SerialNumber) == 0
+		}
+	}
+
+	for _, cert := range certs {
+		if match(cert) {
+			return cert, nil
+		}
+	}
+
+	return nil, errors.New("TSA certificate not found in token")
+}
+
+// verifySignerInfo checks the signed attributes against the encapsulated
+// TSTInfo, and the signature over the signed attributes
+func verifySignerInfo(si cmsSignerInfo, eci cmsEncapContentInfo, cert *x509.Certificate) error {
+	if len(si.SignedAttrs.FullBytes) == 0 {
+		return errors.New("missing signed attributes")
+	}
+
+	hash, err := hashFromOID(si.DigestAlgorithm.Algorithm)
+	if err != nil {
+		return fmt.Errorf("signer digest: %w", err)
+	}
+
+	var contentType, messageDigest []byte
+
+	for rest := si.SignedAttrs.Bytes; len(rest) > 0; {
+		var attr cmsAttribute
+		if rest, err = asn1.Unmarshal(rest, &attr); err != nil {
+			return fmt.Errorf("decoding signed attributes: %w", err)
+		}
+
+		if len(attr.Values) != 1 {
+			continue
+		}
+
+		switch {
+		case attr.Type.Equal(oidContentType):
+			contentType = attr.Values[0].FullBytes
+		case attr.Type.Equal(oidMessageDigest):
+			messageDigest = attr.Values[0].Bytes
+		}
+	}
+
+	wantContentType, _ := asn1.Marshal(oidTSTInfo)
+	if !bytes.Equal(contentType, wantContentType) {
+		return errors.New("signed content type is not TSTInfo")
+	}
+
+	h := hash.New()
+	h.Write(eci.EContent)
+
+	if !bytes.Equal(h.Sum(nil), messageDigest) {
+		return errors.New("signed message digest does not match TSTInfo")
+	}
+
+	// the signature is over the DER encoding of the SET OF attributes, rather
+	// than over their [0] IMPLICIT encoding
+	signed := bytes.Clone(si.SignedAttrs.FullBytes)
+	signed[0] = 0x31
+
+	h = hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch pk := cert.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(pk, digest, si.Signature) {
+			return errors.New("invalid TSA signature")
+		}
+	case *rsa.PublicKey:
+		if si.SignatureAlgorithm.Algorithm.Equal(oidRSASSAPSS) {
+			err = rsa.VerifyPSS(pk, hash, digest, si.Signature, nil)
+		} else {
+			err = rsa.VerifyPKCS1v15(pk, hash, digest, si.Signature)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid TSA signature: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported TSA key type %T", pk)
+	}
+
+	return nil
+}
+
+// verifyTSACert checks that the TSA certificate is dedicated to time stamping
+// (RFC 3161, Section 2.3) and chains to the TSA roots at the attested time
+func verifyTSACert(cert *x509.Certificate, certs []*x509.Certificate, roots *x509.CertPool, at time.Time) error {
+	if len(cert.ExtKeyUsage) != 1 || cert.ExtKeyUsage[0] != x509.ExtKeyUsageTimeStamping {
+		return fmt.Errorf("TSA certificate %q must have the sole timeStamping extended key usage", cert.Subject)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, c := range certs {
+		if c != cert {
+			intermediates.AddCert(c)
+		}
+	}
+
+	_, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   at,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+	})
+	if err != nil {
+		return fmt.Errorf("TSA certificate verification failed: %w", err)
+	}
+
+	return nil
+}
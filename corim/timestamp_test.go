@@ -0,0 +1,438 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTSA is a local stand-in for an RFC 3161 time-stamping authority
+type testTSA struct {
+	cert    *x509.Certificate
+	chain   []byte
+	key     crypto.Signer
+	genTime time.Time
+	// imprint, if set, is timestamped instead of the supplied digest
+	imprint []byte
+}
+
+func newTestTSA(t *testing.T, pki testPKI, key crypto.Signer, eku []x509.ExtKeyUsage) *testTSA {
+	t.Helper()
+
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(5),
+		Subject:      pkix.Name{CommonName: "Test TSA"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  eku,
+	}, pki.intermediate, key.Public(), pki.intermediateKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	chain := append(bytes.Clone(cert.Raw), pki.intermediateDER...)
+
+	return &testTSA{cert: cert, chain: chain, key: key, genTime: time.Now().UTC().Truncate(time.Second)}
+}
+
+func newECTestTSA(t *testing.T, pki testPKI) *testTSA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return newTestTSA(t, pki, key, []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping})
+}
+
+func (o *testTSA) Timestamp(digest []byte, hash crypto.Hash) ([]byte, error) {
+	if o.imprint != nil {
+		digest = o.imprint
+	}
+
+	info, err := asn1.Marshal(tstInfo{
+		Version: 1,
+		Policy:  asn1.ObjectIdentifier{1, 2, 3, 4},
+		MessageImprint: tstMessageImprint{
+			HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidHashes[hash]},
+			HashedMessage: digest,
+		},
+		SerialNumber: big.NewInt(1),
+		GenTime:      o.genTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	h := crypto.SHA256.New()
+	h.Write(info)
+
+	contentType, err := asn1.Marshal(oidTSTInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	messageDigest, err := asn1.Marshal(h.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	attrs, err := asn1.Marshal([]cmsAttribute{
+		{Type: oidContentType, Values: []asn1.RawValue{{FullBytes: contentType}}},
+		{Type: oidMessageDigest, Values: []asn1.RawValue{{FullBytes: messageDigest}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the signature is over the SET OF attributes
+	attrs[0] = 0x31
+
+	h = crypto.SHA256.New()
+	h.Write(attrs)
+
+	sig, err := o.key.Sign(rand.Reader, h.Sum(nil), crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+
+	sid, err := asn1.Marshal(cmsIssuerAndSerialNumber{
+		Issuer:       asn1.RawValue{FullBytes: o.cert.RawIssuer},
+		SerialNumber: o.cert.SerialNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// retag the SET OF attributes as [0] IMPLICIT
+	attrs[0] = 0xa0
+
+	sd, err := asn1.Marshal(cmsSignedData{
+		Version:          3,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{{Algorithm: oidHashes[crypto.SHA256]}},
+		EncapContentInfo: cmsEncapContentInfo{EContentType: oidTSTInfo, EContent: info},
+		Certificates: asn1.RawValue{
+			Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: o.chain,
+		},
+		SignerInfos: []cmsSignerInfo{{
+			Version:            1,
+			SID:                asn1.RawValue{FullBytes: sid},
+			DigestAlgorithm:    pkix.AlgorithmIdentifier{Algorithm: oidHashes[crypto.SHA256]},
+			SignedAttrs:        asn1.RawValue{FullBytes: attrs},
+			SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}},
+			Signature:          sig,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(cmsContentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: sd},
+	})
+}
+
+func timestampedCorim(t *testing.T, pki testPKI, tsa Timestamper) SignedCorim {
+	t.Helper()
+
+	_, in, _ := signWithChain(t, mustECPrivateKeyJWK(t, pki.leafKey), pki.leafDER, pki.intermediateDER)
+
+	data, err := in.AddTimestamp(tsa, crypto.SHA256)
+	require.NoError(t, err)
+
+	var out SignedCorim
+	require.NoError(t, out.FromCOSE(data))
+
+	return out
+}
+
+func pkiRootPool(pki testPKI) *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(pki.root)
+
+	return pool
+}
+
+func TestSignedCorim_VerifyWithX5ChainAtTimestamp_expiredSigningCert(t *testing.T) {
+	pki := buildTestPKI(t)
+	tsa := newECTestTSA(t, pki)
+	sc := timestampedCorim(t, pki, tsa)
+
+	// the signing certificate has expired by now
+	anchors := pkiTrustAnchors(pki)
+	anchors.CurrentTime = time.Now().Add(2 * time.Hour)
+
+	err := sc.VerifyWithX5Chain(anchors)
+	assert.ErrorContains(t, err, "expired")
+
+	err = sc.VerifyWithX5ChainAtTimestamp(anchors, pkiRootPool(pki))
+	assert.NoError(t, err)
+}
+
+func TestSignedCorim_VerifyWithX5ChainAtTimestamp_CRLIssuedAfterTimestamp(t *testing.T) {
+	pki := buildTestPKI(t)
+	tsa := newECTestTSA(t, pki)
+	sc := timestampedCorim(t, pki, tsa)
+
+	// the CRLs are issued after the signing certificate has expired
+	issued := tsa.genTime.Add(90 * time.Minute)
+
+	makeCRL := func(issuer *x509.Certificate, key *ecdsa.PrivateKey, entries ...x509.RevocationListEntry) *x509.RevocationList {
+		der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+			Number:                    big.NewInt(1),
+			ThisUpdate:                issued,
+			NextUpdate:                issued.Add(time.Hour),
+			RevokedCertificateEntries: entries,
+		}, issuer, key)
+		require.NoError(t, err)
+
+		crl, err := x509.ParseRevocationList(der)
+		require.NoError(t, err)
+
+		return crl
+	}
+
+	anchors := pkiTrustAnchors(pki)
+	anchors.CurrentTime = issued.Add(time.Minute)
+
+	for _, tv := range []struct {
+		name   string
+		entry  x509.RevocationListEntry
+		expect string
+	}{
+		{
+			name: "not revoked",
+		},
+		{
+			name: "revoked after signing",
+			entry: x509.RevocationListEntry{
+				SerialNumber:   pki.leaf.SerialNumber,
+				RevocationTime: tsa.genTime.Add(time.Minute),
+				ReasonCode:     4, // superseded
+			},
+		},
+		{
+			name: "revoked before signing",
+			entry: x509.RevocationListEntry{
+				SerialNumber:   pki.leaf.SerialNumber,
+				RevocationTime: tsa.genTime.Add(-time.Minute),
+				ReasonCode:     4, // superseded
+			},
+			expect: `x5chain: certificate "CN=Leaf" is revoked`,
+		},
+		{
+			name: "key compromised after signing",
+			entry: x509.RevocationListEntry{
+				SerialNumber:   pki.leaf.SerialNumber,
+				RevocationTime: tsa.genTime.Add(time.Minute),
+				ReasonCode:     1, // keyCompromise
+			},
+			expect: `x5chain: certificate "CN=Leaf" is revoked`,
+		},
+	} {
+		t.Run(tv.name, func(t *testing.T) {
+			var entries []x509.RevocationListEntry
+			if tv.entry.SerialNumber != nil {
+				entries = append(entries, tv.entry)
+			}
+
+			anchors.CRLs = []*x509.RevocationList{
+				makeCRL(pki.intermediate, pki.intermediateKey, entries...),
+				makeCRL(pki.root, pki.rootKey),
+			}
+
+			err := sc.VerifyWithX5ChainAtTimestamp(anchors, pkiRootPool(pki))
+			if tv.expect == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tv.expect)
+			}
+		})
+	}
+}
+
+func TestSignedCorim_VerifyTimestamp_ok(t *testing.T) {
+	pki := buildTestPKI(t)
+	tsa := newECTestTSA(t, pki)
+	sc := timestampedCorim(t, pki, tsa)
+
+	ts, err := sc.VerifyTimestamp(pkiRootPool(pki))
+	require.NoError(t, err)
+	assert.True(t, tsa.genTime.Equal(ts.GenTime))
+	assert.Equal(t, tsa.cert, ts.TSACert)
+}
+
+func TestSignedCorim_VerifyTimestamp_RSA(t *testing.T) {
+	pki := buildTestPKI(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tsa := newTestTSA(t, pki, key, []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping})
+	sc := timestampedCorim(t, pki, tsa)
+
+	_, err = sc.VerifyTimestamp(pkiRootPool(pki))
+	assert.NoError(t, err)
+}
+
+func TestSignedCorim_VerifyTimestamp_imprintMismatch(t *testing.T) {
+	pki := buildTestPKI(t)
+	tsa := newECTestTSA(t, pki)
+	tsa.imprint = make([]byte, 32)
+	sc := timestampedCorim(t, pki, tsa)
+
+	_, err := sc.VerifyTimestamp(pkiRootPool(pki))
+	assert.EqualError(t, err, "timestamp: message imprint does not match the signature")
+}
+
+func TestSignedCorim_VerifyTimestamp_badTSASignature(t *testing.T) {
+	pki := buildTestPKI(t)
+	tsa := newECTestTSA(t, pki)
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tsa.key = otherKey
+
+	sc := timestampedCorim(t, pki, tsa)
+
+	_, err = sc.VerifyTimestamp(pkiRootPool(pki))
+	assert.EqualError(t, err, "timestamp: invalid TSA signature")
+}
+
+func TestSignedCorim_VerifyTimestamp_TSACertWithoutTimeStampingEKU(t *testing.T) {
+	pki := buildTestPKI(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tsa := newTestTSA(t, pki, key, []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning})
+	sc := timestampedCorim(t, pki, tsa)
+
+	_, err = sc.VerifyTimestamp(pkiRootPool(pki))
+	assert.EqualError(t, err,
+		`timestamp: TSA certificate "CN=Test TSA" must have the sole timeStamping extended key usage`)
+}
+
+func TestSignedCorim_VerifyTimestamp_untrustedTSA(t *testing.T) {
+	pki := buildTestPKI(t)
+	tsa := newECTestTSA(t, pki)
+	sc := timestampedCorim(t, pki, tsa)
+
+	_, err := sc.VerifyTimestamp(x509.NewCertPool())
+	assert.ErrorContains(t, err, "timestamp: TSA certificate verification failed")
+}
+
+func TestSignedCorim_VerifyTimestamp_TSACertNotValidAtGenTime(t *testing.T) {
+	pki := buildTestPKI(t)
+	tsa := newECTestTSA(t, pki)
+	tsa.genTime = time.Now().Add(-2 * time.Hour)
+	sc := timestampedCorim(t, pki, tsa)
+
+	_, err := sc.VerifyTimestamp(pkiRootPool(pki))
+	assert.ErrorContains(t, err, "timestamp: TSA certificate verification failed")
+}
+
+func TestSignedCorim_VerifyWithX5ChainAtTimestamp_futureGenTime(t *testing.T) {
+	pki := buildTestPKI(t)
+	tsa := newECTestTSA(t, pki)
+	tsa.genTime = time.Now().Add(30 * time.Minute).UTC().Truncate(time.Second)
+	sc := timestampedCorim(t, pki, tsa)
+
+	err := sc.VerifyWithX5ChainAtTimestamp(pkiTrustAnchors(pki), pkiRootPool(pki))
+	assert.ErrorContains(t, err, "is in the future")
+}
+
+func TestSignedCorim_VerifyTimestamp_noToken(t *testing.T) {
+	pki := buildTestPKI(t)
+	_, _, sc := signWithChain(t, mustECPrivateKeyJWK(t, pki.leafKey), pki.leafDER, pki.intermediateDER)
+
+	_, err := sc.VerifyTimestamp(pkiRootPool(pki))
+	assert.EqualError(t, err, "timestamp: token not set in CoRIM")
+
+	err = sc.VerifyWithX5ChainAtTimestamp(pkiTrustAnchors(pki), pkiRootPool(pki))
+	assert.EqualError(t, err, "timestamp: token not set in CoRIM")
+}
+
+func TestSignedCorim_VerifyTimestamp_badToken(t *testing.T) {
+	pki := buildTestPKI(t)
+	_, _, sc := signWithChain(t, mustECPrivateKeyJWK(t, pki.leafKey), pki.leafDER, pki.intermediateDER)
+
+	sc.TimestampToken = []byte{0x30, 0x00}
+
+	_, err := sc.VerifyTimestamp(pkiRootPool(pki))
+	assert.ErrorContains(t, err, "timestamp: decoding token")
+}
+
+type failingTimestamper struct{}
+
+func (failingTimestamper) Timestamp([]byte, crypto.Hash) ([]byte, error) {
+	return nil, errors.New("TSA unreachable")
+}
+
+func TestSignedCorim_AddTimestamp_NOK(t *testing.T) {
+	var sc SignedCorim
+
+	_, err := sc.AddTimestamp(failingTimestamper{}, crypto.SHA256)
+	assert.EqualError(t, err, "no Sign1 message found")
+
+	pki := buildTestPKI(t)
+	_, sc, _ = signWithChain(t, mustECPrivateKeyJWK(t, pki.leafKey), pki.leafDER, pki.intermediateDER)
+
+	_, err = sc.AddTimestamp(nil, crypto.SHA256)
+	assert.EqualError(t, err, "nil timestamper")
+
+	_, err = sc.AddTimestamp(failingTimestamper{}, crypto.SHA1)
+	assert.EqualError(t, err, "unsupported timestamp hash function SHA-1")
+
+	_, err = sc.AddTimestamp(failingTimestamper{}, crypto.SHA256)
+	assert.EqualError(t, err, "obtaining timestamp token: TSA unreachable")
+	assert.Nil(t, sc.TimestampToken)
+}
+
+func TestSignedCorim_Sign_clearsTimestamp(t *testing.T) {
+	pki := buildTestPKI(t)
+	sc := timestampedCorim(t, pki, newECTestTSA(t, pki))
+	require.NotNil(t, sc.TimestampToken)
+
+	signer, err := NewSignerFromJWK(mustECPrivateKeyJWK(t, pki.leafKey))
+	require.NoError(t, err)
+
+	data, err := sc.Sign(signer)
+	require.NoError(t, err)
+	assert.Nil(t, sc.TimestampToken)
+
+	var out SignedCorim
+	require.NoError(t, out.FromCOSE(data))
+	assert.Nil(t, out.TimestampToken)
+}
+
+func TestSignedCorim_FromCOSE_badTimestampToken(t *testing.T) {
+	pki := buildTestPKI(t)
+	_, sc, _ := signWithChain(t, mustECPrivateKeyJWK(t, pki.leafKey), pki.leafDER, pki.intermediateDER)
+
+	sc.message.Headers.Unprotected[HeaderLabelTimestampToken] = "not a bstr"
+	data, err := sc.message.MarshalCBOR()
+	require.NoError(t, err)
+
+	var out SignedCorim
+	err = out.FromCOSE(data)
+	assert.EqualError(t, err, "processing COSE headers: timestamp token: expected a []byte but got string")
+}
@@ -10,10 +10,12 @@ import (
 	"errors"
 	"fmt"
 	"iter"
+	"slices"
 	"time"
 
 	cbor "github.com/fxamacker/cbor/v2"
 
+	"github.com/veraison/corim/cotl"
 	"github.com/veraison/corim/cots"
 	"github.com/veraison/corim/encoding"
 	"github.com/veraison/corim/extensions"
@@ -80,16 +82,23 @@ func (o *UnsignedCorim) GetExtensions() extensions.IMapValue {
 // corim-id can be passed as UUID in string or binary form (i.e., byte array),
 // or as a (non-empty) string
 func (o *UnsignedCorim) SetID(v interface{}) *UnsignedCorim {
-	if o != nil {
-		tagID := swid.NewTagID(v)
-		if tagID == nil {
-			return nil
-		}
-		o.ID = *tagID
+	if o != nil && o.setID(v) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *UnsignedCorim) setID(v interface{}) error {
+	tagID, err := comid.ParseTagID(v)
+	if err != nil {
+		return err
+	}
+
+	o.ID = *tagID
+
+	return nil
+}
+
 // GetID retrieves the corim-id from the unsigned-corim-map as a string
 // nolint:gocritic
 func (o UnsignedCorim) GetID() string {
@@ -99,137 +108,225 @@ func (o UnsignedCorim) GetID() string {
 // AddComid appends the CBOR encoded (and appropriately tagged) CoMID to the
 // tags array of the unsigned-corim-map
 func (o *UnsignedCorim) AddComid(c *comid.Comid) *UnsignedCorim {
-	if o != nil {
-		if c.Valid() != nil {
-			return nil
-		}
+	if o != nil && o.addComid(c) != nil {
+		return nil
+	}
+	return o
+}
 
-		comidCBOR, err := c.ToCBOR()
-		if err != nil {
-			return nil
-		}
+func (o *UnsignedCorim) addComid(c *comid.Comid) error {
+	if c == nil {
+		return errors.New("nil comid")
+	}
 
-		o.Tags = append(o.Tags, Tag{Number: ComidTag, Content: comidCBOR})
+	if err := c.Valid(); err != nil {
+		return fmt.Errorf("invalid comid: %w", err)
 	}
-	return o
+
+	comidCBOR, err := c.ToCBOR()
+	if err != nil {
+		return fmt.Errorf("encoding comid: %w", err)
+	}
+
+	o.Tags = append(o.Tags, Tag{Number: ComidTag, Content: comidCBOR})
+
+	return nil
 }
 
 // AddCots appends the CBOR encoded (and appropriately tagged) CoTS to the
 // tags array of the unsigned-corim-map
 func (o *UnsignedCorim) AddCots(c *cots.ConciseTaStore) *UnsignedCorim {
-	if o != nil {
-		if c.Valid() != nil {
-			return nil
-		}
+	if o != nil && o.addCots(c) != nil {
+		return nil
+	}
+	return o
+}
 
-		cotsCBOR, err := c.ToCBOR()
-		if err != nil {
-			return nil
-		}
+func (o *UnsignedCorim) addCots(c *cots.ConciseTaStore) error {
+	if c == nil {
+		return errors.New("nil cots")
+	}
 
-		o.Tags = append(o.Tags, Tag{Number: cots.CotsTag, Content: cotsCBOR})
+	if err := c.Valid(); err != nil {
+		return fmt.Errorf("invalid cots: %w", err)
+	}
+
+	cotsCBOR, err := c.ToCBOR()
+	if err != nil {
+		return fmt.Errorf("encoding cots: %w", err)
+	}
+
+	o.Tags = append(o.Tags, Tag{Number: cots.CotsTag, Content: cotsCBOR})
+
+	return nil
+}
+
+// AddCotl appends the CBOR encoded (and appropriately tagged) CoTL to the
+// tags array of the unsigned-corim-map
+func (o *UnsignedCorim) AddCotl(c *cotl.ConciseTagList) *UnsignedCorim {
+	if o != nil && o.addCotl(c) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *UnsignedCorim) addCotl(c *cotl.ConciseTagList) error {
+	if c == nil {
+		return errors.New("nil cotl")
+	}
+
+	cotlCBOR, err := c.ToCBOR()
+	if err != nil {
+		return fmt.Errorf("encoding cotl: %w", err)
+	}
+
+	o.Tags = append(o.Tags, Tag{Number: cotl.CotlTag, Content: cotlCBOR})
+
+	return nil
+}
+
 // AddCoswid appends the CBOR encoded (and appropriately tagged) CoSWID to the
 // tags array of the unsigned-corim-map
 func (o *UnsignedCorim) AddCoswid(c *swid.SoftwareIdentity) *UnsignedCorim {
-	if o != nil {
-		// Currently the swid package doesn't offer an interface
-		// for validating the supplied CoSWID, so -- for now --
-		// we take any input for granted and pass it to the encoder.
-		// See also https://github.com/veraison/swid/issues/23.
+	if o != nil && o.addCoswid(c) != nil {
+		return nil
+	}
+	return o
+}
 
-		coswidCBOR, err := c.ToCBOR()
-		if err != nil {
-			return nil
-		}
+func (o *UnsignedCorim) addCoswid(c *swid.SoftwareIdentity) error {
+	if c == nil {
+		return errors.New("nil coswid")
+	}
+
+	// Currently the swid package doesn't offer an interface
+	// for validating the supplied CoSWID, so -- for now --
+	// we take any input for granted and pass it to the encoder.
+	// See also https://github.com/veraison/swid/issues/23.
 
-		o.Tags = append(o.Tags, Tag{Number: CoswidTag, Content: coswidCBOR})
+	coswidCBOR, err := c.ToCBOR()
+	if err != nil {
+		return fmt.Errorf("encoding coswid: %w", err)
 	}
-	return o
+
+	o.Tags = append(o.Tags, Tag{Number: CoswidTag, Content: coswidCBOR})
+
+	return nil
 }
 
 // AddDependentRim creates a corim-locator-map from the supplied arguments and
 // appends it to the dependent RIMs in the unsigned-corim-map
 func (o *UnsignedCorim) AddDependentRim(href string, thumbprint *comid.Digest) *UnsignedCorim {
 	if o != nil {
-		l := Locator{
-			Href: OneOrMore[comid.TaggedURI]{comid.TaggedURI(href)},
-		}
+		o.addDependentRim(newLocator(href, thumbprint))
+	}
+	return o
+}
 
-		if thumbprint != nil {
-			l.Thumbprint = &OneOrMore[comid.Digest]{*thumbprint}
-		}
+func (o *UnsignedCorim) addDependentRim(l Locator) {
+	if o.DependentRims == nil {
+		o.DependentRims = new([]Locator)
+	}
 
-		if o.DependentRims == nil {
-			o.DependentRims = new([]Locator)
-		}
+	*o.DependentRims = append(*o.DependentRims, l)
+}
 
-		*o.DependentRims = append(*o.DependentRims, l)
+func newLocator(href string, thumbprint *comid.Digest) Locator {
+	l := Locator{
+		Href: OneOrMore[comid.TaggedURI]{comid.TaggedURI(href)},
+	}
 
+	if thumbprint != nil {
+		l.Thumbprint = &OneOrMore[comid.Digest]{*thumbprint}
 	}
-	return o
+
+	return l
 }
 
 // SetProfile sets the supplied profile identifier (either a URL or OID) as
 // the profile in the unsigned-corim-map
 func (o *UnsignedCorim) SetProfile(urlOrOID string) *UnsignedCorim {
-	if o != nil {
-		p, err := NewProfileFromString(urlOrOID)
-		if err != nil {
-			return nil
-		}
-
-		o.Profile = p
-
+	if o != nil && o.setProfile(urlOrOID) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *UnsignedCorim) setProfile(urlOrOID string) error {
+	p, err := NewProfileFromString(urlOrOID)
+	if err != nil {
+		return err
+	}
+
+	o.Profile = p
+
+	return nil
+}
+
 // SetRimValidity can be used to set the validity period of the CoRIM.
 // The caller must supply a "not-after" timestamp and optionally a "not-before"
 // timestamp.
 func (o *UnsignedCorim) SetRimValidity(notAfter time.Time, notBefore *time.Time) *UnsignedCorim {
-	if o != nil {
-		v := NewValidity().Set(notAfter, notBefore)
-		if v == nil {
-			return nil
-		}
-
-		o.RimValidity = v
+	if o != nil && o.setRimValidity(notAfter, notBefore) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *UnsignedCorim) setRimValidity(notAfter time.Time, notBefore *time.Time) error {
+	v := Validity{NotAfter: notAfter, NotBefore: notBefore}
+
+	if err := v.Valid(); err != nil {
+		return err
+	}
+
+	o.RimValidity = &v
+
+	return nil
+}
+
 // AddEntity adds an organizational entity, together with the roles this entity
 // claims with regards to the CoRIM, to the target UnsignerCorim.  name is the entity
 // name, regID is a URI that uniquely identifies the entity.  For the moment, roles
 // can only be RoleManifestCreator.
 func (o *UnsignedCorim) AddEntity(name string, regID *string, roles ...Role) *UnsignedCorim {
-	if o != nil {
-		e := NewEntity().
-			SetName(name).
-			SetRoles(roles...)
+	if o != nil && o.addEntity(name, regID, roles...) != nil {
+		return nil
+	}
+	return o
+}
 
-		if regID != nil {
-			e = e.SetRegID(*regID)
-		}
+func (o *UnsignedCorim) addEntity(name string, regID *string, roles ...Role) error {
+	if name == "" {
+		return errors.New("empty entity name")
+	}
 
-		if e == nil {
-			return nil
-		}
+	e := NewEntity().SetName(name)
 
-		if o.Entities == nil {
-			o.Entities = NewEntities()
+	if regID != nil {
+		uri, err := comid.String2URI(regID)
+		if err != nil {
+			return fmt.Errorf("invalid regid: %w", err)
 		}
 
-		if o.Entities.Add(e) == nil {
-			return nil
+		e.RegID = uri
+	}
+
+	for i, r := range roles {
+		if !isRole(r) {
+			return fmt.Errorf("unknown role %d at index %d", r, i)
 		}
 	}
-	return o
+
+	e.SetRoles(roles...)
+
+	if o.Entities == nil {
+		o.Entities = NewEntities()
+	}
+
+	o.Entities.Add(e)
+
+	return nil
 }
 
 // IterComids provides an iterator over all Comids inside an UnsignedCorim. The
@@ -239,10 +336,26 @@ func (o *UnsignedCorim) AddEntity(name string, regID *string, roles ...Role) *Un
 // iteration); if the function returns nil, that means it was possible to
 // iterate over all Comid's without error.
 func (o *UnsignedCorim) IterComids() (it iter.Seq[*comid.Comid], errFunc func() error) {
+	return o.iterComids(false)
+}
+
+// IterComidsSkipOthers is like IterComids, but skips the CoSWID, CoTS and
+// CoTL tags of the UnsignedCorim instead of reporting them as errors. Tags of
+// an unknown type are still reported as errors.
+func (o *UnsignedCorim) IterComidsSkipOthers() (it iter.Seq[*comid.Comid], errFunc func() error) {
+	return o.iterComids(true)
+}
+
+func (o *UnsignedCorim) iterComids(skipOthers bool) (it iter.Seq[*comid.Comid], errFunc func() error) {
 	var err error
 
 	seq := func(yield func(*comid.Comid) bool) {
 		for i, tag := range o.Tags {
+			if skipOthers &&
+				(tag.Number == CoswidTag || tag.Number == cots.CotsTag || tag.Number == cotl.CotlTag) {
+				continue
+			}
+
 			if tag.Number != ComidTag {
 				err = fmt.Errorf("unknown CBOR tag %x detected at index %d", tag.Number, i)
 				return
@@ -402,6 +515,111 @@ func (o *UnsignedCorim) IterDevIdentityKeys() (it iter.Seq[*comid.KeyTriple], er
 	return seq, errf
 }
 
+// IterCotls provides an iterator over all the CoTLs inside an UnsignedCorim,
+// skipping any other tags. The second return value is a function that should
+// be called after the iteration has finished. If an error occurred while
+// iterating, the function will return that error (note: an error also results
+// in immediate termination of iteration).
+func (o *UnsignedCorim) IterCotls() (it iter.Seq[*cotl.ConciseTagList], errFunc func() error) {
+	var err error
+
+	seq := func(yield func(*cotl.ConciseTagList) bool) {
+		for i, tag := range o.Tags {
+			if tag.Number != cotl.CotlTag {
+				continue
+			}
+
+			var tl cotl.ConciseTagList
+			if err = tl.FromCBOR(tag.Content); err != nil {
+				err = fmt.Errorf("decoding CoTL at index %d: %w", i, err)
+				return
+			}
+
+			if !yield(&tl) {
+				return
+			}
+		}
+	}
+
+	errf := func() error {
+		return err
+	}
+
+	return seq, errf
+}
+
+// IterComidsInTagList is like IterComidsSkipOthers, but only yields the CoMIDs
+// whose tag-identity is listed by the supplied CoTL (irrespective of the
+// validity of the list).
+func (o *UnsignedCorim) IterComidsInTagList(
+	tl *cotl.ConciseTagList,
+) (it iter.Seq[*comid.Comid], errFunc func() error) {
+	comidSeq, comidErrf := o.IterComidsSkipOthers()
+
+	seq := func(yield func(*comid.Comid) bool) {
+		for cm := range comidSeq {
+			if !tl.Contains(cm.TagIdentity) {
+				continue
+			}
+
+			if !yield(cm) {
+				return
+			}
+		}
+	}
+
+	return seq, comidErrf
+}
+
+// IterActiveComids is like IterComidsSkipOthers, but only yields the CoMIDs that are
+// active at the supplied time according to the CoTLs carried by the
+// UnsignedCorim itself, i.e., those listed by at least one CoTL that is valid
+// at that time. If the UnsignedCorim carries no CoTL, all CoMIDs are yielded.
+func (o *UnsignedCorim) IterActiveComids(at time.Time) (it iter.Seq[*comid.Comid], errFunc func() error) {
+	var err error
+
+	seq := func(yield func(*comid.Comid) bool) {
+		var (
+			lists []*cotl.ConciseTagList
+			found bool
+		)
+
+		cotlSeq, cotlErrf := o.IterCotls()
+		for tl := range cotlSeq {
+			found = true
+			if tl.TlValidity.CheckAt(at) == nil {
+				lists = append(lists, tl)
+			}
+		}
+
+		if err = cotlErrf(); err != nil {
+			return
+		}
+
+		comidSeq, comidErrf := o.IterComidsSkipOthers()
+
+		for cm := range comidSeq {
+			if found && !slices.ContainsFunc(lists, func(tl *cotl.ConciseTagList) bool {
+				return tl.Contains(cm.TagIdentity)
+			}) {
+				continue
+			}
+
+			if !yield(cm) {
+				return
+			}
+		}
+
+		err = comidErrf()
+	}
+
+	errf := func() error {
+		return err
+	}
+
+	return seq, errf
+}
+
 // Valid checks the validity (according to the spec) of the target unsigned CoRIM
 // nolint:gocritic
 func (o UnsignedCorim) Valid() error {
@@ -450,6 +668,55 @@ func (o UnsignedCorim) Valid() error {
 	return o.validCorim(&o)
 }
 
+// ValidateAll is like Valid, except that, rather than stopping at the first
+// violation, it returns all of them as a comid.ValidationErrors. CoMID and CoTS
+// tags are decoded (using the extensions of the CoRIM profile, if any) and
+// validated in full as well.
+// nolint:gocritic
+func (o UnsignedCorim) ValidateAll() error {
+	v := comid.NewValidator()
+
+	if o.ID == (swid.TagID{}) {
+		v.Field("corim-id", 0).Check(errors.New("empty id"))
+	}
+
+	tv := v.Field("tags", 1)
+
+	if len(o.Tags) == 0 {
+		tv.Check(errors.New("no tags"))
+	}
+
+	for i, t := range o.Tags {
+		tv.Index(i).Check(t.validateAll(o.Profile))
+	}
+
+	if o.DependentRims != nil {
+		rv := v.Field("dependent-rims", 2)
+		for i, r := range *o.DependentRims {
+			rv.Index(i).Check(r.Valid())
+		}
+	}
+
+	if o.Profile != nil {
+		v.Field("profile", 3).Check(o.Profile.Valid())
+	}
+
+	if o.RimValidity != nil {
+		v.Field("validity", 4).Check(o.RimValidity.Valid())
+	}
+
+	if o.Entities != nil {
+		ev := v.Field("entities", 5)
+		for i, e := range o.Entities.Values {
+			ev.Index(i).Check(e.ValidateAll())
+		}
+	}
+
+	v.Check(o.validCorim(&o))
+
+	return v.Err()
+}
+
 // ToCBOR serializes the target unsigned CoRIM to CBOR
 // nolint:gocritic
 func (o UnsignedCorim) ToCBOR() ([]byte, error) {
@@ -524,6 +791,33 @@ func (o Tag) Valid() error {
 	return nil
 }
 
+// validateAll checks the tag and, for CoMID and CoTS tags, all of their
+// contents
+func (o Tag) validateAll(profile *Profile) error {
+	if err := o.Valid(); err != nil {
+		return err
+	}
+
+	switch o.Number {
+	case ComidTag:
+		c, err := UnmarshalComidFromCBOR(o.Content, profile)
+		if err != nil {
+			return fmt.Errorf("decoding CoMID: %w", err)
+		}
+
+		return c.ValidateAll()
+	case cots.CotsTag:
+		var c cots.ConciseTaStore
+		if err := c.FromCBOR(o.Content); err != nil {
+			return fmt.Errorf("decoding CoTS: %w", err)
+		}
+
+		return c.ValidateAll()
+	}
+
+	return nil
+}
+
 func (o Tag) MarshalCBOR() ([]byte, error) {
 	return em.Marshal(cbor.Tag{Number: o.Number, Content: o.Content})
 }
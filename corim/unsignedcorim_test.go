@@ -5,6 +5,7 @@ package corim
 
 import (
 	"fmt"
+	"iter"
 	"os"
 	"path/filepath"
 	"slices"
@@ -14,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/veraison/corim/comid"
+	"github.com/veraison/corim/cotl"
 	"github.com/veraison/corim/cots"
 	"github.com/veraison/corim/extensions"
 	"github.com/veraison/swid"
@@ -544,3 +546,213 @@ func TestCorim_unmarshal_RFC_examples(t *testing.T) {
 		})
 	}
 }
+
+func TestUnsignedCorim_IterComidsSkipOthers(t *testing.T) {
+	ts := &cots.ConciseTaStore{}
+	require.NoError(t, ts.FromJSON([]byte(cots.ConciseTaStoreTemplateSingleOrg)))
+
+	c := unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR).AddCots(ts)
+	require.NotNil(t, c)
+
+	// IterComids reports any tag that is not a CoMID
+	seq, errFunc := c.IterComids()
+	assert.Len(t, slices.Collect(seq), 1)
+	assert.EqualError(t, errFunc(), "unknown CBOR tag 1fb detected at index 1")
+
+	seq, errFunc = c.IterComidsSkipOthers()
+	assert.Len(t, slices.Collect(seq), 1)
+	assert.NoError(t, errFunc())
+
+	c.Tags = append(c.Tags, Tag{Number: 1234, Content: []byte{0xa0}})
+
+	seq, errFunc = c.IterComidsSkipOthers()
+	assert.Len(t, slices.Collect(seq), 1)
+	assert.EqualError(t, errFunc(), "unknown CBOR tag 4d2 detected at index 2")
+}
+
+func TestUnsignedCorim_IterComidsSkipOthers_mixed_tags(t *testing.T) {
+	var sw swid.SoftwareIdentity
+	require.NoError(t, sw.FromXML([]byte(`<SoftwareIdentity `+
+		`xmlns="http://standards.iso.org/iso/19770/-2/2015/schema.xsd" `+
+		`tagId="com.acme.rrd" name="ACME Roadrunner Detector" version="4.1.5">`+
+		`<Entity name="The ACME Corporation" regid="acme.com" role="tagCreator"></Entity>`+
+		`</SoftwareIdentity>`)))
+
+	ts := &cots.ConciseTaStore{}
+	require.NoError(t, ts.FromJSON([]byte(cots.ConciseTaStoreTemplateSingleOrg)))
+
+	tl := cotl.NewConciseTagList().
+		SetTagIdentity("tag-list", 0).
+		AddTag("tag-a", 0).
+		AddTag("tag-b", 0).
+		SetValidity(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+
+	c := NewUnsignedCorim().SetID("test corim id with mixed tags").
+		AddCoswid(&sw).
+		AddComid(testCotlComid("tag-a")).
+		AddCots(ts).
+		AddCotl(tl).
+		AddComid(testCotlComid("tag-b"))
+	require.NotNil(t, c)
+	require.Len(t, c.Tags, 5)
+
+	data, err := c.ToCBOR()
+	require.NoError(t, err)
+
+	var decoded UnsignedCorim
+	require.NoError(t, decoded.FromCBOR(data))
+
+	seq, errFunc := decoded.IterComidsSkipOthers()
+	assert.Equal(t, []string{"tag-a", "tag-b"}, comidTagIDs(seq))
+	assert.NoError(t, errFunc())
+}
+
+func testCotlComid(tagID string) *comid.Comid {
+	return comid.NewComid().
+		SetTagIdentity(tagID, 0).
+		AddReferenceValue(&comid.ValueTriple{
+			Environment:  comid.Environment{Class: comid.NewClassOID("2.5.2.8192")},
+			Measurements: *comid.NewMeasurements().Add(comid.MustNewUintMeasurement(uint64(0)).SetSVN(1)),
+		})
+}
+
+func comidTagIDs(seq iter.Seq[*comid.Comid]) []string {
+	var ret []string
+	for cm := range seq {
+		ret = append(ret, cm.TagIdentity.TagID.String())
+	}
+	return ret
+}
+
+func TestUnsignedCorim_AddCotl(t *testing.T) {
+	notAfter := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	tl := cotl.NewConciseTagList().
+		SetTagIdentity("tag-list", 0).
+		AddTag("tag-a", 0).
+		SetValidity(notAfter, nil)
+
+	c := NewUnsignedCorim().SetID("test corim id with CoTL").
+		AddComid(testCotlComid("tag-a")).
+		AddCotl(tl)
+	require.NotNil(t, c)
+	require.Len(t, c.Tags, 2)
+	assert.Equal(t, cotl.CotlTag, c.Tags[1].Number)
+
+	data, err := c.ToCBOR()
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalAndValidateUnsignedCorimFromCBOR(data)
+	require.NoError(t, err)
+
+	seq, errFunc := decoded.IterCotls()
+	cotls := slices.Collect(seq)
+	require.NoError(t, errFunc())
+	require.Len(t, cotls, 1)
+	assert.Equal(t, tl.TagsList, cotls[0].TagsList)
+
+	assert.Nil(t, NewUnsignedCorim().AddCotl(cotl.NewConciseTagList()))
+}
+
+func TestUnsignedCorim_invalid_Cotl(t *testing.T) {
+	c := NewUnsignedCorim().SetID("test corim id").AddComid(testCotlComid("tag-a"))
+	c.Tags = append(c.Tags, Tag{Number: cotl.CotlTag, Content: []byte{0xa0}})
+
+	data, err := c.ToCBOR()
+	require.NoError(t, err)
+
+	_, err = UnmarshalAndValidateUnsignedCorimFromCBOR(data)
+	assert.EqualError(t, err,
+		"CoTL tag at index 1: tag-identity validation failed: empty tag-id")
+
+	c.Tags[1].Content = []byte{0xff}
+	seq, errFunc := c.IterCotls()
+	assert.Empty(t, slices.Collect(seq))
+	assert.ErrorContains(t, errFunc(), "decoding CoTL at index 1")
+}
+
+func TestUnsignedCorim_IterActiveComids(t *testing.T) {
+	notBefore := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	current := cotl.NewConciseTagList().
+		SetTagIdentity("current", 0).
+		AddTag("tag-a", 0).
+		SetValidity(notAfter, &notBefore)
+	next := cotl.NewConciseTagList().
+		SetTagIdentity("next", 0).
+		AddTag("tag-b", 0).
+		AddTag("tag-c", 0).
+		SetValidity(notAfter.AddDate(1, 0, 0), &notAfter)
+
+	c := NewUnsignedCorim().SetID("test corim id").
+		AddComid(testCotlComid("tag-a")).
+		AddComid(testCotlComid("tag-b")).
+		AddComid(testCotlComid("tag-c"))
+	require.NotNil(t, c)
+
+	// with no CoTL, all CoMIDs are active
+	seq, errFunc := c.IterActiveComids(notBefore)
+	assert.Equal(t, []string{"tag-a", "tag-b", "tag-c"}, comidTagIDs(seq))
+	assert.NoError(t, errFunc())
+
+	c.AddCotl(current).AddCotl(next)
+
+	seq, errFunc = c.IterActiveComids(notBefore.AddDate(0, 6, 0))
+	assert.Equal(t, []string{"tag-a"}, comidTagIDs(seq))
+	assert.NoError(t, errFunc())
+
+	seq, errFunc = c.IterActiveComids(notAfter.AddDate(0, 6, 0))
+	assert.Equal(t, []string{"tag-b", "tag-c"}, comidTagIDs(seq))
+	assert.NoError(t, errFunc())
+
+	seq, errFunc = c.IterActiveComids(notBefore.AddDate(-1, 0, 0))
+	assert.Empty(t, comidTagIDs(seq))
+	assert.NoError(t, errFunc())
+
+	seq, errFunc = c.IterComidsInTagList(next)
+	assert.Equal(t, []string{"tag-b", "tag-c"}, comidTagIDs(seq))
+	assert.NoError(t, errFunc())
+}
+
+func TestUnsignedCorim_ValidateAll(t *testing.T) {
+	c := comid.NewComid().
+		SetTagIdentity(comid.TestTagID, 0).
+		AddReferenceValue(&comid.ValueTriple{
+			Environment: comid.Environment{
+				Instance: comid.MustNewUUIDInstance(comid.TestUUID),
+			},
+			Measurements: *comid.NewMeasurements().Add(&comid.Measurement{
+				Val: comid.Mval{MACAddr: &comid.MACaddr{0x01, 0x02}},
+			}),
+		})
+	require.NotNil(t, c)
+
+	// bypass AddComid, which refuses invalid CoMIDs
+	comidCBOR, err := em.Marshal(c)
+	require.NoError(t, err)
+
+	tv := NewUnsignedCorim()
+	tv.Tags = []Tag{{Number: ComidTag, Content: comidCBOR}, {Number: ComidTag}}
+	tv.Entities = NewEntities()
+	tv.Entities.Add(&Entity{Roles: Roles{RoleManifestCreator}})
+
+	err = tv.ValidateAll()
+	require.Error(t, err)
+
+	var verrs comid.ValidationErrors
+	require.ErrorAs(t, err, &verrs)
+
+	var pointers []string
+	for _, e := range verrs {
+		pointers = append(pointers, e.Path.JSONPointer())
+	}
+
+	assert.Equal(t, []string{
+		"/corim-id",
+		"/tags/0/triples/reference-values/0/measurements/0/value/mac-addr",
+		"/tags/1",
+		"/entities/0/name",
+	}, pointers)
+	assert.Equal(t, []int{1, 0, 4, 0, 0, 1, 0, 1, 6}, verrs[1].Path.CBORPath())
+	assert.EqualError(t, verrs[2], "/tags/1: empty tag")
+}
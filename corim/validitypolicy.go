@@ -0,0 +1,188 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/veraison/corim/comid"
+)
+
+var (
+	// ErrExpired is wrapped by a ValidityError when a signed CoRIM is used
+	// after the end of one of its validity periods. It is the same error as
+	// comid.ErrExpired.
+	ErrExpired = comid.ErrExpired
+	// ErrNotYetValid is wrapped by a ValidityError when a signed CoRIM is
+	// used before the start of one of its validity periods. It is the same
+	// error as comid.ErrNotYetValid.
+	ErrNotYetValid = comid.ErrNotYetValid
+)
+
+// ValidityScope identifies the validity period checked by a ValidityPolicy
+type ValidityScope string
+
+const (
+	// ScopeSignatureValidity is the signature validity in corim-meta
+	ScopeSignatureValidity ValidityScope = "signature validity"
+	// ScopeRimValidity is the rim-validity in the unsigned-corim
+	ScopeRimValidity ValidityScope = "rim-validity"
+	// ScopeCWTClaims is the nbf / exp window in the CWT claims header
+	ScopeCWTClaims ValidityScope = "CWT claims"
+)
+
+// ValidityError is returned when a signed CoRIM is checked outside of one of
+// its validity periods. It wraps either ErrExpired or ErrNotYetValid (and
+// also ErrCWTExpired or ErrCWTNotYetValid when the CWT claims are at fault).
+type ValidityError struct {
+	Scope     ValidityScope
+	NotBefore *time.Time
+	NotAfter  *time.Time
+	At        time.Time
+
+	err error
+}
+
+func (e *ValidityError) Error() string {
+	var bound string
+
+	if errors.Is(e.err, ErrExpired) {
+		bound = "not-after " + e.NotAfter.Format(time.RFC3339)
+	} else {
+		bound = "not-before " + e.NotBefore.Format(time.RFC3339)
+	}
+
+	return fmt.Sprintf("%s %s: %s, checked at %s", e.Scope, e.err, bound, e.At.Format(time.RFC3339))
+}
+
+func (e *ValidityError) Unwrap() []error {
+	errs := []error{e.err}
+
+	if e.Scope == ScopeCWTClaims {
+		if errors.Is(e.err, ErrExpired) {
+			errs = append(errs, ErrCWTExpired)
+		} else {
+			errs = append(errs, ErrCWTNotYetValid)
+		}
+	}
+
+	return errs
+}
+
+// ValidityPolicy is an opt-in verification policy that rejects signed CoRIMs
+// used outside their signature validity, rim-validity or CWT claims validity
+// window. Validity periods that are not set are not checked.
+type ValidityPolicy struct {
+	// CurrentTime is the time at which validity is checked. If zero, the
+	// CurrentTime of the trust anchors (when verifying an x5chain) or
+	// time.Now() is used.
+	CurrentTime time.Time
+	// ClockSkew is the tolerance applied to both ends of each validity
+	// period
+	ClockSkew time.Duration
+}
+
+// Check checks the validity periods of the supplied signed CoRIM, which must
+// have been decoded with [SignedCorim.FromCOSE]. It returns a *ValidityError
+// if one of them does not include the current time.
+func (o ValidityPolicy) Check(sc *SignedCorim) error {
+	if sc == nil {
+		return errors.New("nil signed CoRIM")
+	}
+
+	if o.ClockSkew < 0 {
+		return fmt.Errorf("negative clock skew %s", o.ClockSkew)
+	}
+
+	now := o.CurrentTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	if v := sc.Meta.Validity; v != nil {
+		if err := o.checkWindow(ScopeSignatureValidity, v.NotBefore, &v.NotAfter, now); err != nil {
+			return err
+		}
+	}
+
+	if v := sc.UnsignedCorim.RimValidity; v != nil {
+		if err := o.checkWindow(ScopeRimValidity, v.NotBefore, &v.NotAfter, now); err != nil {
+			return err
+		}
+	}
+
+	if c := sc.CWTClaims; c != nil {
+		if err := o.checkWindow(ScopeCWTClaims, c.NotBefore, c.Expiration, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o ValidityPolicy) checkWindow(
+	scope ValidityScope,
+	notBefore *time.Time,
+	notAfter *time.Time,
+	now time.Time,
+) error {
+	var err error
+
+	// the exp claim is exclusive (RFC 8392, Section 3.1.4), while not-after
+	// is inclusive
+	expired := func(end time.Time) bool {
+		if scope == ScopeCWTClaims {
+			return !now.Before(end)
+		}
+		return now.After(end)
+	}
+
+	switch {
+	case notBefore != nil && now.Before(notBefore.Add(-o.ClockSkew)):
+		err = ErrNotYetValid
+	case notAfter != nil && expired(notAfter.Add(o.ClockSkew)):
+		err = ErrExpired
+	default:
+		return nil
+	}
+
+	return &ValidityError{
+		Scope:     scope,
+		NotBefore: notBefore,
+		NotAfter:  notAfter,
+		At:        now,
+		err:       err,
+	}
+}
+
+// VerifyWithPolicy verifies the signature of the target SignedCorim using the
+// supplied public key, like [SignedCorim.Verify], and then checks its
+// validity periods against the supplied policy. A bad signature is reported
+// as such even if the signed CoRIM is also out of its validity periods.
+func (o *SignedCorim) VerifyWithPolicy(pk crypto.PublicKey, policy ValidityPolicy) error {
+	if err := o.Verify(pk); err != nil {
+		return err
+	}
+
+	return policy.Check(o)
+}
+
+// VerifyWithX5ChainAndPolicy is like [SignedCorim.VerifyWithX5Chain], but also
+// checks the validity periods of the target SignedCorim against the supplied
+// policy. If the policy has no CurrentTime, the CurrentTime of the trust
+// anchors is used.
+func (o *SignedCorim) VerifyWithX5ChainAndPolicy(anchors TrustAnchors, policy ValidityPolicy) error {
+	if err := o.VerifyWithX5Chain(anchors); err != nil {
+		return err
+	}
+
+	if policy.CurrentTime.IsZero() {
+		policy.CurrentTime = anchors.CurrentTime
+	}
+
+	return policy.Check(o)
+}
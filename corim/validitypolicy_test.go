@@ -0,0 +1,173 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package corim
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cose "github.com/veraison/go-cose"
+)
+
+// signed with signature validity [testNbf, testExp], and rim-validity
+// [testNbf + 1 month, testExp - 1 month]
+func signedCorimWithValidity(t *testing.T) *SignedCorim {
+	t.Helper()
+
+	signer, err := NewSignerFromJWK(testES256Key)
+	require.NoError(t, err)
+
+	rimNbf := testNbf.AddDate(0, 1, 0)
+
+	in := SignedCorim{
+		UnsignedCorim: *unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR).
+			SetRimValidity(testExp.AddDate(0, -1, 0), &rimNbf),
+		Meta: *NewMeta().SetSigner("ACME Ltd.", nil).SetValidity(testExp, &testNbf),
+	}
+
+	data, err := in.Sign(signer)
+	require.NoError(t, err)
+
+	var out SignedCorim
+	require.NoError(t, out.FromCOSE(data))
+
+	return &out
+}
+
+func TestValidityPolicy_Check(t *testing.T) {
+	sc := signedCorimWithValidity(t)
+
+	p := ValidityPolicy{CurrentTime: testNbf.AddDate(0, 6, 0)}
+	assert.NoError(t, p.Check(sc))
+
+	p.CurrentTime = testNbf.Add(-time.Hour)
+	err := p.Check(sc)
+	assert.ErrorIs(t, err, ErrNotYetValid)
+	assert.NotErrorIs(t, err, ErrExpired)
+	assert.EqualError(t, err,
+		"signature validity not yet valid: not-before 2026-01-01T00:00:00Z, checked at 2025-12-31T23:00:00Z")
+
+	var ve *ValidityError
+	require.True(t, errors.As(err, &ve))
+	assert.Equal(t, ScopeSignatureValidity, ve.Scope)
+
+	// within the signature validity, but after the rim-validity
+	p.CurrentTime = testExp.AddDate(0, 0, -1)
+	err = p.Check(sc)
+	assert.ErrorIs(t, err, ErrExpired)
+	require.True(t, errors.As(err, &ve))
+	assert.Equal(t, ScopeRimValidity, ve.Scope)
+	assert.EqualError(t, err,
+		"rim-validity expired: not-after 2026-12-01T00:00:00Z, checked at 2026-12-31T00:00:00Z")
+
+	p.CurrentTime = testExp.Add(time.Second)
+	err = p.Check(sc)
+	require.True(t, errors.As(err, &ve))
+	assert.Equal(t, ScopeSignatureValidity, ve.Scope)
+
+	p.ClockSkew = -time.Second
+	assert.EqualError(t, p.Check(sc), "negative clock skew -1s")
+
+	assert.EqualError(t, ValidityPolicy{}.Check(nil), "nil signed CoRIM")
+}
+
+func TestValidityPolicy_Check_clock_skew(t *testing.T) {
+	sc := signedCorimWithValidity(t)
+	sc.UnsignedCorim.RimValidity = nil
+
+	p := ValidityPolicy{CurrentTime: testExp.Add(time.Minute), ClockSkew: 5 * time.Minute}
+	assert.NoError(t, p.Check(sc))
+
+	p.CurrentTime = testNbf.Add(-time.Minute)
+	assert.NoError(t, p.Check(sc))
+
+	p.CurrentTime = testExp.Add(6 * time.Minute)
+	assert.ErrorIs(t, p.Check(sc), ErrExpired)
+}
+
+func TestValidityPolicy_Check_CWTClaims(t *testing.T) {
+	claims := NewCWTClaims().SetIssuer("ACME Ltd.").SetValidity(testExp, &testNbf)
+
+	var sc SignedCorim
+	require.NoError(t, sc.FromCOSE(signWithCWTClaims(t, Meta{}, claims)))
+
+	p := ValidityPolicy{CurrentTime: testNbf}
+	assert.NoError(t, p.Check(withoutMetaValidity(sc)))
+
+	// exp is exclusive
+	p.CurrentTime = testExp
+	err := p.Check(withoutMetaValidity(sc))
+	assert.ErrorIs(t, err, ErrExpired)
+	assert.ErrorIs(t, err, ErrCWTExpired)
+	assert.EqualError(t, err, "CWT claims expired: not-after 2027-01-01T00:00:00Z, checked at 2027-01-01T00:00:00Z")
+
+	p.CurrentTime = testNbf.Add(-time.Second)
+	err = p.Check(&sc)
+	assert.ErrorIs(t, err, ErrNotYetValid)
+	assert.NotErrorIs(t, err, ErrCWTNotYetValid)
+}
+
+// only leaves the CWT claims validity to check
+func withoutMetaValidity(sc SignedCorim) *SignedCorim {
+	sc.Meta.Validity = nil
+	return &sc
+}
+
+func TestSignedCorim_VerifyWithPolicy(t *testing.T) {
+	sc := signedCorimWithValidity(t)
+
+	pk, err := NewPublicKeyFromJWK(testES256Key)
+	require.NoError(t, err)
+
+	assert.NoError(t, sc.VerifyWithPolicy(pk, ValidityPolicy{CurrentTime: testNbf.AddDate(0, 6, 0)}))
+
+	err = sc.VerifyWithPolicy(pk, ValidityPolicy{CurrentTime: testExp.AddDate(1, 0, 0)})
+	assert.ErrorIs(t, err, ErrExpired)
+	assert.NotErrorIs(t, err, cose.ErrVerification)
+
+	// a bad signature takes precedence over an expired CoRIM
+	otherPK, err := NewPublicKeyFromJWK(testES384Key)
+	require.NoError(t, err)
+
+	err = sc.VerifyWithPolicy(otherPK, ValidityPolicy{CurrentTime: testExp.AddDate(1, 0, 0)})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrExpired)
+}
+
+func TestSignedCorim_VerifyWithX5ChainAndPolicy(t *testing.T) {
+	pki := buildTestPKI(t)
+	si, signer := pkiSignerInfo(t, pki)
+
+	now := time.Now().Truncate(time.Second)
+	nbf := now.Add(-time.Minute)
+
+	in := SignedCorim{
+		UnsignedCorim:     *unsignedCorimFromCBOR(t, testGoodUnsignedCorimCBOR),
+		Meta:              *NewMeta().SetSigner("ACME Ltd.", nil).SetValidity(now.Add(time.Minute), &nbf),
+		SigningCert:       si.SigningCert,
+		IntermediateCerts: si.IntermediateCerts,
+	}
+
+	data, err := in.Sign(signer)
+	require.NoError(t, err)
+
+	var sc SignedCorim
+	require.NoError(t, sc.FromCOSE(data))
+
+	anchors := pkiTrustAnchors(pki)
+	anchors.CurrentTime = now
+
+	assert.NoError(t, sc.VerifyWithX5ChainAndPolicy(anchors, ValidityPolicy{}))
+
+	// the anchors are still valid, but the signature is not
+	anchors.CurrentTime = now.Add(30 * time.Minute)
+	err = sc.VerifyWithX5ChainAndPolicy(anchors, ValidityPolicy{})
+	assert.ErrorIs(t, err, ErrExpired)
+
+	assert.NoError(t, sc.VerifyWithX5ChainAndPolicy(anchors, ValidityPolicy{CurrentTime: now}))
+	assert.NoError(t, sc.VerifyWithX5ChainAndPolicy(anchors, ValidityPolicy{ClockSkew: time.Hour}))
+}
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"math/big"
 	"time"
+
+	"golang.org/x/crypto/ocsp"
 )
 
 // TrustAnchors holds trust material for x5chain validation.
@@ -27,13 +29,25 @@ import (
 //     [CrlPolicyStrict] also requires each matching CRL to carry a nextUpdate that
 //     has not passed.
 //
+// OCSP semantics:
+//   - no OCSPResponses (and none stapled to the signed-corim) — skip OCSP checks
+//   - otherwise — post-PKIX OCSP checks; [OcspPolicy] selects strict vs
+//     permissive behavior when the signing certificate has no matching response.
+//     Certificates with a valid "good" OCSP response are exempt from CRL checks.
+//
 // See [SignedCorim.VerifyWithX5Chain].
 type TrustAnchors struct {
 	Pool *x509.CertPool
 	CRLs []*x509.RevocationList
 	// CrlPolicy selects revocation behavior when CRLs is non-empty. Zero value is
 	// [CrlPolicyStrict] (OpenSSL CRL_CHECK_ALL).
-	CrlPolicy   CrlPolicy
+	CrlPolicy CrlPolicy
+	// OCSPResponses holds pre-fetched DER-encoded OCSP responses, e.g., loaded
+	// via [LoadOCSPResponses].
+	OCSPResponses [][]byte
+	// OcspPolicy selects OCSP behavior when OCSP responses are available. Zero
+	// value is [OcspPolicyStrict].
+	OcspPolicy  OcspPolicy
 	CurrentTime time.Time
 }
 
@@ -106,14 +120,27 @@ func checkCRLValidity(crl *x509.RevocationList, now time.Time, policy CrlPolicy)
 	return nil
 }
 
-func isSerialRevoked(serial *big.Int, crl *x509.RevocationList) bool {
-	for _, entry := range crl.RevokedCertificateEntries {
+func revokedEntry(serial *big.Int, crl *x509.RevocationList) *x509.RevocationListEntry {
+	for i, entry := range crl.RevokedCertificateEntries {
 		if entry.SerialNumber.Cmp(serial) == 0 {
-			return true
+			return &crl.RevokedCertificateEntries[i]
 		}
 	}
 
-	return false
+	return nil
+}
+
+// isRevocationEffective reports whether a revocation at revokedAt, for the
+// supplied RFC 5280 reason code, invalidates a signature made at signingTime.
+// A zero signingTime stands for an unknown signing time, in which case any
+// revocation is effective. A key compromise is effective regardless of time,
+// since the key may have been misused before the revocation.
+func isRevocationEffective(revokedAt time.Time, reason int, signingTime time.Time) bool {
+	if signingTime.IsZero() || reason == ocsp.KeyCompromise {
+		return true
+	}
+
+	return !revokedAt.After(signingTime)
 }
 
 func checkChainRevocation(
@@ -121,6 +148,8 @@ func checkChainRevocation(
 	crls []*x509.RevocationList,
 	policy CrlPolicy,
 	now time.Time,
+	signingTime time.Time,
+	skip map[*x509.Certificate]bool,
 ) error {
 	if len(crls) == 0 {
 		return nil
@@ -131,6 +160,11 @@ func checkChainRevocation(
 			break
 		}
 
+		// already known to be good from OCSP
+		if skip[cert] {
+			continue
+		}
+
 		issuer := chain[i+1]
 		issuerCRLs := filterCRLsForIssuer(issuer, crls)
 		if len(issuerCRLs) == 0 {
@@ -154,7 +188,8 @@ func checkChainRevocation(
 
 			validCRLFound = true
 
-			if isSerialRevoked(cert.SerialNumber, crl) {
+			entry := revokedEntry(cert.SerialNumber, crl)
+			if entry != nil && isRevocationEffective(entry.RevocationTime, entry.ReasonCode, signingTime) {
 				return fmt.Errorf("x5chain: certificate %q is revoked", cert.Subject)
 			}
 		}
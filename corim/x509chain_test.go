@@ -437,7 +437,7 @@ func TestCheckChainRevocation_revokedReportedBeforeExpiredCRL(t *testing.T) {
 	crls[0] = validCRL
 	crls = append(crls, expiredCRL)
 
-	err = checkChainRevocation(chain, crls, CrlPolicyStrict, time.Now())
+	err = checkChainRevocation(chain, crls, CrlPolicyStrict, time.Now(), time.Time{}, nil)
 	assert.ErrorContains(t, err, "revoked")
 	assert.NotContains(t, err.Error(), "has expired")
 }
@@ -458,7 +458,7 @@ func TestCheckChainRevocation_validCRLIgnoresExpiredSibling(t *testing.T) {
 	chain := []*x509.Certificate{pki.leaf, pki.intermediate, pki.root}
 	crls := append(makeValidChainCRLs(t, &pki), expiredCRL)
 
-	err = checkChainRevocation(chain, crls, CrlPolicyStrict, time.Now())
+	err = checkChainRevocation(chain, crls, CrlPolicyStrict, time.Now(), time.Time{}, nil)
 	assert.NoError(t, err)
 }
 
@@ -490,7 +490,7 @@ func TestCheckChainRevocation_allMatchingCRLsExpired(t *testing.T) {
 	crls[0] = expiredCRL1
 	crls = append(crls, expiredCRL2)
 
-	err = checkChainRevocation(chain, crls, CrlPolicyStrict, time.Now())
+	err = checkChainRevocation(chain, crls, CrlPolicyStrict, time.Now(), time.Time{}, nil)
 	assert.ErrorContains(t, err, "has expired")
 }
 
@@ -502,7 +502,7 @@ func TestCheckChainRevocation_strictRejectsMissingNextUpdate(t *testing.T) {
 
 	chain := []*x509.Certificate{pki.leaf, pki.intermediate, pki.root}
 
-	err := checkChainRevocation(chain, []*x509.RevocationList{crl}, CrlPolicyStrict, time.Now())
+	err := checkChainRevocation(chain, []*x509.RevocationList{crl}, CrlPolicyStrict, time.Now(), time.Time{}, nil)
 	assert.ErrorContains(t, err, "no nextUpdate")
 }
 
@@ -515,7 +515,7 @@ func TestCheckChainRevocation_permissiveAllowsMissingNextUpdate(t *testing.T) {
 	chain := []*x509.Certificate{pki.leaf, pki.intermediate, pki.root}
 	crls := []*x509.RevocationList{crl, makeValidCRL(t, pki.root, pki.rootKey)}
 
-	err := checkChainRevocation(chain, crls, CrlPolicyPermissive, time.Now())
+	err := checkChainRevocation(chain, crls, CrlPolicyPermissive, time.Now(), time.Time{}, nil)
 	assert.NoError(t, err)
 }
 
@@ -524,7 +524,7 @@ func TestCheckChainRevocation_skipsNilCRL(t *testing.T) {
 
 	chain := []*x509.Certificate{pki.leaf, pki.intermediate, pki.root}
 
-	err := checkChainRevocation(chain, append([]*x509.RevocationList{nil}, makeValidChainCRLs(t, &pki)...), CrlPolicyStrict, time.Now())
+	err := checkChainRevocation(chain, append([]*x509.RevocationList{nil}, makeValidChainCRLs(t, &pki)...), CrlPolicyStrict, time.Now(), time.Time{}, nil)
 	assert.NoError(t, err)
 }
 
@@ -568,7 +568,7 @@ func TestCheckChainRevocation_missingIssuerCRLFails(t *testing.T) {
 	crl, err := x509.ParseRevocationList(crlDER)
 	require.NoError(t, err)
 
-	err = checkChainRevocation([]*x509.Certificate{leaf, chainCA}, []*x509.RevocationList{crl}, CrlPolicyStrict, time.Now())
+	err = checkChainRevocation([]*x509.Certificate{leaf, chainCA}, []*x509.RevocationList{crl}, CrlPolicyStrict, time.Now(), time.Time{}, nil)
 	assert.ErrorContains(t, err, "unable to get certificate CRL")
 }
 
@@ -617,6 +617,8 @@ func TestCheckChainRevocation_permissiveSkipsMissingIssuerCRL(t *testing.T) {
 		[]*x509.RevocationList{crl},
 		CrlPolicyPermissive,
 		time.Now(),
+		time.Time{},
+		nil,
 	)
 	assert.NoError(t, err)
 }
@@ -626,7 +628,7 @@ func TestCheckChainRevocation_okWithFullChainCRLs(t *testing.T) {
 
 	chain := []*x509.Certificate{pki.leaf, pki.intermediate, pki.root}
 
-	err := checkChainRevocation(chain, makeValidChainCRLs(t, &pki), CrlPolicyStrict, time.Now())
+	err := checkChainRevocation(chain, makeValidChainCRLs(t, &pki), CrlPolicyStrict, time.Now(), time.Time{}, nil)
 	assert.NoError(t, err)
 }
 
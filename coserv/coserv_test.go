@@ -4,12 +4,17 @@
 package coserv
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/veraison/corim/comid"
+	"github.com/veraison/corim/corim"
 	"github.com/veraison/go-cose"
 )
 
@@ -383,6 +388,33 @@ func TestCoserv_signed_roundtrip(t *testing.T) {
 	assert.Equal(t, c0, c1)
 }
 
+func TestCoserv_signed_roundtrip_Ed25519_PEM(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+
+	signer, err := corim.NewSignerFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0)
+	require.NoError(t, err)
+
+	verifier, err := corim.NewVerifierFromPublicKey(pub, 0)
+	require.NoError(t, err)
+
+	var c0 Coserv
+	err = c0.FromCBOR(readTestVectorSlice(t, "rv-results.cbor"))
+	require.NoError(t, err)
+
+	signed, err := c0.Sign(signer)
+	require.NoError(t, err)
+
+	var c1 Coserv
+	err = c1.Verify(verifier, signed)
+	require.NoError(t, err)
+
+	assert.Equal(t, c0, c1)
+}
+
 func TestCMW_Signed_CBOR_Verify_phdr_failures(t *testing.T) {
 	tvs := []struct {
 		v []byte
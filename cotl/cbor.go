@@ -0,0 +1,44 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package cotl
+
+import (
+	cbor "github.com/fxamacker/cbor/v2"
+)
+
+var (
+	em, emError = initCBOREncMode()
+	dm, dmError = initCBORDecMode()
+)
+
+var (
+	CotlTag uint64 = 508
+)
+
+func initCBOREncMode() (en cbor.EncMode, err error) {
+	encOpt := cbor.EncOptions{
+		Sort:          cbor.SortCoreDeterministic,
+		IndefLength:   cbor.IndefLengthForbidden,
+		NilContainers: cbor.NilContainerAsEmpty,
+		TimeTag:       cbor.EncTagRequired,
+	}
+	return encOpt.EncMode()
+}
+
+func initCBORDecMode() (dm cbor.DecMode, err error) {
+	decOpt := cbor.DecOptions{
+		IndefLength: cbor.IndefLengthAllowed,
+		TimeTag:     cbor.DecTagRequired,
+	}
+	return decOpt.DecMode()
+}
+
+func init() {
+	if emError != nil {
+		panic(emError)
+	}
+	if dmError != nil {
+		panic(dmError)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package cotl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/veraison/corim/comid"
+	"github.com/veraison/swid"
+)
+
+// ConciseTagList is the concise-tl-tag: a list of the tags (identified by
+// their tag-identity) that are current during the validity period of the
+// list. Tags that are not listed by an applicable tag list are to be treated
+// as inactive, which allows expressing revocation.
+//
+// See https://ietf-rats-wg.github.io/draft-ietf-rats-corim/draft-ietf-rats-corim.html#section-6
+type ConciseTagList struct {
+	TagIdentity comid.TagIdentity   `cbor:"0,keyasint" json:"tag-identity"`
+	TagsList    []comid.TagIdentity `cbor:"1,keyasint" json:"tags-list"`
+	TlValidity  comid.Validity      `cbor:"2,keyasint" json:"tl-validity"`
+}
+
+// NewConciseTagList instantiates an empty ConciseTagList
+func NewConciseTagList() *ConciseTagList {
+	return &ConciseTagList{}
+}
+
+// SetTagIdentity sets the identifier of the target ConciseTagList to the
+// supplied tagID, which MUST be of type string or [16]byte, and tagIDVersion.
+func (o *ConciseTagList) SetTagIdentity(tagID interface{}, tagIDVersion uint) *ConciseTagList {
+	if o != nil {
+		id := swid.NewTagID(tagID)
+		if id == nil {
+			return nil
+		}
+		o.TagIdentity = comid.TagIdentity{TagID: *id, TagVersion: tagIDVersion}
+	}
+	return o
+}
+
+// AddTag appends the tag with the supplied tagID (string or [16]byte) and
+// tagIDVersion to the list of current tags
+func (o *ConciseTagList) AddTag(tagID interface{}, tagIDVersion uint) *ConciseTagList {
+	if o != nil {
+		id := swid.NewTagID(tagID)
+		if id == nil {
+			return nil
+		}
+		o.TagsList = append(o.TagsList, comid.TagIdentity{TagID: *id, TagVersion: tagIDVersion})
+	}
+	return o
+}
+
+// SetValidity sets the validity period of the target ConciseTagList to the
+// supplied time range
+func (o *ConciseTagList) SetValidity(notAfter time.Time, notBefore *time.Time) *ConciseTagList {
+	if o != nil {
+		v := comid.Validity{NotBefore: notBefore, NotAfter: notAfter}
+		if validTlValidity(v) != nil {
+			return nil
+		}
+		o.TlValidity = v
+	}
+	return o
+}
+
+// Contains returns true if the tag with the supplied identity is listed by
+// the target ConciseTagList. Both tag-id and tag-version must match.
+func (o ConciseTagList) Contains(ti comid.TagIdentity) bool {
+	for _, t := range o.TagsList {
+		if t.TagID.String() == ti.TagID.String() && t.TagVersion == ti.TagVersion {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsActive returns true if the tag with the supplied identity is listed by the
+// target ConciseTagList, and the list is valid at the supplied time.
+func (o ConciseTagList) IsActive(ti comid.TagIdentity, at time.Time) bool {
+	return o.TlValidity.CheckAt(at) == nil && o.Contains(ti)
+}
+
+// Valid checks the validity (according to the spec) of the target
+// ConciseTagList
+func (o ConciseTagList) Valid() error {
+	if err := o.TagIdentity.Valid(); err != nil {
+		return fmt.Errorf("tag-identity validation failed: %w", err)
+	}
+
+	if len(o.TagsList) == 0 {
+		return errors.New("tags-list validation failed: no tags")
+	}
+
+	for i, t := range o.TagsList {
+		if err := t.Valid(); err != nil {
+			return fmt.Errorf("tags-list validation failed at index %d: %w", i, err)
+		}
+	}
+
+	if err := validTlValidity(o.TlValidity); err != nil {
+		return fmt.Errorf("tl-validity validation failed: %w", err)
+	}
+
+	return nil
+}
+
+// validTlValidity checks a tl-validity, in which (unlike in the validity-map
+// of a CoRIM) not-after is mandatory
+func validTlValidity(v comid.Validity) error {
+	if v.NotAfter.IsZero() {
+		return errors.New("not-after must be set")
+	}
+
+	return v.Valid()
+}
+
+// ToCBOR serializes the target ConciseTagList to CBOR (if it is valid)
+func (o ConciseTagList) ToCBOR() ([]byte, error) {
+	if err := o.Valid(); err != nil {
+		return nil, err
+	}
+
+	return em.Marshal(o)
+}
+
+// FromCBOR deserializes a CBOR-encoded CoTL into the target ConciseTagList
+func (o *ConciseTagList) FromCBOR(data []byte) error {
+	return dm.Unmarshal(data, o)
+}
+
+// ToJSON serializes the target ConciseTagList to JSON (if it is valid)
+func (o ConciseTagList) ToJSON() ([]byte, error) {
+	if err := o.Valid(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(o)
+}
+
+// FromJSON deserializes a JSON-encoded CoTL into the target ConciseTagList
+func (o *ConciseTagList) FromJSON(data []byte) error {
+	return json.Unmarshal(data, o)
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package cotl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/comid"
+)
+
+var (
+	testNotBefore = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	testNotAfter  = time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func testTagIdentity(id string, version uint) comid.TagIdentity {
+	return comid.NewComid().SetTagIdentity(id, version).TagIdentity
+}
+
+func testConciseTagList() *ConciseTagList {
+	return NewConciseTagList().
+		SetTagIdentity("tag-list", 1).
+		AddTag("tag-a", 0).
+		AddTag("tag-b", 2).
+		SetValidity(testNotAfter, &testNotBefore)
+}
+
+func TestConciseTagList_Valid(t *testing.T) {
+	tl := testConciseTagList()
+	require.NotNil(t, tl)
+	assert.NoError(t, tl.Valid())
+
+	assert.EqualError(t, ConciseTagList{}.Valid(),
+		"tag-identity validation failed: empty tag-id")
+
+	tl = NewConciseTagList().SetTagIdentity("tag-list", 0).SetValidity(testNotAfter, nil)
+	assert.EqualError(t, tl.Valid(), "tags-list validation failed: no tags")
+
+	tl = NewConciseTagList().SetTagIdentity("tag-list", 0).AddTag("tag-a", 0)
+	assert.EqualError(t, tl.Valid(), "tl-validity validation failed: not-after must be set")
+
+	tl.TagsList = append(tl.TagsList, comid.TagIdentity{})
+	tl.SetValidity(testNotAfter, nil)
+	assert.EqualError(t, tl.Valid(), "tags-list validation failed at index 1: empty tag-id")
+
+	assert.Nil(t, NewConciseTagList().SetValidity(testNotBefore, &testNotAfter))
+	assert.Nil(t, NewConciseTagList().AddTag("", 0))
+	assert.Nil(t, NewConciseTagList().SetTagIdentity(42, 0))
+}
+
+func TestConciseTagList_CBOR_roundtrip(t *testing.T) {
+	tl := testConciseTagList()
+
+	data, err := tl.ToCBOR()
+	require.NoError(t, err)
+
+	var actual ConciseTagList
+	require.NoError(t, actual.FromCBOR(data))
+	assert.Equal(t, tl.TagIdentity, actual.TagIdentity)
+	assert.Equal(t, tl.TagsList, actual.TagsList)
+	assert.True(t, tl.TlValidity.NotAfter.Equal(actual.TlValidity.NotAfter))
+	require.NotNil(t, actual.TlValidity.NotBefore)
+	assert.True(t, tl.TlValidity.NotBefore.Equal(*actual.TlValidity.NotBefore))
+
+	_, err = ConciseTagList{}.ToCBOR()
+	assert.Error(t, err)
+
+	assert.Error(t, actual.FromCBOR([]byte{0xff}))
+}
+
+func TestConciseTagList_JSON_roundtrip(t *testing.T) {
+	tl := testConciseTagList()
+
+	data, err := tl.ToJSON()
+	require.NoError(t, err)
+
+	expected := `{
+		"tag-identity": {"id": "tag-list", "version": 1},
+		"tags-list": [
+			{"id": "tag-a"},
+			{"id": "tag-b", "version": 2}
+		],
+		"tl-validity": {
+			"not-before": "2026-01-01T00:00:00Z",
+			"not-after": "2027-01-01T00:00:00Z"
+		}
+	}`
+	assert.JSONEq(t, expected, string(data))
+
+	var actual ConciseTagList
+	require.NoError(t, actual.FromJSON([]byte(expected)))
+	assert.NoError(t, actual.Valid())
+	assert.Equal(t, tl.TagsList, actual.TagsList)
+
+	_, err = ConciseTagList{}.ToJSON()
+	assert.Error(t, err)
+}
+
+func TestConciseTagList_IsActive(t *testing.T) {
+	tl := testConciseTagList()
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, tl.Contains(testTagIdentity("tag-b", 2)))
+	assert.False(t, tl.Contains(testTagIdentity("tag-b", 1)))
+	assert.False(t, tl.Contains(testTagIdentity("tag-c", 0)))
+
+	assert.True(t, tl.IsActive(testTagIdentity("tag-a", 0), now))
+	assert.False(t, tl.IsActive(testTagIdentity("tag-c", 0), now))
+	assert.False(t, tl.IsActive(testTagIdentity("tag-a", 0), testNotAfter.Add(time.Second)))
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package cots
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/veraison/corim/comid"
+)
+
+// ConciseTaStoreBuilder builds a ConciseTaStore with the same methods as the
+// fluent ConciseTaStore setters, but instead of returning nil on failure, it
+// records the reason and carries on. Errors are reported by Err and Build.
+type ConciseTaStoreBuilder struct {
+	comid.BuildErrors
+	cots *ConciseTaStore
+}
+
+// NewConciseTaStoreBuilder instantiates a builder for an empty ConciseTaStore
+func NewConciseTaStoreBuilder() *ConciseTaStoreBuilder {
+	return &ConciseTaStoreBuilder{cots: NewConciseTaStore()}
+}
+
+// Build returns the built ConciseTaStore, or the errors recorded by the
+// builder. If there are none, the ConciseTaStore is validated.
+func (o *ConciseTaStoreBuilder) Build() (*ConciseTaStore, error) {
+	if err := o.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := o.cots.Valid(); err != nil {
+		return nil, fmt.Errorf("invalid cots: %w", err)
+	}
+
+	return o.cots, nil
+}
+
+// SetTagIdentity is like [ConciseTaStore.SetTagIdentity]
+func (o *ConciseTaStoreBuilder) SetTagIdentity(tagID interface{}, tagIDVersion *uint) *ConciseTaStoreBuilder {
+	o.Record("SetTagIdentity", o.cots.setTagIdentity(tagID, tagIDVersion))
+
+	return o
+}
+
+// SetLanguage is like [ConciseTaStore.SetLanguage], but also rejects an empty
+// language
+func (o *ConciseTaStoreBuilder) SetLanguage(language string) *ConciseTaStoreBuilder {
+	if language == "" {
+		o.Record("SetLanguage", errors.New("empty language"))
+		return o
+	}
+
+	o.Record("SetLanguage", o.cots.setLanguage(language))
+
+	return o
+}
+
+// AddEnvironmentGroup is like [ConciseTaStore.AddEnvironmentGroup], but also
+// rejects an invalid environment group
+func (o *ConciseTaStoreBuilder) AddEnvironmentGroup(eg EnvironmentGroup) *ConciseTaStoreBuilder {
+	if err := eg.Valid(); err != nil {
+		o.Record("AddEnvironmentGroup", fmt.Errorf("invalid environment group: %w", err))
+		return o
+	}
+
+	o.Record("AddEnvironmentGroup", o.cots.addEnvironmentGroup(eg))
+
+	return o
+}
+
+// AddPurpose is like [ConciseTaStore.AddPurpose], but also rejects an empty
+// purpose
+func (o *ConciseTaStoreBuilder) AddPurpose(purpose string) *ConciseTaStoreBuilder {
+	if purpose == "" {
+		o.Record("AddPurpose", errors.New("empty purpose"))
+		return o
+	}
+
+	o.Record("AddPurpose", o.cots.addPurpose(purpose))
+
+	return o
+}
+
+// AddPermClaims is like [ConciseTaStore.AddPermClaims]
+func (o *ConciseTaStoreBuilder) AddPermClaims(permclaim *EatCWTClaim) *ConciseTaStoreBuilder {
+	o.Record("AddPermClaims", o.cots.addPermClaims(permclaim))
+
+	return o
+}
+
+// AddExclClaims is like [ConciseTaStore.AddExclClaims]
+func (o *ConciseTaStoreBuilder) AddExclClaims(exclclaim *EatCWTClaim) *ConciseTaStoreBuilder {
+	o.Record("AddExclClaims", o.cots.addExclClaims(exclclaim))
+
+	return o
+}
+
+// SetKeys is like [ConciseTaStore.SetKeys], but also rejects invalid keys
+func (o *ConciseTaStoreBuilder) SetKeys(keys TasAndCas) *ConciseTaStoreBuilder {
+	if err := keys.Valid(); err != nil {
+		o.Record("SetKeys", err)
+		return o
+	}
+
+	o.Record("SetKeys", o.cots.setKeys(keys))
+
+	return o
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package cots
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/comid"
+)
+
+func TestConciseTaStoreBuilder_Build_OK(t *testing.T) {
+	vendor := "Worthless Sea, Inc."
+
+	c, err := NewConciseTaStoreBuilder().
+		SetTagIdentity(comid.TestUUIDString, nil).
+		SetLanguage("en-GB").
+		AddEnvironmentGroup(*NewEnvironmentGroup().SetEnvironment(comid.Environment{
+			Class: &comid.Class{Vendor: &vendor},
+		})).
+		AddPurpose("cots").
+		SetKeys(*NewTasAndCas().AddTaCert(ta)).
+		Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, "en-GB", *c.Language)
+	assert.Equal(t, []string{"cots"}, c.Purposes)
+	assert.Len(t, c.Environments, 1)
+	require.NotNil(t, c.Keys)
+	assert.Len(t, c.Keys.Tas, 1)
+}
+
+func TestConciseTaStoreBuilder_Build_accumulates_errors(t *testing.T) {
+	_, err := NewConciseTaStoreBuilder().
+		SetTagIdentity(42, nil).
+		AddPurpose("").
+		AddPermClaims(nil).
+		SetKeys(*NewTasAndCas().AddCaCert(ca)).
+		Build()
+	require.Error(t, err)
+
+	assert.ErrorContains(t, err, "SetTagIdentity (call 1): invalid tag-id: unsupported type int")
+	assert.ErrorContains(t, err, "AddPurpose (call 2): empty purpose")
+	assert.ErrorContains(t, err, "AddPermClaims (call 3): nil claims")
+	assert.ErrorContains(t, err, "SetKeys (call 4): ")
+}
+
+func TestConciseTaStoreBuilder_stricter_than_setters(t *testing.T) {
+	// the fluent setters accept these, only the builder rejects them
+	assert.NotNil(t, NewConciseTaStore().SetLanguage(""))
+	assert.NotNil(t, NewConciseTaStore().AddPurpose(""))
+	assert.NotNil(t, NewConciseTaStore().AddEnvironmentGroup(EnvironmentGroup{Environment: &comid.Environment{}}))
+	assert.NotNil(t, NewConciseTaStore().SetKeys(*NewTasAndCas()))
+
+	_, err := NewConciseTaStoreBuilder().
+		SetTagIdentity(comid.TestUUIDString, nil).
+		SetLanguage("").
+		AddEnvironmentGroup(EnvironmentGroup{Environment: &comid.Environment{}}).
+		Build()
+	require.Error(t, err)
+
+	assert.ErrorContains(t, err, "SetLanguage (call 2): empty language")
+	assert.ErrorContains(t, err, "AddEnvironmentGroup (call 3): invalid environment group: ")
+}
+
+func TestConciseTaStoreBuilder_Build_invalid_cots(t *testing.T) {
+	_, err := NewConciseTaStoreBuilder().
+		SetTagIdentity(comid.TestUUIDString, nil).
+		Build()
+	assert.ErrorContains(t, err, "invalid cots: ")
+}
@@ -9,7 +9,6 @@ import (
 	"fmt"
 
 	"github.com/veraison/corim/comid"
-	"github.com/veraison/swid"
 )
 
 type ConciseTaStore struct {
@@ -27,62 +26,113 @@ func NewConciseTaStore() *ConciseTaStore {
 }
 
 func (o *ConciseTaStore) SetTagIdentity(tagID interface{}, tagIDVersion *uint) *ConciseTaStore {
-	if o != nil {
-		id := swid.NewTagID(tagID)
-		if id == nil {
-			return nil
-		}
-		o.TagIdentity = &comid.TagIdentity{}
-		o.TagIdentity.TagID = *id
-		if tagIDVersion != nil {
-			o.TagIdentity.TagVersion = *tagIDVersion
-		}
+	if o != nil && o.setTagIdentity(tagID, tagIDVersion) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *ConciseTaStore) setTagIdentity(tagID interface{}, tagIDVersion *uint) error {
+	id, err := comid.ParseTagID(tagID)
+	if err != nil {
+		return err
+	}
+
+	o.TagIdentity = &comid.TagIdentity{}
+	o.TagIdentity.TagID = *id
+	if tagIDVersion != nil {
+		o.TagIdentity.TagVersion = *tagIDVersion
+	}
+
+	return nil
+}
+
 func (o *ConciseTaStore) SetLanguage(language string) *ConciseTaStore {
-	if o != nil {
-		o.Language = &language
+	if o != nil && o.setLanguage(language) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *ConciseTaStore) setLanguage(language string) error {
+	o.Language = &language
+
+	return nil
+}
+
 func (o *ConciseTaStore) AddEnvironmentGroup(eg EnvironmentGroup) *ConciseTaStore {
-	if o != nil {
-		o.Environments = append(o.Environments, eg)
+	if o != nil && o.addEnvironmentGroup(eg) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *ConciseTaStore) addEnvironmentGroup(eg EnvironmentGroup) error {
+	o.Environments = append(o.Environments, eg)
+
+	return nil
+}
+
 func (o *ConciseTaStore) AddPurpose(purpose string) *ConciseTaStore {
-	if o != nil {
-		o.Purposes = append(o.Purposes, purpose)
+	if o != nil && o.addPurpose(purpose) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *ConciseTaStore) addPurpose(purpose string) error {
+	o.Purposes = append(o.Purposes, purpose)
+
+	return nil
+}
+
 func (o *ConciseTaStore) AddPermClaims(permclaim *EatCWTClaim) *ConciseTaStore {
-	if o != nil {
-		o.PermClaims = append(o.PermClaims, *permclaim)
+	if o != nil && o.addPermClaims(permclaim) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *ConciseTaStore) addPermClaims(permclaim *EatCWTClaim) error {
+	if permclaim == nil {
+		return errors.New("nil claims")
+	}
+
+	o.PermClaims = append(o.PermClaims, *permclaim)
+
+	return nil
+}
+
 func (o *ConciseTaStore) AddExclClaims(exclclaim *EatCWTClaim) *ConciseTaStore {
-	if o != nil {
-		o.ExclClaims = append(o.ExclClaims, *exclclaim)
+	if o != nil && o.addExclClaims(exclclaim) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *ConciseTaStore) addExclClaims(exclclaim *EatCWTClaim) error {
+	if exclclaim == nil {
+		return errors.New("nil claims")
+	}
+
+	o.ExclClaims = append(o.ExclClaims, *exclclaim)
+
+	return nil
+}
+
 func (o *ConciseTaStore) SetKeys(keys TasAndCas) *ConciseTaStore {
-	if o != nil {
-		o.Keys = &keys
+	if o != nil && o.setKeys(keys) != nil {
+		return nil
 	}
 	return o
 }
 
+func (o *ConciseTaStore) setKeys(keys TasAndCas) error {
+	o.Keys = &keys
+
+	return nil
+}
+
 // ToCBOR serializes the target ConciseTaStore to CBOR.
 // nolint:gocritic
 func (o ConciseTaStore) ToCBOR() ([]byte, error) {
@@ -123,6 +173,33 @@ func (o ConciseTaStore) Valid() error {
 	return nil
 }
 
+// ValidateAll is like Valid, except that, rather than stopping at the first
+// violation, it returns all of them as a comid.ValidationErrors.
+// nolint:gocritic
+func (o ConciseTaStore) ValidateAll() error {
+	v := comid.NewValidator()
+
+	if o.TagIdentity != nil {
+		v.Field("tag-identity", 1).Check(o.TagIdentity.Valid())
+	}
+
+	ev := v.Field("environments", 2)
+
+	if o.Environments == nil {
+		ev.Check(errors.New("environmentGroups must be present"))
+	}
+
+	for i, e := range o.Environments {
+		ev.Index(i).Check(e.Valid())
+	}
+
+	if o.Keys == nil || len(o.Keys.Tas) == 0 {
+		v.Field("keys", 6).Check(errors.New("empty Keys"))
+	}
+
+	return v.Err()
+}
+
 // FromJSON deserializes a JSON-encoded CoTS into the target ConciseTaStore.
 func (o *ConciseTaStore) FromJSON(data []byte) error {
 	return json.Unmarshal(data, o)
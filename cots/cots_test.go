@@ -53,3 +53,17 @@ func TestConciseTaStores_Valid_bad_cots(t *testing.T) {
 	cotsList := ConciseTaStores{*NewConciseTaStore().AddPurpose("cots")}
 	assert.EqualError(t, cotsList.Valid(), "bad ConciseTaStore group at index 0: environmentGroups must be present")
 }
+
+func TestConciseTaStore_ValidateAll(t *testing.T) {
+	tv := NewConciseTaStore()
+	tv.TagIdentity = &comid.TagIdentity{}
+	tv.Environments = EnvironmentGroups{
+		{},
+		{Environment: &comid.Environment{}},
+	}
+
+	err := tv.ValidateAll()
+	assert.EqualError(t, err, "/tag-identity: empty tag-id\n"+
+		"/environments/1: environment group validation failed: environment must not be empty\n"+
+		"/keys: empty Keys")
+}
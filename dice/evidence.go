@@ -0,0 +1,158 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package dice
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/veraison/corim/coev"
+	"github.com/veraison/corim/comid"
+)
+
+// ChainToConciseEvidence converts a DICE certificate chain into a
+// ConciseEvidence. The chain is ordered from the leaf (Alias) certificate to
+// the root, as in an x5chain. Certificates that carry no DiceTcbInfo or
+// DiceMultiTcbInfo extension (e.g., vendor CAs) are skipped.
+//
+// Each TCB info produces one evidence triple, whose environment class is made
+// of vendor, model, layer, index and type (see TcbInfo.Class), and whose only
+// measurement carries version, svn, fwids, flags and vendorInfo (see
+// TcbInfo.Measurement). When a TCB info does not state its layer, the position
+// of its certificate among the DICE certificates, counting from the root side
+// and starting at 0, is used instead.
+//
+// The public key of the leaf certificate is added as an attest key triple for
+// the environment of the last TCB info of the leaf certificate.
+func ChainToConciseEvidence(chain []*x509.Certificate) (*coev.ConciseEvidence, error) {
+	certs, err := parseChain(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		evTriples coev.EvTriples
+		leafEnv   *comid.Environment
+	)
+
+	for _, c := range certs {
+		for j, t := range c.tcbInfos {
+			vt, err := valueTriple(t, false)
+			if err != nil {
+				return nil, fmt.Errorf("certificate at index %d: TCB info at index %d: %w", c.index, j, err)
+			}
+
+			evTriples.AddEvidenceTriple(vt)
+
+			if c.index == 0 {
+				leafEnv = &vt.Environment
+			}
+		}
+	}
+
+	if leafEnv == nil {
+		return nil, errors.New("leaf certificate carries no DICE TCB info")
+	}
+
+	key, err := pkixKey(chain[0])
+	if err != nil {
+		return nil, fmt.Errorf("leaf certificate: %w", err)
+	}
+
+	evTriples.AddAttestKeyTriple(&comid.KeyTriple{
+		Environment: *leafEnv,
+		VerifKeys:   *comid.NewCryptoKeys().Add(key),
+	})
+
+	ce := coev.NewConciseEvidence()
+	if err := ce.AddTriples(&evTriples); err != nil {
+		return nil, err
+	}
+
+	return ce, nil
+}
+
+// diceCert is a certificate of a DICE chain, together with its index in the
+// chain and the TCB infos it carries, all of which have their layer set
+type diceCert struct {
+	index    int
+	cert     *x509.Certificate
+	tcbInfos []TcbInfo
+}
+
+// parseChain returns the certificates of the supplied chain (leaf first) that
+// carry DICE TCB infos, root side first, defaulting the unstated layers to the
+// position of the certificate among them
+func parseChain(chain []*x509.Certificate) ([]diceCert, error) {
+	if len(chain) == 0 {
+		return nil, errors.New("empty certificate chain")
+	}
+
+	var ret []diceCert
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		tcbInfos, err := TcbInfosFromCert(chain[i])
+		if err != nil {
+			return nil, fmt.Errorf("certificate at index %d: %w", i, err)
+		}
+
+		if len(tcbInfos) == 0 {
+			continue
+		}
+
+		layer := uint64(len(ret))
+		for j := range tcbInfos {
+			if tcbInfos[j].Layer == nil {
+				tcbInfos[j].Layer = &layer
+			}
+		}
+
+		ret = append(ret, diceCert{index: i, cert: chain[i], tcbInfos: tcbInfos})
+	}
+
+	return ret, nil
+}
+
+func valueTriple(t TcbInfo, minSVN bool) (*comid.ValueTriple, error) {
+	class, err := t.Class()
+	if err != nil {
+		return nil, err
+	}
+
+	if class == nil {
+		return nil, errors.New("no environment class values")
+	}
+
+	m, err := t.Measurement(minSVN)
+	if err != nil {
+		return nil, err
+	}
+
+	if m == nil {
+		return nil, errors.New("no measurement values")
+	}
+
+	vt := &comid.ValueTriple{
+		Environment: comid.Environment{Class: class},
+	}
+	vt.Measurements.Add(m)
+
+	return vt, nil
+}
+
+// pkixKey returns the public key of the supplied certificate as a PEM-encoded
+// SubjectPublicKeyInfo
+func pkixKey(cert *x509.Certificate) (*comid.CryptoKey, error) {
+	der, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("encoding public key: %w", err)
+	}
+
+	return comid.NewPKIXBase64Key(string(pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: der,
+	})))
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package dice
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/coev"
+	"github.com/veraison/corim/comid"
+)
+
+func TestChainToConciseEvidence(t *testing.T) {
+	chain := testChain(t)
+
+	ce, err := ChainToConciseEvidence(chain)
+	require.NoError(t, err)
+	require.NoError(t, ce.Valid())
+
+	require.NotNil(t, ce.EvTriples.EvidenceTriples)
+	triples := ce.EvTriples.EvidenceTriples.Values
+	require.Len(t, triples, 2)
+
+	// layer 0, from the DeviceID certificate
+	l0 := triples[0]
+	require.NotNil(t, l0.Environment.Class)
+	assert.Equal(t, "Roadrunner L0", l0.Environment.Class.GetModel())
+	assert.Equal(t, uint64(0), *l0.Environment.Class.Layer)
+	require.Len(t, l0.Measurements.Values, 1)
+	assert.Equal(t, comid.MustNewTaggedSVN(uint64(7)), l0.Measurements.Values[0].Val.SVN)
+
+	// layer 1, from the Alias certificate, which does not state its layer
+	l1 := triples[1]
+	require.NotNil(t, l1.Environment.Class)
+	assert.Equal(t, "ACME", l1.Environment.Class.GetVendor())
+	assert.Equal(t, "Roadrunner L1", l1.Environment.Class.GetModel())
+	require.NotNil(t, l1.Environment.Class.Layer)
+	assert.Equal(t, uint64(1), *l1.Environment.Class.Layer)
+	require.Len(t, l1.Measurements.Values, 1)
+	m := l1.Measurements.Values[0]
+	assert.Equal(t, comid.MustNewTaggedSVN(uint64(2)), m.Val.SVN)
+	assert.Equal(t, comid.Digests{*comid.NewDigestIntAlg(comid.Sha384, testFWID1)}, *m.Val.Digests)
+	require.NotNil(t, m.Val.Flags)
+	assert.Equal(t, &comid.False, m.Val.Flags.IsDebug)
+	assert.Nil(t, m.Val.Flags.IsSecure)
+
+	// the Alias key is the attestation key of the last layer
+	require.NotNil(t, ce.EvTriples.AttestKeysTriples)
+	require.Len(t, *ce.EvTriples.AttestKeysTriples, 1)
+	akt := (*ce.EvTriples.AttestKeysTriples)[0]
+	assert.Equal(t, l1.Environment, akt.Environment)
+	require.Len(t, akt.VerifKeys, 1)
+	pk, err := akt.VerifKeys[0].PublicKey()
+	require.NoError(t, err)
+	assert.Equal(t, chain[0].PublicKey, pk)
+
+	// round trip
+	data, err := ce.ToCBOR()
+	require.NoError(t, err)
+
+	var out coev.ConciseEvidence
+	require.NoError(t, out.FromCBOR(data))
+	assert.Len(t, out.EvTriples.EvidenceTriples.Values, 2)
+}
+
+func TestChainToConciseEvidence_NOK(t *testing.T) {
+	chain := testChain(t)
+
+	_, err := ChainToConciseEvidence(nil)
+	assert.EqualError(t, err, "empty certificate chain")
+
+	// the leaf must describe a DICE layer
+	_, err = ChainToConciseEvidence(chain[1:])
+	require.NoError(t, err)
+	_, err = ChainToConciseEvidence(chain[2:])
+	assert.EqualError(t, err, "leaf certificate carries no DICE TCB info")
+
+	// TCB info with no measurement values
+	noValues, _ := testCert(t, 5, nil, nil,
+		pkix.Extension{Id: OIDTcbInfo, Value: testTcbInfoDER(t, tcbInfo{Model: "empty"})})
+	_, err = ChainToConciseEvidence(append([]*x509.Certificate{noValues}, chain[1:]...))
+	assert.EqualError(t, err, "certificate at index 0: TCB info at index 0: no measurement values")
+
+	bad, _ := testCert(t, 6, nil, nil, pkix.Extension{Id: OIDMultiTcbInfo, Value: []byte{0x30, 0x00}})
+	_, err = ChainToConciseEvidence(append([]*x509.Certificate{chain[0]}, bad))
+	assert.EqualError(t, err, "certificate at index 1: DiceMultiTcbInfo extension: empty DiceMultiTcbInfo")
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package dice
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/veraison/corim/comid"
+)
+
+// ReferenceOptions controls the generation of CoMID reference values from DICE
+// TCB infos. With MinSVN, the svn of each TCB info is set as a min-svn.
+type ReferenceOptions = comid.ReferenceOptions
+
+// ChainToComid generates a CoMID with reference values from a golden DICE
+// certificate chain, ordered from the leaf (Alias) certificate to the root.
+// Triples are built as in ChainToConciseEvidence, one reference value triple
+// per TCB info.
+//
+// Moreover, the public key of each certificate carrying TCB infos (i.e., the
+// DeviceID and Alias keys) is added as an attestation verification key for the
+// environment of the last TCB info of that certificate.
+func ChainToComid(chain []*x509.Certificate, opts ReferenceOptions) (*comid.Comid, error) {
+	certs, err := parseChain(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.New("no DICE TCB info found in the certificate chain")
+	}
+
+	c, err := opts.NewComid()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dc := range certs {
+		var env *comid.Environment
+
+		for j, t := range dc.tcbInfos {
+			vt, err := valueTriple(t, opts.MinSVN)
+			if err != nil {
+				return nil, fmt.Errorf("certificate at index %d: TCB info at index %d: %w", dc.index, j, err)
+			}
+
+			c.AddReferenceValue(vt)
+			env = &vt.Environment
+		}
+
+		key, err := pkixKey(dc.cert)
+		if err != nil {
+			return nil, fmt.Errorf("certificate at index %d: %w", dc.index, err)
+		}
+
+		c.AddAttestVerifKey(&comid.KeyTriple{
+			Environment: *env,
+			VerifKeys:   *comid.NewCryptoKeys().Add(key),
+		})
+	}
+
+	if err := c.Valid(); err != nil {
+		return nil, fmt.Errorf("generated CoMID is not valid: %w", err)
+	}
+
+	return c, nil
+}
+
+// TcbInfosToComid generates a CoMID with one reference value triple for each
+// of the supplied TCB infos (see ChainToComid). TCB infos that do not state
+// their layer are assigned their position in the slice.
+func TcbInfosToComid(tcbInfos []TcbInfo, opts ReferenceOptions) (*comid.Comid, error) {
+	if len(tcbInfos) == 0 {
+		return nil, errors.New("no TCB info")
+	}
+
+	c, err := opts.NewComid()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, t := range tcbInfos {
+		if t.Layer == nil {
+			layer := uint64(i)
+			t.Layer = &layer
+		}
+
+		vt, err := valueTriple(t, opts.MinSVN)
+		if err != nil {
+			return nil, fmt.Errorf("TCB info at index %d: %w", i, err)
+		}
+
+		c.AddReferenceValue(vt)
+	}
+
+	if err := c.Valid(); err != nil {
+		return nil, fmt.Errorf("generated CoMID is not valid: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package dice
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/comid"
+)
+
+func TestChainToComid(t *testing.T) {
+	chain := testChain(t)
+
+	c, err := ChainToComid(chain, ReferenceOptions{TagID: "dice-golden", TagVersion: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, "dice-golden", c.TagIdentity.TagID.String())
+	assert.Equal(t, uint(1), c.TagIdentity.TagVersion)
+
+	require.NotNil(t, c.Triples.ReferenceValues)
+	refVals := c.Triples.ReferenceValues.Values
+	require.Len(t, refVals, 2)
+	assert.Equal(t, "Roadrunner L0", refVals[0].Environment.Class.GetModel())
+	assert.Equal(t, uint64(1), *refVals[1].Environment.Class.Layer)
+	assert.Equal(t, comid.MustNewTaggedSVN(uint64(2)),
+		refVals[1].Measurements.Values[0].Val.SVN)
+
+	// DeviceID and Alias keys, each for the layer of its certificate
+	require.NotNil(t, c.Triples.AttestVerifKeys)
+	keys := *c.Triples.AttestVerifKeys
+	require.Len(t, keys, 2)
+
+	for i, cert := range []*x509.Certificate{chain[1], chain[0]} {
+		assert.Equal(t, refVals[i].Environment, keys[i].Environment)
+		require.Len(t, keys[i].VerifKeys, 1)
+		pk, err := keys[i].VerifKeys[0].PublicKey()
+		require.NoError(t, err)
+		assert.Equal(t, cert.PublicKey, pk)
+	}
+
+	// round trip
+	data, err := c.ToCBOR()
+	require.NoError(t, err)
+
+	var out comid.Comid
+	require.NoError(t, out.FromCBOR(data))
+	require.NoError(t, out.Valid())
+}
+
+func TestChainToComid_min_svn(t *testing.T) {
+	c, err := ChainToComid(testChain(t), ReferenceOptions{TagID: "dice-golden", MinSVN: true})
+	require.NoError(t, err)
+
+	refVals := c.Triples.ReferenceValues.Values
+	assert.Equal(t, comid.MustNewTaggedMinSVN(uint64(7)), refVals[0].Measurements.Values[0].Val.SVN)
+	assert.Equal(t, comid.MustNewTaggedMinSVN(uint64(2)), refVals[1].Measurements.Values[0].Val.SVN)
+}
+
+func TestChainToComid_NOK(t *testing.T) {
+	chain := testChain(t)
+
+	_, err := ChainToComid(nil, ReferenceOptions{TagID: "x"})
+	assert.EqualError(t, err, "empty certificate chain")
+
+	_, err = ChainToComid(chain[2:], ReferenceOptions{TagID: "x"})
+	assert.EqualError(t, err, "no DICE TCB info found in the certificate chain")
+
+	_, err = ChainToComid(chain, ReferenceOptions{TagID: 1})
+	assert.EqualError(t, err, "invalid tag ID: 1 (int)")
+}
+
+func TestTcbInfosToComid(t *testing.T) {
+	l0, err := ParseTcbInfo(testTcbInfoDER(t, testLayer0TcbInfo(t)))
+	require.NoError(t, err)
+	l1, err := ParseTcbInfo(testTcbInfoDER(t, testLayer1TcbInfo(t)))
+	require.NoError(t, err)
+
+	c, err := TcbInfosToComid([]TcbInfo{*l0, *l1}, ReferenceOptions{TagID: "dice-golden"})
+	require.NoError(t, err)
+
+	refVals := c.Triples.ReferenceValues.Values
+	require.Len(t, refVals, 2)
+	assert.Equal(t, uint64(0), *refVals[0].Environment.Class.Layer)
+	// the layer defaults to the position in the slice
+	assert.Equal(t, uint64(1), *refVals[1].Environment.Class.Layer)
+	assert.Nil(t, l1.Layer)
+	assert.Nil(t, c.Triples.AttestVerifKeys)
+
+	_, err = TcbInfosToComid(nil, ReferenceOptions{TagID: "dice-golden"})
+	assert.EqualError(t, err, "no TCB info")
+
+	_, err = TcbInfosToComid([]TcbInfo{*l0, {Model: l1.Model}}, ReferenceOptions{TagID: "dice-golden"})
+	assert.EqualError(t, err, "TCB info at index 1: no measurement values")
+}
@@ -0,0 +1,403 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dice decodes the TCG DICE DiceTcbInfo and DiceMultiTcbInfo X.509
+// extensions and maps DICE certificate chains to Concise Evidence, so that DICE
+// devices can be appraised against CoMID reference values. The same mapping is
+// used to generate such reference values from golden chains or TCB infos.
+package dice
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/veraison/corim/comid"
+)
+
+// OIDs of the DICE X.509 extensions, from the TCG DICE Attestation Architecture
+var (
+	OIDTcbInfo      = asn1.ObjectIdentifier{2, 23, 133, 5, 4, 1}
+	OIDMultiTcbInfo = asn1.ObjectIdentifier{2, 23, 133, 5, 4, 5}
+)
+
+// Bit positions in OperationalFlags
+const (
+	FlagNotConfigured = iota
+	FlagNotSecure
+	FlagRecovery
+	FlagDebug
+	FlagNotReplayProtected
+	FlagNotIntegrityProtected
+	FlagNotRuntimeMeasured
+	FlagNotImmutable
+	FlagNotTcb
+)
+
+// FWID is a firmware identifier: the digest of a TCB component, together with
+// the OID of the hash algorithm used to compute it
+type FWID struct {
+	HashAlg asn1.ObjectIdentifier
+	Digest  []byte
+}
+
+// TcbInfo is a decoded DiceTcbInfo. Absent optional fields are nil.
+//
+//	DiceTcbInfo ::= SEQUENCE {
+//	  vendor [0] IMPLICIT UTF8String OPTIONAL,
+//	  model [1] IMPLICIT UTF8String OPTIONAL,
+//	  version [2] IMPLICIT UTF8String OPTIONAL,
+//	  svn [3] IMPLICIT INTEGER OPTIONAL,
+//	  layer [4] IMPLICIT INTEGER OPTIONAL,
+//	  index [5] IMPLICIT INTEGER OPTIONAL,
+//	  fwids [6] IMPLICIT FWIDLIST OPTIONAL,
+//	  flags [7] IMPLICIT OperationalFlags OPTIONAL,
+//	  vendorInfo [8] IMPLICIT OCTET STRING OPTIONAL,
+//	  type [9] IMPLICIT OCTET STRING OPTIONAL,
+//	  flagsMask [10] IMPLICIT OperationalFlagsMask OPTIONAL,
+//	  integrityRegisters [11] IMPLICIT IrList OPTIONAL
+//	}
+//
+// Integrity registers are skipped.
+type TcbInfo struct {
+	Vendor     *string
+	Model      *string
+	Version    *string
+	SVN        *uint64
+	Layer      *uint64
+	Index      *uint64
+	FWIDs      []FWID
+	Flags      *asn1.BitString
+	VendorInfo []byte
+	Type       []byte
+	FlagsMask  *asn1.BitString
+}
+
+// tcbInfo is the ASN.1 representation of DiceTcbInfo
+type tcbInfo struct {
+	Vendor             string        `asn1:"optional,tag:0,utf8"`
+	Model              string        `asn1:"optional,tag:1,utf8"`
+	Version            string        `asn1:"optional,tag:2,utf8"`
+	SVN                *big.Int      `asn1:"optional,tag:3"`
+	Layer              *big.Int      `asn1:"optional,tag:4"`
+	Index              *big.Int      `asn1:"optional,tag:5"`
+	FWIDs              []fwid        `asn1:"optional,tag:6"`
+	Flags              asn1.RawValue `asn1:"optional,tag:7"`
+	VendorInfo         []byte        `asn1:"optional,tag:8"`
+	Type               []byte        `asn1:"optional,tag:9"`
+	FlagsMask          asn1.RawValue `asn1:"optional,tag:10"`
+	IntegrityRegisters asn1.RawValue `asn1:"optional,tag:11"`
+}
+
+type fwid struct {
+	HashAlg asn1.ObjectIdentifier
+	Digest  []byte
+}
+
+// ParseTcbInfo decodes a DER-encoded DiceTcbInfo (the value of an OIDTcbInfo
+// extension)
+func ParseTcbInfo(der []byte) (*TcbInfo, error) {
+	var t tcbInfo
+
+	rest, err := asn1.Unmarshal(der, &t)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%d trailing bytes after DiceTcbInfo", len(rest))
+	}
+
+	return t.decode()
+}
+
+// ParseMultiTcbInfo decodes a DER-encoded DiceMultiTcbInfo (the value of an
+// OIDMultiTcbInfo extension)
+func ParseMultiTcbInfo(der []byte) ([]TcbInfo, error) {
+	var ts []tcbInfo
+
+	rest, err := asn1.Unmarshal(der, &ts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%d trailing bytes after DiceMultiTcbInfo", len(rest))
+	}
+
+	if len(ts) == 0 {
+		return nil, errors.New("empty DiceMultiTcbInfo")
+	}
+
+	ret := make([]TcbInfo, 0, len(ts))
+
+	for i, t := range ts {
+		ti, err := t.decode()
+		if err != nil {
+			return nil, fmt.Errorf("DiceTcbInfo at index %d: %w", i, err)
+		}
+
+		ret = append(ret, *ti)
+	}
+
+	return ret, nil
+}
+
+// TcbInfosFromCert returns the TCB infos found in the DiceTcbInfo and
+// DiceMultiTcbInfo extensions of the supplied certificate, in the order in
+// which the extensions appear. An empty slice is returned if the certificate
+// carries neither extension.
+func TcbInfosFromCert(cert *x509.Certificate) ([]TcbInfo, error) {
+	var ret []TcbInfo
+
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(OIDTcbInfo):
+			t, err := ParseTcbInfo(ext.Value)
+			if err != nil {
+				return nil, fmt.Errorf("DiceTcbInfo extension: %w", err)
+			}
+
+			ret = append(ret, *t)
+		case ext.Id.Equal(OIDMultiTcbInfo):
+			ts, err := ParseMultiTcbInfo(ext.Value)
+			if err != nil {
+				return nil, fmt.Errorf("DiceMultiTcbInfo extension: %w", err)
+			}
+
+			ret = append(ret, ts...)
+		}
+	}
+
+	return ret, nil
+}
+
+func (o tcbInfo) decode() (*TcbInfo, error) {
+	var (
+		t   TcbInfo
+		err error
+	)
+
+	t.Vendor = optionalString(o.Vendor)
+	t.Model = optionalString(o.Model)
+	t.Version = optionalString(o.Version)
+
+	if t.SVN, err = optionalUint(o.SVN); err != nil {
+		return nil, fmt.Errorf("svn: %w", err)
+	}
+
+	if t.Layer, err = optionalUint(o.Layer); err != nil {
+		return nil, fmt.Errorf("layer: %w", err)
+	}
+
+	if t.Index, err = optionalUint(o.Index); err != nil {
+		return nil, fmt.Errorf("index: %w", err)
+	}
+
+	for _, f := range o.FWIDs {
+		t.FWIDs = append(t.FWIDs, FWID(f))
+	}
+
+	if t.Flags, err = optionalBitString(o.Flags, 7); err != nil {
+		return nil, fmt.Errorf("flags: %w", err)
+	}
+
+	if t.FlagsMask, err = optionalBitString(o.FlagsMask, 10); err != nil {
+		return nil, fmt.Errorf("flagsMask: %w", err)
+	}
+
+	t.VendorInfo = o.VendorInfo
+	t.Type = o.Type
+
+	return &t, nil
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+// optionalBitString decodes an implicitly tagged BIT STRING. Flags are kept as
+// raw values while parsing the DiceTcbInfo, so that a present but empty BIT
+// STRING (i.e., no flag set) can be told apart from an absent one.
+func optionalBitString(raw asn1.RawValue, tag int) (*asn1.BitString, error) {
+	if len(raw.FullBytes) == 0 {
+		return nil, nil
+	}
+
+	var bs asn1.BitString
+
+	if _, err := asn1.UnmarshalWithParams(raw.FullBytes, &bs, fmt.Sprintf("tag:%d", tag)); err != nil {
+		return nil, err
+	}
+
+	return &bs, nil
+}
+
+func optionalUint(i *big.Int) (*uint64, error) {
+	if i == nil {
+		return nil, nil
+	}
+
+	if i.Sign() < 0 || !i.IsUint64() {
+		return nil, fmt.Errorf("%s out of range", i)
+	}
+
+	u := i.Uint64()
+
+	return &u, nil
+}
+
+// Class returns the class of the environment described by the target
+// TcbInfo: vendor, model, layer and index, and type, if present, as a
+// tagged-bytes class ID. Nil is returned if none of them is set.
+func (o TcbInfo) Class() (*comid.Class, error) {
+	class := &comid.Class{
+		Vendor: o.Vendor,
+		Model:  o.Model,
+		Layer:  o.Layer,
+		Index:  o.Index,
+	}
+
+	if len(o.Type) != 0 {
+		classID, err := comid.NewBytesClassID(o.Type)
+		if err != nil {
+			return nil, fmt.Errorf("type: %w", err)
+		}
+
+		class.ClassID = classID
+	}
+
+	if class.ClassID == nil && class.Vendor == nil && class.Model == nil &&
+		class.Layer == nil && class.Index == nil {
+		return nil, nil
+	}
+
+	return class, nil
+}
+
+// FlagsMap maps the operational flags of the target TcbInfo to a
+// comid.FlagsMap. If a flags mask is present, only the flags selected by it
+// are set. Nil is returned if the TcbInfo has no flags.
+func (o TcbInfo) FlagsMap() *comid.FlagsMap {
+	if o.Flags == nil {
+		return nil
+	}
+
+	mapping := []struct {
+		bit      int
+		flag     comid.Flag
+		negative bool
+	}{
+		{FlagNotConfigured, comid.FlagIsConfigured, true},
+		{FlagNotSecure, comid.FlagIsSecure, true},
+		{FlagRecovery, comid.FlagIsRecovery, false},
+		{FlagDebug, comid.FlagIsDebug, false},
+		{FlagNotReplayProtected, comid.FlagIsReplayProtected, true},
+		{FlagNotIntegrityProtected, comid.FlagIsIntegrityProtected, true},
+		{FlagNotRuntimeMeasured, comid.FlagIsRuntimeMeasured, true},
+		{FlagNotImmutable, comid.FlagIsImmutable, true},
+		{FlagNotTcb, comid.FlagIsTcb, true},
+	}
+
+	flags := comid.NewFlagsMap()
+
+	for _, m := range mapping {
+		if o.FlagsMask != nil && o.FlagsMask.At(m.bit) == 0 {
+			continue
+		}
+
+		if (o.Flags.At(m.bit) == 1) != m.negative {
+			flags.SetTrue(m.flag)
+		} else {
+			flags.SetFalse(m.flag)
+		}
+	}
+
+	if !flags.AnySet() {
+		return nil
+	}
+
+	return flags
+}
+
+// Measurement returns a measurement carrying the version, svn, fwids (as
+// digests), flags and vendorInfo (as raw value) of the target TcbInfo. If
+// minSVN is true, the svn is set as a min-svn, as appropriate for reference
+// values. Nil is returned if none of them is set.
+func (o TcbInfo) Measurement(minSVN bool) (*comid.Measurement, error) {
+	m := &comid.Measurement{}
+	empty := true
+
+	if o.Version != nil {
+		m.Val.Ver = comid.NewVersion().SetVersion(*o.Version)
+		empty = false
+	}
+
+	if o.SVN != nil {
+		if minSVN {
+			m.SetMinSVN(*o.SVN)
+		} else {
+			m.SetSVN(*o.SVN)
+		}
+		empty = false
+	}
+
+	for i, f := range o.FWIDs {
+		alg, ok := hashAlgFromOID(f.HashAlg)
+		if !ok {
+			return nil, fmt.Errorf("fwid at index %d: unsupported hash algorithm %s", i, f.HashAlg)
+		}
+
+		if err := comid.NewDigestIntAlg(alg, f.Digest).Valid(); err != nil {
+			return nil, fmt.Errorf("fwid at index %d: %w", i, err)
+		}
+
+		m.AddDigest(alg, f.Digest)
+		empty = false
+	}
+
+	if flags := o.FlagsMap(); flags != nil {
+		m.Val.Flags = flags
+		empty = false
+	}
+
+	if len(o.VendorInfo) != 0 {
+		m.SetRawValueBytes(o.VendorInfo, nil)
+		empty = false
+	}
+
+	if empty {
+		return nil, nil
+	}
+
+	return m, nil
+}
+
+var oidToHashAlg = []struct {
+	oid asn1.ObjectIdentifier
+	alg int
+}{
+	{asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}, comid.Sha256},
+	{asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}, comid.Sha384},
+	{asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}, comid.Sha512},
+	{asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 7}, comid.Sha3_224},
+	{asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 8}, comid.Sha3_256},
+	{asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 9}, comid.Sha3_384},
+	{asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 10}, comid.Sha3_512},
+}
+
+func hashAlgFromOID(oid asn1.ObjectIdentifier) (int, bool) {
+	for _, e := range oidToHashAlg {
+		if e.oid.Equal(oid) {
+			return e.alg, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,312 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package dice
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/comid"
+)
+
+var (
+	testOIDSHA256 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	testOIDSHA384 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	testOIDMD5    = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 5}
+
+	testFWID0 = []byte{
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+		0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20,
+	}
+	testFWID1 = make([]byte, 48)
+)
+
+// testBitString returns a DER-style BIT STRING with the supplied bits set
+// (trailing zero bits are removed, as DER requires for named bit lists)
+func testBitString(bits ...int) asn1.BitString {
+	var bs asn1.BitString
+
+	for _, b := range bits {
+		if b+1 > bs.BitLength {
+			bs.BitLength = b + 1
+		}
+	}
+
+	bs.Bytes = make([]byte, (bs.BitLength+7)/8)
+	for _, b := range bits {
+		bs.Bytes[b/8] |= 0x80 >> (b % 8)
+	}
+
+	return bs
+}
+
+func testTaggedBitString(t *testing.T, bs asn1.BitString, tag int) asn1.RawValue {
+	der, err := asn1.MarshalWithParams(bs, fmt.Sprintf("tag:%d", tag))
+	require.NoError(t, err)
+
+	return asn1.RawValue{FullBytes: der}
+}
+
+func testTcbInfoDER(t *testing.T, ti tcbInfo) []byte {
+	der, err := asn1.Marshal(ti)
+	require.NoError(t, err)
+
+	return der
+}
+
+// testLayer0TcbInfo describes the first mutable layer of a DICE device
+func testLayer0TcbInfo(t *testing.T) tcbInfo {
+	return tcbInfo{
+		Vendor:  "ACME",
+		Model:   "Roadrunner L0",
+		Version: "1.2.3",
+		SVN:     big.NewInt(7),
+		Layer:   big.NewInt(0),
+		FWIDs: []fwid{
+			{HashAlg: testOIDSHA256, Digest: testFWID0},
+		},
+		// not configured & debug
+		Flags:      testTaggedBitString(t, testBitString(FlagNotConfigured, FlagDebug), 7),
+		VendorInfo: []byte{0xde, 0xad},
+		Type:       []byte{0x4c, 0x30},
+	}
+}
+
+// testLayer1TcbInfo describes the second layer of a DICE device; it does not
+// state its layer
+func testLayer1TcbInfo(t *testing.T) tcbInfo {
+	return tcbInfo{
+		Vendor: "ACME",
+		Model:  "Roadrunner L1",
+		SVN:    big.NewInt(2),
+		FWIDs: []fwid{
+			{HashAlg: testOIDSHA384, Digest: testFWID1},
+		},
+		// nothing set, but only the debug and recovery flags are meaningful
+		Flags:     testTaggedBitString(t, asn1.BitString{}, 7),
+		FlagsMask: testTaggedBitString(t, testBitString(FlagRecovery, FlagDebug), 10),
+	}
+}
+
+func testCert(
+	t *testing.T, serial int64, issuer *x509.Certificate, issuerKey *ecdsa.PrivateKey, exts ...pkix.Extension,
+) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: fmt.Sprintf("test %d", serial)},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtraExtensions:       exts,
+	}
+
+	if issuer == nil {
+		issuer, issuerKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, issuer, &key.PublicKey, issuerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+// testChain returns a vendor root, a DeviceID certificate carrying the layer 0
+// TCB info and an Alias certificate carrying a multi TCB info with the layer 1
+// TCB info, leaf first
+func testChain(t *testing.T) []*x509.Certificate {
+	multi, err := asn1.Marshal([]tcbInfo{testLayer1TcbInfo(t)})
+	require.NoError(t, err)
+
+	root, rootKey := testCert(t, 1, nil, nil)
+	deviceID, deviceIDKey := testCert(t, 2, root, rootKey,
+		pkix.Extension{Id: OIDTcbInfo, Value: testTcbInfoDER(t, testLayer0TcbInfo(t))})
+	alias, _ := testCert(t, 3, deviceID, deviceIDKey,
+		pkix.Extension{Id: OIDMultiTcbInfo, Value: multi})
+
+	return []*x509.Certificate{alias, deviceID, root}
+}
+
+func TestTcbInfo_ParseTcbInfo(t *testing.T) {
+	ti, err := ParseTcbInfo(testTcbInfoDER(t, testLayer0TcbInfo(t)))
+	require.NoError(t, err)
+
+	require.NotNil(t, ti.Vendor)
+	assert.Equal(t, "ACME", *ti.Vendor)
+	require.NotNil(t, ti.Model)
+	assert.Equal(t, "Roadrunner L0", *ti.Model)
+	require.NotNil(t, ti.Version)
+	assert.Equal(t, "1.2.3", *ti.Version)
+	require.NotNil(t, ti.SVN)
+	assert.Equal(t, uint64(7), *ti.SVN)
+	require.NotNil(t, ti.Layer)
+	assert.Equal(t, uint64(0), *ti.Layer)
+	assert.Nil(t, ti.Index)
+	assert.Equal(t, []FWID{{HashAlg: testOIDSHA256, Digest: testFWID0}}, ti.FWIDs)
+	require.NotNil(t, ti.Flags)
+	assert.Equal(t, 1, ti.Flags.At(FlagNotConfigured))
+	assert.Equal(t, 1, ti.Flags.At(FlagDebug))
+	assert.Equal(t, 0, ti.Flags.At(FlagNotSecure))
+	assert.Nil(t, ti.FlagsMask)
+	assert.Equal(t, []byte{0xde, 0xad}, ti.VendorInfo)
+	assert.Equal(t, []byte{0x4c, 0x30}, ti.Type)
+}
+
+func TestTcbInfo_ParseTcbInfo_empty_flags(t *testing.T) {
+	ti, err := ParseTcbInfo(testTcbInfoDER(t, testLayer1TcbInfo(t)))
+	require.NoError(t, err)
+
+	// a present but empty BIT STRING is not the same as no flags
+	require.NotNil(t, ti.Flags)
+	assert.Equal(t, 0, ti.Flags.BitLength)
+	require.NotNil(t, ti.FlagsMask)
+	assert.Nil(t, ti.Layer)
+}
+
+func TestTcbInfo_ParseTcbInfo_NOK(t *testing.T) {
+	_, err := ParseTcbInfo([]byte{0x30, 0x03, 0x01})
+	assert.ErrorContains(t, err, "asn1: syntax error")
+
+	_, err = ParseTcbInfo(append(testTcbInfoDER(t, tcbInfo{Vendor: "ACME"}), 0x00))
+	assert.EqualError(t, err, "1 trailing bytes after DiceTcbInfo")
+
+	_, err = ParseTcbInfo(testTcbInfoDER(t, tcbInfo{SVN: big.NewInt(-1)}))
+	assert.EqualError(t, err, "svn: -1 out of range")
+}
+
+func TestTcbInfo_ParseMultiTcbInfo(t *testing.T) {
+	der, err := asn1.Marshal([]tcbInfo{testLayer0TcbInfo(t), testLayer1TcbInfo(t)})
+	require.NoError(t, err)
+
+	tis, err := ParseMultiTcbInfo(der)
+	require.NoError(t, err)
+	require.Len(t, tis, 2)
+	assert.Equal(t, "Roadrunner L0", *tis[0].Model)
+	assert.Equal(t, "Roadrunner L1", *tis[1].Model)
+
+	der, err = asn1.Marshal([]tcbInfo{testLayer0TcbInfo(t), {Layer: big.NewInt(-2)}})
+	require.NoError(t, err)
+
+	_, err = ParseMultiTcbInfo(der)
+	assert.EqualError(t, err, "DiceTcbInfo at index 1: layer: -2 out of range")
+
+	_, err = ParseMultiTcbInfo([]byte{0x30, 0x00})
+	assert.EqualError(t, err, "empty DiceMultiTcbInfo")
+}
+
+func TestTcbInfo_TcbInfosFromCert(t *testing.T) {
+	chain := testChain(t)
+
+	tis, err := TcbInfosFromCert(chain[0])
+	require.NoError(t, err)
+	require.Len(t, tis, 1)
+	assert.Equal(t, "Roadrunner L1", *tis[0].Model)
+
+	tis, err = TcbInfosFromCert(chain[1])
+	require.NoError(t, err)
+	require.Len(t, tis, 1)
+	assert.Equal(t, "Roadrunner L0", *tis[0].Model)
+
+	tis, err = TcbInfosFromCert(chain[2])
+	require.NoError(t, err)
+	assert.Empty(t, tis)
+
+	bad, _ := testCert(t, 4, nil, nil, pkix.Extension{Id: OIDTcbInfo, Value: []byte{0x05, 0x00}})
+	_, err = TcbInfosFromCert(bad)
+	assert.ErrorContains(t, err, "DiceTcbInfo extension: asn1: structure error")
+}
+
+func TestTcbInfo_Class(t *testing.T) {
+	ti, err := ParseTcbInfo(testTcbInfoDER(t, testLayer0TcbInfo(t)))
+	require.NoError(t, err)
+
+	class, err := ti.Class()
+	require.NoError(t, err)
+
+	expectedID, err := comid.NewBytesClassID([]byte{0x4c, 0x30})
+	require.NoError(t, err)
+
+	assert.Equal(t, "ACME", class.GetVendor())
+	assert.Equal(t, "Roadrunner L0", class.GetModel())
+	assert.Equal(t, expectedID, class.ClassID)
+	require.NotNil(t, class.Layer)
+	assert.Equal(t, uint64(0), *class.Layer)
+	assert.Nil(t, class.Index)
+
+	class, err = TcbInfo{}.Class()
+	require.NoError(t, err)
+	assert.Nil(t, class)
+}
+
+func TestTcbInfo_FlagsMap(t *testing.T) {
+	ti, err := ParseTcbInfo(testTcbInfoDER(t, testLayer0TcbInfo(t)))
+	require.NoError(t, err)
+
+	expected := comid.NewFlagsMap()
+	expected.SetTrue(comid.FlagIsSecure, comid.FlagIsDebug, comid.FlagIsReplayProtected,
+		comid.FlagIsIntegrityProtected, comid.FlagIsRuntimeMeasured, comid.FlagIsImmutable,
+		comid.FlagIsTcb)
+	expected.SetFalse(comid.FlagIsConfigured, comid.FlagIsRecovery)
+
+	assert.Equal(t, expected, ti.FlagsMap())
+
+	// the mask selects the flags that are meaningful
+	ti, err = ParseTcbInfo(testTcbInfoDER(t, testLayer1TcbInfo(t)))
+	require.NoError(t, err)
+
+	expected = comid.NewFlagsMap()
+	expected.SetFalse(comid.FlagIsRecovery, comid.FlagIsDebug)
+
+	assert.Equal(t, expected, ti.FlagsMap())
+
+	assert.Nil(t, TcbInfo{}.FlagsMap())
+}
+
+func TestTcbInfo_Measurement(t *testing.T) {
+	ti, err := ParseTcbInfo(testTcbInfoDER(t, testLayer0TcbInfo(t)))
+	require.NoError(t, err)
+
+	m, err := ti.Measurement(false)
+	require.NoError(t, err)
+	require.NoError(t, m.Valid())
+
+	require.NotNil(t, m.Val.Ver)
+	assert.Equal(t, "1.2.3", m.Val.Ver.Version)
+	assert.Equal(t, comid.MustNewTaggedSVN(uint64(7)), m.Val.SVN)
+	require.NotNil(t, m.Val.Digests)
+	assert.Equal(t, comid.Digests{*comid.NewDigestIntAlg(comid.Sha256, testFWID0)}, *m.Val.Digests)
+	assert.Equal(t, ti.FlagsMap(), m.Val.Flags)
+	assert.Equal(t, comid.NewRawValueFromBytes([]byte{0xde, 0xad}), m.Val.RawValue)
+
+	m, err = ti.Measurement(true)
+	require.NoError(t, err)
+	assert.Equal(t, comid.MustNewTaggedMinSVN(uint64(7)), m.Val.SVN)
+
+	m, err = TcbInfo{}.Measurement(false)
+	require.NoError(t, err)
+	assert.Nil(t, m)
+
+	_, err = TcbInfo{FWIDs: []FWID{{HashAlg: testOIDMD5, Digest: testFWID0[:16]}}}.Measurement(false)
+	assert.EqualError(t, err, "fwid at index 0: unsupported hash algorithm 1.2.840.113549.2.5")
+
+	_, err = TcbInfo{FWIDs: []FWID{{HashAlg: testOIDSHA384, Digest: testFWID0}}}.Measurement(false)
+	assert.ErrorContains(t, err, "fwid at index 0: ")
+}
@@ -0,0 +1,489 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package encoding
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Default limits applied by strict decoding when the corresponding Limits
+// field is not set
+const (
+	DefaultMaxBytes         = 16 * 1024 * 1024
+	DefaultMaxNestedLevels  = 32
+	DefaultMaxArrayElements = 131072
+	DefaultMaxMapPairs      = 131072
+)
+
+// Limits bounds the resources used to decode untrusted input. A zero field
+// selects the corresponding default.
+type Limits struct {
+	// MaxBytes is the maximum size of the input
+	MaxBytes int
+	// MaxNestedLevels is the maximum nesting depth of arrays, maps and (CBOR
+	// only) tags
+	MaxNestedLevels int
+	// MaxArrayElements is the maximum number of elements in an array
+	MaxArrayElements int
+	// MaxMapPairs is the maximum number of entries in a map
+	MaxMapPairs int
+}
+
+func (o Limits) withDefaults() Limits {
+	if o.MaxBytes == 0 {
+		o.MaxBytes = DefaultMaxBytes
+	}
+
+	if o.MaxNestedLevels == 0 {
+		o.MaxNestedLevels = DefaultMaxNestedLevels
+	}
+
+	if o.MaxArrayElements == 0 {
+		o.MaxArrayElements = DefaultMaxArrayElements
+	}
+
+	if o.MaxMapPairs == 0 {
+		o.MaxMapPairs = DefaultMaxMapPairs
+	}
+
+	return o
+}
+
+// CheckStrictCBOR checks that data is a single CBOR data item, within the
+// supplied limits, using the deterministic encoding of RFC 8949 §4.2.1:
+// definite lengths only, arguments and floating-point values in their
+// shortest (preferred) form, and map keys sorted in bytewise lexicographic
+// order of their encodings, with no duplicates. Byte strings are not looked
+// into: CBOR wrapped in byte strings must be checked separately.
+func CheckStrictCBOR(data []byte, limits Limits) error {
+	limits = limits.withDefaults()
+
+	if len(data) > limits.MaxBytes {
+		return fmt.Errorf("input size %d exceeds the limit of %d bytes", len(data), limits.MaxBytes)
+	}
+
+	if len(data) == 0 {
+		return errors.New("empty input")
+	}
+
+	s := strictCBORScanner{data: data, limits: limits}
+
+	end, err := s.item(0, 0)
+	if err != nil {
+		return err
+	}
+
+	if end != len(data) {
+		return fmt.Errorf("%d trailing bytes after the data item", len(data)-end)
+	}
+
+	return nil
+}
+
+type strictCBORScanner struct {
+	data   []byte
+	limits Limits
+}
+
+func (o strictCBORScanner) errorf(off int, format string, a ...any) error {
+	return fmt.Errorf("offset %d: %s", off, fmt.Sprintf(format, a...))
+}
+
+// head decodes the initial byte and argument of the item at off, and returns
+// the major type, the argument and the offset of the item's content
+// nolint:gocyclo
+func (o strictCBORScanner) head(off int) (major byte, arg uint64, next int, err error) {
+	if off >= len(o.data) {
+		return 0, 0, 0, o.errorf(off, "unexpected EOF")
+	}
+
+	major = o.data[off] >> 5
+	info := o.data[off] & 0x1f
+	next = off + 1
+
+	if info < 24 {
+		return major, uint64(info), next, nil
+	}
+
+	var size int
+
+	switch info {
+	case 24:
+		size = 1
+	case 25:
+		size = 2
+	case 26:
+		size = 4
+	case 27:
+		size = 8
+	case 31:
+		if major == 7 {
+			return 0, 0, 0, o.errorf(off, "unexpected break")
+		}
+		return 0, 0, 0, o.errorf(off, "indefinite length encoding")
+	default:
+		return 0, 0, 0, o.errorf(off, "reserved additional information %d", info)
+	}
+
+	if len(o.data)-next < size {
+		return 0, 0, 0, o.errorf(off, "unexpected EOF")
+	}
+
+	raw := o.data[next : next+size]
+	next += size
+
+	switch size {
+	case 1:
+		arg = uint64(raw[0])
+	case 2:
+		arg = uint64(binary.BigEndian.Uint16(raw))
+	case 4:
+		arg = uint64(binary.BigEndian.Uint32(raw))
+	case 8:
+		arg = binary.BigEndian.Uint64(raw)
+	}
+
+	if major == 7 {
+		// floats have their own notion of preferred serialization, and
+		// one-byte simple values below 32 are not well-formed
+		switch {
+		case size == 1 && arg < 32:
+			return 0, 0, 0, o.errorf(off, "invalid simple value %d", arg)
+		case size == 4 && float32FitsFloat16(uint32(arg)):
+			return 0, 0, 0, o.errorf(off, "float32 value is not in its preferred (shortest) form")
+		case size == 8 && float64FitsFloat32(arg):
+			return 0, 0, 0, o.errorf(off, "float64 value is not in its preferred (shortest) form")
+		}
+
+		return major, arg, next, nil
+	}
+
+	var minimum uint64
+
+	switch size {
+	case 1:
+		minimum = 24
+	case 2:
+		minimum = math.MaxUint8 + 1
+	case 4:
+		minimum = math.MaxUint16 + 1
+	case 8:
+		minimum = math.MaxUint32 + 1
+	}
+
+	if arg < minimum {
+		return 0, 0, 0, o.errorf(off, "argument %d is not in its preferred (shortest) form", arg)
+	}
+
+	return major, arg, next, nil
+}
+
+// nolint:gocyclo
+func (o strictCBORScanner) item(off int, depth int) (int, error) {
+	major, arg, next, err := o.head(off)
+	if err != nil {
+		return 0, err
+	}
+
+	switch major {
+	case 0, 1, 7: // integers, simple values and floats
+		return next, nil
+	case 2, 3: // byte and text strings
+		if arg > uint64(len(o.data)-next) {
+			return 0, o.errorf(off, "unexpected EOF")
+		}
+
+		end := next + int(arg)
+
+		if major == 3 && !utf8.Valid(o.data[next:end]) {
+			return 0, o.errorf(off, "invalid UTF-8 text string")
+		}
+
+		return end, nil
+	}
+
+	if depth >= o.limits.MaxNestedLevels {
+		return 0, o.errorf(off, "exceeded max nested levels %d", o.limits.MaxNestedLevels)
+	}
+
+	switch major {
+	case 4: // array
+		if arg > uint64(o.limits.MaxArrayElements) {
+			return 0, o.errorf(off, "array has %d elements, exceeding the limit of %d",
+				arg, o.limits.MaxArrayElements)
+		}
+
+		for i := uint64(0); i < arg; i++ {
+			if next, err = o.item(next, depth+1); err != nil {
+				return 0, err
+			}
+		}
+
+		return next, nil
+	case 5: // map
+		if arg > uint64(o.limits.MaxMapPairs) {
+			return 0, o.errorf(off, "map has %d entries, exceeding the limit of %d",
+				arg, o.limits.MaxMapPairs)
+		}
+
+		var prev []byte
+		seen := make(map[string]bool, arg)
+
+		for i := uint64(0); i < arg; i++ {
+			keyOff := next
+
+			if next, err = o.item(keyOff, depth+1); err != nil {
+				return 0, err
+			}
+
+			key := o.data[keyOff:next]
+
+			if seen[string(key)] {
+				return 0, o.errorf(keyOff, "duplicate map key 0x%x", key)
+			}
+			seen[string(key)] = true
+
+			if prev != nil && bytes.Compare(prev, key) > 0 {
+				return 0, o.errorf(keyOff, "map key 0x%x is not in deterministic order", key)
+			}
+			prev = key
+
+			if next, err = o.item(next, depth+1); err != nil {
+				return 0, err
+			}
+		}
+
+		return next, nil
+	default: // 6, tag
+		return o.item(next, depth+1)
+	}
+}
+
+// float32FitsFloat16 returns true if the float32 with the supplied bits can be
+// encoded as a float16 without loss
+func float32FitsFloat16(f uint32) bool {
+	exp := int((f >> 23) & 0xff)
+	mant := f & 0x7fffff
+
+	switch {
+	case exp == 0xff: // Inf or NaN: the payload must fit in 10 bits
+		return mant&0x1fff == 0
+	case exp == 0: // zero, or float32 subnormal (too small for float16)
+		return mant == 0
+	}
+
+	e := exp - 127
+
+	switch {
+	case e >= -14 && e <= 15: // float16 normal
+		return mant&0x1fff == 0
+	case e >= -24 && e < -14: // float16 subnormal
+		significand := mant | 1<<23
+		return bits.TrailingZeros32(significand) >= 13+(-14-e)
+	default:
+		return false
+	}
+}
+
+// float64FitsFloat32 returns true if the float64 with the supplied bits can be
+// encoded as a float32 without loss
+func float64FitsFloat32(f uint64) bool {
+	v := math.Float64frombits(f)
+
+	if math.IsNaN(v) {
+		// the payload must fit in 23 bits
+		return f&0x1fffffff == 0
+	}
+
+	return math.Float64bits(float64(float32(v))) == f
+}
+
+// CheckStrictJSON checks that data is a single JSON value, within the supplied
+// limits, and without duplicate object member names.
+// nolint:gocyclo
+func CheckStrictJSON(data []byte, limits Limits) error {
+	limits = limits.withDefaults()
+
+	if len(data) > limits.MaxBytes {
+		return fmt.Errorf("input size %d exceeds the limit of %d bytes", len(data), limits.MaxBytes)
+	}
+
+	type container struct {
+		object bool
+		count  int
+		names  map[string]bool
+		isName bool
+	}
+
+	var stack []*container
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				return errors.New("unexpected EOF")
+			}
+			return err
+		}
+
+		var top *container
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		if d, ok := tok.(json.Delim); ok && (d == '}' || d == ']') {
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				break
+			}
+			continue
+		}
+
+		if top != nil && top.object && top.isName {
+			name, _ := tok.(string)
+
+			if top.names[name] {
+				return fmt.Errorf("offset %d: duplicate member name %q", decoder.InputOffset(), name)
+			}
+			top.names[name] = true
+
+			top.isName = false
+			continue
+		}
+
+		if top != nil {
+			top.count++
+
+			if top.object {
+				if top.count > limits.MaxMapPairs {
+					return fmt.Errorf("offset %d: object has more than %d members",
+						decoder.InputOffset(), limits.MaxMapPairs)
+				}
+				top.isName = true
+			} else if top.count > limits.MaxArrayElements {
+				return fmt.Errorf("offset %d: array has more than %d elements",
+					decoder.InputOffset(), limits.MaxArrayElements)
+			}
+		}
+
+		if d, ok := tok.(json.Delim); ok {
+			if len(stack) >= limits.MaxNestedLevels {
+				return fmt.Errorf("offset %d: exceeded max nested levels %d",
+					decoder.InputOffset(), limits.MaxNestedLevels)
+			}
+
+			c := &container{object: d == '{', isName: d == '{'}
+			if c.object {
+				c.names = make(map[string]bool)
+			}
+
+			stack = append(stack, c)
+			continue
+		}
+
+		if len(stack) == 0 {
+			break
+		}
+	}
+
+	end := decoder.InputOffset()
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("offset %d: trailing data after the JSON value", end)
+	}
+
+	return nil
+}
+
+// CheckNoCachedFields returns an error if any field-cache reachable from v is
+// not empty, i.e. if decoding the value into v encountered map keys that are
+// neither part of the base structures nor of the registered extensions.
+func CheckNoCachedFields(v any) error {
+	var found []string
+
+	walkCachedFields(reflect.ValueOf(v), "", make(map[uintptr]bool), &found)
+
+	if len(found) != 0 {
+		return fmt.Errorf("unknown keys: %s", strings.Join(found, ", "))
+	}
+
+	return nil
+}
+
+// nolint:gocyclo
+func walkCachedFields(v reflect.Value, path string, seen map[uintptr]bool, found *[]string) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || seen[v.Pointer()] {
+			return
+		}
+		seen[v.Pointer()] = true
+
+		walkCachedFields(v.Elem(), path, seen, found)
+	case reflect.Interface:
+		if !v.IsNil() {
+			walkCachedFields(v.Elem(), path, seen, found)
+		}
+	case reflect.Struct:
+		t := v.Type()
+
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+
+			if _, ok := f.Tag.Lookup("field-cache"); ok {
+				if isMapStringAny(v.Field(i)) && v.Field(i).Len() != 0 {
+					keys := make([]string, 0, v.Field(i).Len())
+					for _, k := range v.Field(i).MapKeys() {
+						keys = append(keys, k.String())
+					}
+					sort.Strings(keys)
+
+					entry := strings.Join(keys, ",")
+					if path != "" {
+						entry += " at " + strings.TrimPrefix(path, ".")
+					}
+
+					*found = append(*found, entry)
+				}
+				continue
+			}
+
+			fieldPath := path
+			if !f.Anonymous {
+				fieldPath += "." + f.Name
+			}
+
+			walkCachedFields(v.Field(i), fieldPath, seen, found)
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+
+		for i := 0; i < v.Len(); i++ {
+			walkCachedFields(v.Index(i), fmt.Sprintf("%s[%d]", path, i), seen, found)
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			walkCachedFields(v.MapIndex(k), fmt.Sprintf("%s[%v]", path, k), seen, found)
+		}
+	}
+}
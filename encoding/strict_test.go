@@ -0,0 +1,167 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package encoding
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustHexDecode(t *testing.T, s string) []byte {
+	data, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return data
+}
+
+func TestCheckStrictCBOR_OK(t *testing.T) {
+	for _, tv := range []string{
+		"00",                   // 0
+		"17",                   // 23
+		"1818",                 // 24
+		"3903e7",               // -1000
+		"4401020304",           // h'01020304'
+		"6449455446",           // "IETF"
+		"f93c00",               // 1.0
+		"fa47c35000",           // 100000.0
+		"fb3ff199999999999a",   // 1.1
+		"f4", "f5", "f6", "f7", // false, true, null, undefined
+		"f820",               // simple(32)
+		"83010203",           // [1, 2, 3]
+		"a201020304",         // {1: 2, 3: 4}
+		"a30102200361610a",   // {1: 2, -1: 3, "a": 10}
+		"a20161616262616161", // {1: "a", "ba": "a"}: shorter keys sort first
+		"d9d9f7a0",           // 55799({})
+		"c06161",             // 0("a")
+	} {
+		assert.NoError(t, CheckStrictCBOR(mustHexDecode(t, tv), Limits{}), tv)
+	}
+}
+
+func TestCheckStrictCBOR_NOK(t *testing.T) {
+	for _, tv := range []struct {
+		data string
+		err  string
+	}{
+		{"", "empty input"},
+		{"0000", "1 trailing bytes after the data item"},
+		{"1817", "offset 0: argument 23 is not in its preferred (shortest) form"},
+		{"190017", "offset 0: argument 23 is not in its preferred (shortest) form"},
+		{"5800", "offset 0: argument 0 is not in its preferred (shortest) form"},
+		{"9f01ff", "offset 0: indefinite length encoding"},
+		{"5f4101ff", "offset 0: indefinite length encoding"},
+		{"ff", "offset 0: unexpected break"},
+		{"1c", "offset 0: reserved additional information 28"},
+		{"19", "offset 0: unexpected EOF"},
+		{"8301", "offset 2: unexpected EOF"},
+		{"62c328", "offset 0: invalid UTF-8 text string"},
+		{"f800", "offset 0: invalid simple value 0"},
+		{"fa3f800000", "offset 0: float32 value is not in its preferred (shortest) form"},
+		{"fb3ff0000000000000", "offset 0: float64 value is not in its preferred (shortest) form"},
+		{"fb3ff8000000000000", "offset 0: float64 value is not in its preferred (shortest) form"},
+		{"a201020102", "offset 3: duplicate map key 0x01"},
+		{"a203020102", "offset 3: map key 0x01 is not in deterministic order"},
+		// negative integers sort after unsigned ones
+		{"a220020102", "offset 3: map key 0x01 is not in deterministic order"},
+	} {
+		assert.EqualError(t, CheckStrictCBOR(mustHexDecode(t, tv.data), Limits{}), tv.err, tv.data)
+	}
+}
+
+func TestCheckStrictCBOR_limits(t *testing.T) {
+	data := mustHexDecode(t, "a1018281a0f6") // {1: [[{}], null]}
+
+	assert.NoError(t, CheckStrictCBOR(data, Limits{MaxNestedLevels: 4}))
+	assert.EqualError(t, CheckStrictCBOR(data, Limits{MaxNestedLevels: 3}),
+		"offset 4: exceeded max nested levels 3")
+
+	assert.NoError(t, CheckStrictCBOR(data, Limits{MaxArrayElements: 2}))
+	assert.EqualError(t, CheckStrictCBOR(data, Limits{MaxArrayElements: 1}),
+		"offset 2: array has 2 elements, exceeding the limit of 1")
+
+	assert.EqualError(t, CheckStrictCBOR(mustHexDecode(t, "a201020304"), Limits{MaxMapPairs: 1}),
+		"offset 0: map has 2 entries, exceeding the limit of 1")
+
+	assert.EqualError(t, CheckStrictCBOR(data, Limits{MaxBytes: 5}),
+		"input size 6 exceeds the limit of 5 bytes")
+
+	// tags count as a nesting level
+	assert.EqualError(t, CheckStrictCBOR(mustHexDecode(t, "c1c101"), Limits{MaxNestedLevels: 1}),
+		"offset 1: exceeded max nested levels 1")
+
+	// the declared length is checked before reading the elements
+	assert.EqualError(t, CheckStrictCBOR(mustHexDecode(t, "9a7fffffff"), Limits{}),
+		"offset 0: array has 2147483647 elements, exceeding the limit of 131072")
+}
+
+func TestCheckStrictJSON_OK(t *testing.T) {
+	for _, tv := range []string{
+		`1`,
+		`"a"`,
+		`{}`,
+		`[]`,
+		` {"a": [1, {"a": 2}], "b": {"a": null}} `,
+		`[{"a": 1}, {"a": 1}]`,
+	} {
+		assert.NoError(t, CheckStrictJSON([]byte(tv), Limits{}), tv)
+	}
+}
+
+func TestCheckStrictJSON_NOK(t *testing.T) {
+	for _, tv := range []struct {
+		data string
+		err  string
+	}{
+		{``, "unexpected EOF"},
+		{`{"a": 1`, "unexpected EOF"},
+		{`{"a": 1, "a": 2}`, `offset 12: duplicate member name "a"`},
+		{`{"b": {"a": 1, "a": 2}}`, `offset 18: duplicate member name "a"`},
+		{`{} {}`, "offset 2: trailing data after the JSON value"},
+		{`1 2`, "offset 1: trailing data after the JSON value"},
+	} {
+		assert.EqualError(t, CheckStrictJSON([]byte(tv.data), Limits{}), tv.err, tv.data)
+	}
+}
+
+func TestCheckStrictJSON_limits(t *testing.T) {
+	data := []byte(`{"a": [[{}], null]}`)
+
+	assert.NoError(t, CheckStrictJSON(data, Limits{MaxNestedLevels: 4}))
+	assert.ErrorContains(t, CheckStrictJSON(data, Limits{MaxNestedLevels: 3}),
+		"exceeded max nested levels 3")
+
+	assert.ErrorContains(t, CheckStrictJSON(data, Limits{MaxArrayElements: 1}),
+		"array has more than 1 elements")
+
+	assert.ErrorContains(t, CheckStrictJSON([]byte(`{"a": 1, "b": 2}`), Limits{MaxMapPairs: 1}),
+		"object has more than 1 members")
+
+	assert.EqualError(t, CheckStrictJSON(data, Limits{MaxBytes: 5}),
+		"input size 19 exceeds the limit of 5 bytes")
+}
+
+func TestCheckNoCachedFields(t *testing.T) {
+	dm := mustInitDecMode()
+
+	var s MyStruct
+
+	require.NoError(t, PopulateStructFromCBOR(dm, mustHexDecode(t, "a20061610102"), &s))
+	assert.NoError(t, CheckNoCachedFields(&s))
+
+	s = MyStruct{}
+	require.NoError(t, PopulateStructFromCBOR(dm, mustHexDecode(t, "a3006161010218630a"), &s))
+	assert.EqualError(t, CheckNoCachedFields(&s), "unknown keys: 99")
+
+	// nested values are reported with their path
+	type outer struct {
+		Inner []*MyStruct
+	}
+
+	assert.EqualError(t, CheckNoCachedFields(&outer{Inner: []*MyStruct{{}, &s}}),
+		"unknown keys: 99 at Inner[1]")
+
+	assert.NoError(t, CheckNoCachedFields(nil))
+}
@@ -0,0 +1,398 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package tdx
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/veraison/corim/comid"
+)
+
+// CompareAgainstReference checks the (evidence) SVN against a reference SVN.
+// A reference unsigned integer must be matched exactly, while a reference
+// numeric expression is evaluated against the claimed SVN. If the comparison
+// fails, a reason is returned.
+func (o TeeSVN) CompareAgainstReference(ref TeeSVN) (bool, string) {
+	claim, err := o.GetUint()
+	if err != nil {
+		return false, fmt.Sprintf("claimed SVN: %v", err)
+	}
+
+	return compareUint(claim, ref.val)
+}
+
+// CompareAgainstReference checks the (evidence) TCB evaluation number against
+// a reference TCB evaluation number, with the same semantics as
+// TeeSVN.CompareAgainstReference.
+func (o TeeTcbEvalNumber) CompareAgainstReference(ref TeeTcbEvalNumber) (bool, string) {
+	claim, err := o.GetUint()
+	if err != nil {
+		return false, fmt.Sprintf("claimed TCB evaluation number: %v", err)
+	}
+
+	return compareUint(claim, ref.val)
+}
+
+// CompareAgainstReference checks the (evidence) TCB component SVNs against
+// the reference ones, element by element. Reference elements that are not
+// set are ignored.
+// nolint:gocritic
+func (o TeeTcbCompSvn) CompareAgainstReference(ref TeeTcbCompSvn) (bool, string) {
+	for i, r := range ref {
+		if r.val == nil {
+			continue
+		}
+
+		if ok, reason := o[i].CompareAgainstReference(r); !ok {
+			return false, fmt.Sprintf("component %d: %s", i, reason)
+		}
+	}
+
+	return true, ""
+}
+
+// CompareAgainstReference checks the (evidence) advisory IDs against the
+// reference advisory IDs. A reference array of strings must contain the same
+// advisory IDs as the claim (in any order), while a reference set expression
+// is evaluated against the claimed advisory IDs.
+func (o TeeAdvisoryIDs) CompareAgainstReference(ref TeeAdvisoryIDs) (bool, string) {
+	claim, err := o.GetString()
+	if err != nil {
+		return false, fmt.Sprintf("claimed advisory IDs: %v", err)
+	}
+
+	return compareStrings(claim, ref.val)
+}
+
+// CompareAgainstReference checks the (evidence) TCB status against the
+// reference TCB status, with the same semantics as
+// TeeAdvisoryIDs.CompareAgainstReference.
+func (o TeeTcbStatus) CompareAgainstReference(ref TeeTcbStatus) (bool, string) {
+	claim, err := o.GetString()
+	if err != nil {
+		return false, fmt.Sprintf("claimed TCB status: %v", err)
+	}
+
+	return compareStrings(claim, ref.val)
+}
+
+// CompareAgainstReference checks the (evidence) digests against the reference.
+// Reference digests are compared as per comid.Digests.CompareAgainstReference,
+// while a reference set expression is evaluated against the claimed digests.
+func (o TeeDigest) CompareAgainstReference(ref TeeDigest) (bool, string) {
+	claim, err := o.GetDigest()
+	if err != nil {
+		return false, fmt.Sprintf("claimed digests: %v", err)
+	}
+
+	switch t := ref.val.(type) {
+	case Digests:
+		if !claim.CompareAgainstReference(t) {
+			return false, "claimed digests do not match reference"
+		}
+		return true, ""
+	case TaggedSetDigestExpression:
+		return t.Evaluate(claim)
+	default:
+		return false, fmt.Sprintf("unsupported reference type %T", t)
+	}
+}
+
+// CompareAgainstReference checks that the (evidence) attributes are equal to
+// the reference attributes.
+func (o TeeAttributes) CompareAgainstReference(ref TeeAttributes) (bool, string) {
+	return compareBytes(o, ref)
+}
+
+// CompareAgainstReference checks that the (evidence) miscselect is equal to
+// the reference miscselect.
+func (o TeeMiscSelect) CompareAgainstReference(ref TeeMiscSelect) (bool, string) {
+	return compareBytes(o, ref)
+}
+
+// CompareAgainstReference checks that the (evidence) instance ID is equal to
+// the reference instance ID.
+func (o TeeInstanceID) CompareAgainstReference(ref TeeInstanceID) (bool, string) {
+	return compareUintOrBytes(o.val, ref.val)
+}
+
+// CompareAgainstReference checks that the (evidence) ISV product ID is equal
+// to the reference ISV product ID.
+func (o TeeISVProdID) CompareAgainstReference(ref TeeISVProdID) (bool, string) {
+	return compareUintOrBytes(o.val, ref.val)
+}
+
+// CompareMval implements comid.IMvalComparator: it compares the TDX extensions
+// of the claimed measurement values against the (reference) receiver, using
+// the semantics of the CompareAgainstReference methods of each field. Only
+// the fields that are set in the reference are compared.
+// nolint:gocyclo
+func (o *MValExtensions) CompareMval(claim *comid.Mval) []comid.MvalFieldMatch {
+	var (
+		ret []comid.MvalFieldMatch
+		c   *MValExtensions
+	)
+
+	if claim != nil {
+		c, _ = claim.Extensions.IMapValue.(*MValExtensions)
+	}
+	if c == nil {
+		c = &MValExtensions{}
+	}
+
+	add := func(field string, ok bool, reason string) {
+		if ok {
+			reason = ""
+		}
+		ret = append(ret, comid.MvalFieldMatch{Field: field, Matched: ok, Reason: reason})
+	}
+
+	missing := func(field string) {
+		add(field, false, "not present in claim")
+	}
+
+	if o.TeeTcbDate != nil {
+		if c.TeeTcbDate == nil {
+			missing("tcbdate")
+		} else {
+			ok, reason := compareTime(*c.TeeTcbDate, *o.TeeTcbDate)
+			add("tcbdate", ok, reason)
+		}
+	}
+
+	if o.TeeISVSVN != nil {
+		if c.TeeISVSVN == nil {
+			missing("isvsvn")
+		} else {
+			ok, reason := c.TeeISVSVN.CompareAgainstReference(*o.TeeISVSVN)
+			add("isvsvn", ok, reason)
+		}
+	}
+
+	if o.TeeInstanceID != nil {
+		if c.TeeInstanceID == nil {
+			missing("instanceid")
+		} else {
+			ok, reason := c.TeeInstanceID.CompareAgainstReference(*o.TeeInstanceID)
+			add("instanceid", ok, reason)
+		}
+	}
+
+	if o.TeePCEID != nil {
+		if c.TeePCEID == nil {
+			missing("pceid")
+		} else {
+			add("pceid", *c.TeePCEID == *o.TeePCEID,
+				fmt.Sprintf("claimed %q does not match reference %q", *c.TeePCEID, *o.TeePCEID))
+		}
+	}
+
+	if o.TeeMiscSelect != nil {
+		if c.TeeMiscSelect == nil {
+			missing("miscselect")
+		} else {
+			ok, reason := c.TeeMiscSelect.CompareAgainstReference(*o.TeeMiscSelect)
+			add("miscselect", ok, reason)
+		}
+	}
+
+	if o.TeeAttributes != nil {
+		if c.TeeAttributes == nil {
+			missing("attributes")
+		} else {
+			ok, reason := c.TeeAttributes.CompareAgainstReference(*o.TeeAttributes)
+			add("attributes", ok, reason)
+		}
+	}
+
+	if o.TeeMrTee != nil {
+		if c.TeeMrTee == nil {
+			missing("mrtee")
+		} else {
+			ok, reason := c.TeeMrTee.CompareAgainstReference(*o.TeeMrTee)
+			add("mrtee", ok, reason)
+		}
+	}
+
+	if o.TeeMrSigner != nil {
+		if c.TeeMrSigner == nil {
+			missing("mrsigner")
+		} else {
+			ok, reason := c.TeeMrSigner.CompareAgainstReference(*o.TeeMrSigner)
+			add("mrsigner", ok, reason)
+		}
+	}
+
+	if o.TeeISVProdID != nil {
+		if c.TeeISVProdID == nil {
+			missing("isvprodid")
+		} else {
+			ok, reason := c.TeeISVProdID.CompareAgainstReference(*o.TeeISVProdID)
+			add("isvprodid", ok, reason)
+		}
+	}
+
+	if o.TeeTcbEvalNum != nil {
+		if c.TeeTcbEvalNum == nil {
+			missing("tcbevalnum")
+		} else {
+			ok, reason := c.TeeTcbEvalNum.CompareAgainstReference(*o.TeeTcbEvalNum)
+			add("tcbevalnum", ok, reason)
+		}
+	}
+
+	if o.TeeTcbStatus != nil {
+		if c.TeeTcbStatus == nil {
+			missing("tcbstatus")
+		} else {
+			ok, reason := c.TeeTcbStatus.CompareAgainstReference(*o.TeeTcbStatus)
+			add("tcbstatus", ok, reason)
+		}
+	}
+
+	if o.TeeAdvisoryIDs != nil {
+		// a claim without advisory IDs is a claim of no advisories, which
+		// may well satisfy e.g. a non-membership expression
+		var (
+			claimed []string
+			err     error
+		)
+		if c.TeeAdvisoryIDs != nil {
+			claimed, err = c.TeeAdvisoryIDs.GetString()
+		}
+		if err != nil {
+			add("advisoryids", false, fmt.Sprintf("claimed advisory IDs: %v", err))
+		} else {
+			ok, reason := compareStrings(claimed, o.TeeAdvisoryIDs.val)
+			add("advisoryids", ok, reason)
+		}
+	}
+
+	if o.TeeEpoch != nil {
+		if c.TeeEpoch == nil {
+			missing("epoch")
+		} else {
+			ok, reason := compareTime(*c.TeeEpoch, *o.TeeEpoch)
+			add("epoch", ok, reason)
+		}
+	}
+
+	if o.TeeCryptoKeys != nil {
+		if c.TeeCryptoKeys == nil {
+			missing("teecryptokeys")
+		} else {
+			ok, reason := compareCryptoKeys(*c.TeeCryptoKeys, *o.TeeCryptoKeys)
+			add("teecryptokeys", ok, reason)
+		}
+	}
+
+	if o.TeeTCBCompSvn != nil {
+		if c.TeeTCBCompSvn == nil {
+			missing("tcbcompsvn")
+		} else {
+			ok, reason := c.TeeTCBCompSvn.CompareAgainstReference(*o.TeeTCBCompSvn)
+			add("tcbcompsvn", ok, reason)
+		}
+	}
+
+	return ret
+}
+
+func compareUint(claim uint, ref any) (bool, string) {
+	switch t := ref.(type) {
+	case uint:
+		if claim != t {
+			return false, fmt.Sprintf("claimed %d does not match reference %d", claim, t)
+		}
+		return true, ""
+	case uint64:
+		if uint64(claim) != t {
+			return false, fmt.Sprintf("claimed %d does not match reference %d", claim, t)
+		}
+		return true, ""
+	case TaggedNumericExpression:
+		return t.Evaluate(claim)
+	default:
+		return false, fmt.Sprintf("unsupported reference type %T", t)
+	}
+}
+
+func compareStrings(claim []string, ref any) (bool, string) {
+	switch t := ref.(type) {
+	case []string:
+		if !stringSetsEqual(claim, t) {
+			return false, fmt.Sprintf("claimed %v does not match reference %v", claim, t)
+		}
+		return true, ""
+	case TaggedSetStringExpression:
+		return t.Evaluate(claim)
+	default:
+		return false, fmt.Sprintf("unsupported reference type %T", t)
+	}
+}
+
+func compareBytes(claim, ref []byte) (bool, string) {
+	if len(claim) != len(ref) {
+		return false, fmt.Sprintf("claimed length %d does not match reference length %d",
+			len(claim), len(ref))
+	}
+
+	if !bytes.Equal(claim, ref) {
+		return false, fmt.Sprintf("claimed %x does not match reference %x", claim, ref)
+	}
+
+	return true, ""
+}
+
+func compareUintOrBytes(claim, ref any) (bool, string) {
+	claimBytes, claimIsBytes := claim.([]byte)
+	refBytes, refIsBytes := ref.([]byte)
+
+	if claimIsBytes || refIsBytes {
+		if !claimIsBytes || !refIsBytes || !bytes.Equal(claimBytes, refBytes) {
+			return false, fmt.Sprintf("claimed %v does not match reference %v", claim, ref)
+		}
+		return true, ""
+	}
+
+	lhs, err := numericToRat(claim)
+	if err != nil {
+		return false, fmt.Sprintf("claimed value: %v", err)
+	}
+
+	rhs, err := numericToRat(ref)
+	if err != nil {
+		return false, fmt.Sprintf("reference value: %v", err)
+	}
+
+	if lhs.Cmp(rhs) != 0 {
+		return false, fmt.Sprintf("claimed %v does not match reference %v", claim, ref)
+	}
+
+	return true, ""
+}
+
+func compareTime(claim, ref time.Time) (bool, string) {
+	if !claim.Equal(ref) {
+		return false, fmt.Sprintf("claimed %s does not match reference %s",
+			claim.Format(time.RFC3339), ref.Format(time.RFC3339))
+	}
+	return true, ""
+}
+
+func compareCryptoKeys(claim, ref comid.CryptoKeys) (bool, string) {
+outer:
+	for _, r := range ref {
+		for _, c := range claim {
+			if c.Type() == r.Type() && c.String() == r.String() {
+				continue outer
+			}
+		}
+		return false, fmt.Sprintf("reference key %s not found in claim", r.String())
+	}
+
+	return true, ""
+}
@@ -0,0 +1,252 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package tdx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/comid"
+)
+
+func TestTeeSVN_CompareAgainstReference(t *testing.T) {
+	claim, err := NewSvnUint(TestISVSVN)
+	require.NoError(t, err)
+
+	exact, err := NewSvnUint(TestISVSVN)
+	require.NoError(t, err)
+	ok, reason := claim.CompareAgainstReference(*exact)
+	assert.True(t, ok)
+	assert.Empty(t, reason)
+
+	ref, err := NewSvnExpression(TestISVSVN - 1)
+	require.NoError(t, err)
+	ok, _ = claim.CompareAgainstReference(*ref)
+	assert.True(t, ok)
+
+	ref, err = NewSvnExpression(TestISVSVN + 1)
+	require.NoError(t, err)
+	ok, reason = claim.CompareAgainstReference(*ref)
+	assert.False(t, ok)
+	assert.Equal(t, "10 is not greater_or_equal 11", reason)
+
+	ok, reason = ref.CompareAgainstReference(*exact)
+	assert.False(t, ok)
+	assert.Contains(t, reason, "claimed SVN")
+}
+
+func TestTeeTcbEvalNumber_CompareAgainstReference(t *testing.T) {
+	claim, err := NewTeeTcbEvalNumberUint(TestTCBEvalNum)
+	require.NoError(t, err)
+
+	ref, err := NewTeeTcbEvalNumberNumeric(TestTCBEvalNum)
+	require.NoError(t, err)
+	ok, _ := claim.CompareAgainstReference(*ref)
+	assert.True(t, ok)
+
+	ref, err = NewTeeTcbEvalNumberUint(TestTCBEvalNum + 1)
+	require.NoError(t, err)
+	ok, reason := claim.CompareAgainstReference(*ref)
+	assert.False(t, ok)
+	assert.Equal(t, "claimed 11 does not match reference 12", reason)
+}
+
+func TestTeeTcbCompSvn_CompareAgainstReference(t *testing.T) {
+	claim, err := NewTeeTcbCompSvnUint([]uint{10, 10, 2, 2, 2, 1, 4})
+	require.NoError(t, err)
+
+	ref, err := NewTeeTcbCompSvnExpression([]uint{10, 9, 2, 2})
+	require.NoError(t, err)
+	ok, reason := claim.CompareAgainstReference(*ref)
+	assert.True(t, ok)
+	assert.Empty(t, reason)
+
+	ref, err = NewTeeTcbCompSvnExpression([]uint{10, 9, 3, 2})
+	require.NoError(t, err)
+	ok, reason = claim.CompareAgainstReference(*ref)
+	assert.False(t, ok)
+	assert.Equal(t, "component 2: 2 is not greater_or_equal 3", reason)
+}
+
+func TestTeeAdvisoryIDs_CompareAgainstReference(t *testing.T) {
+	claim, err := NewTeeAdvisoryIDsString([]string{"INTEL-SA-00078", "INTEL-SA-00079"})
+	require.NoError(t, err)
+
+	ref, err := NewTeeAdvisoryIDsString([]string{"INTEL-SA-00079", "INTEL-SA-00078"})
+	require.NoError(t, err)
+	ok, _ := claim.CompareAgainstReference(*ref)
+	assert.True(t, ok)
+
+	ref, err = NewTeeAdvisoryIDsExpr(NMEM, []string{"INTEL-SA-00079"})
+	require.NoError(t, err)
+	ok, reason := claim.CompareAgainstReference(*ref)
+	assert.False(t, ok)
+	assert.Equal(t, `"INTEL-SA-00079" is a member of [INTEL-SA-00079]`, reason)
+}
+
+func TestTeeTcbStatus_CompareAgainstReference(t *testing.T) {
+	claim, err := NewTeeTcbStatusString([]string{"UpToDate"})
+	require.NoError(t, err)
+
+	ref, err := NewTcbStatusExpr(MEM, []string{"UpToDate", "SWHardeningNeeded"})
+	require.NoError(t, err)
+	ok, _ := claim.CompareAgainstReference(*ref)
+	assert.True(t, ok)
+
+	ref, err = NewTeeTcbStatusString([]string{"OutOfDate"})
+	require.NoError(t, err)
+	ok, reason := claim.CompareAgainstReference(*ref)
+	assert.False(t, ok)
+	assert.Equal(t, "claimed [UpToDate] does not match reference [OutOfDate]", reason)
+}
+
+func TestTeeDigest_CompareAgainstReference(t *testing.T) {
+	claim, err := NewTeeDigest(getNewDigests())
+	require.NoError(t, err)
+
+	ref, err := NewTeeDigest(getNewDigests())
+	require.NoError(t, err)
+	ok, _ := claim.CompareAgainstReference(*ref)
+	assert.True(t, ok)
+
+	ref, err = NewTeeDigestExpr(MEM, getNewDigests())
+	require.NoError(t, err)
+	ok, _ = claim.CompareAgainstReference(*ref)
+	assert.True(t, ok)
+
+	ref, err = NewTeeDigest(*comid.NewDigests().AddDigest(comid.Sha256, make([]byte, 32)))
+	require.NoError(t, err)
+	ok, reason := claim.CompareAgainstReference(*ref)
+	assert.False(t, ok)
+	assert.Equal(t, "claimed digests do not match reference", reason)
+}
+
+func TestTeeAttributes_CompareAgainstReference(t *testing.T) {
+	claim := TeeAttributes{0x01, 0x10}
+
+	ok, _ := claim.CompareAgainstReference(TeeAttributes{0x01, 0x10})
+	assert.True(t, ok)
+
+	ok, reason := claim.CompareAgainstReference(TeeAttributes{0x01, 0x00})
+	assert.False(t, ok)
+	assert.Equal(t, "claimed 0110 does not match reference 0100", reason)
+
+	ok, reason = claim.CompareAgainstReference(TeeAttributes{0x01})
+	assert.False(t, ok)
+	assert.Equal(t, "claimed length 2 does not match reference length 1", reason)
+}
+
+func TestTeeMiscSelect_CompareAgainstReference(t *testing.T) {
+	claim := TeeMiscSelect{0xc0, 0x00, 0xfb, 0xff}
+
+	ok, _ := claim.CompareAgainstReference(TeeMiscSelect{0xc0, 0x00, 0xfb, 0xff})
+	assert.True(t, ok)
+
+	ok, reason := claim.CompareAgainstReference(TeeMiscSelect{0xc0, 0x00, 0x00, 0x00})
+	assert.False(t, ok)
+	assert.Equal(t, "claimed c000fbff does not match reference c0000000", reason)
+}
+
+func TestTeeInstanceID_CompareAgainstReference(t *testing.T) {
+	claim, err := NewTeeInstanceID(uint64(TestUIntInstance))
+	require.NoError(t, err)
+
+	ref, err := NewTeeInstanceID(TestUIntInstance)
+	require.NoError(t, err)
+	ok, _ := claim.CompareAgainstReference(*ref)
+	assert.True(t, ok)
+
+	ref, err = NewTeeInstanceID([]byte{0x2d})
+	require.NoError(t, err)
+	ok, reason := claim.CompareAgainstReference(*ref)
+	assert.False(t, ok)
+	assert.Equal(t, "claimed 45 does not match reference [45]", reason)
+}
+
+func TestTeeISVProdID_CompareAgainstReference(t *testing.T) {
+	claim, err := NewTeeISVProdID([]byte{0x01, 0x02})
+	require.NoError(t, err)
+
+	ok, _ := claim.CompareAgainstReference(TeeISVProdID{val: []byte{0x01, 0x02}})
+	assert.True(t, ok)
+
+	ok, _ = claim.CompareAgainstReference(TeeISVProdID{val: []byte{0x01}})
+	assert.False(t, ok)
+}
+
+func testTDXMval(ext *MValExtensions) comid.Mval {
+	var m comid.Mval
+	m.Register(ext)
+	return m
+}
+
+func TestMValExtensions_CompareMval(t *testing.T) {
+	svn, err := NewSvnUint(TestISVSVN)
+	require.NoError(t, err)
+	minSVN, err := NewSvnExpression(TestISVSVN)
+	require.NoError(t, err)
+	advisories, err := NewTeeAdvisoryIDsExpr(NMEM, []string{"INTEL-SA-00079"})
+	require.NoError(t, err)
+	mrSigner, err := NewTeeDigest(getNewDigests())
+	require.NoError(t, err)
+	mrSignerExpr, err := NewTeeDigestExpr(MEM, getNewDigests())
+	require.NoError(t, err)
+	pceID := TeePCEID(TestPCEID)
+	tcbDate, err := time.Parse(time.RFC3339, TestTime)
+	require.NoError(t, err)
+
+	claim := testTDXMval(&MValExtensions{
+		TeeISVSVN:     svn,
+		TeeMrSigner:   mrSigner,
+		TeePCEID:      &pceID,
+		TeeTcbDate:    &tcbDate,
+		TeeAttributes: &TeeAttributes{0x01, 0x10},
+	})
+
+	ref := testTDXMval(&MValExtensions{
+		TeeISVSVN:      minSVN,
+		TeeMrSigner:    mrSignerExpr,
+		TeePCEID:       &pceID,
+		TeeTcbDate:     &tcbDate,
+		TeeAttributes:  &TeeAttributes{0x01, 0x10},
+		TeeAdvisoryIDs: advisories,
+	})
+
+	res := claim.CompareAgainstReference(ref)
+	assert.True(t, res.Matched(), "%v", res.Err())
+	assert.Len(t, res.Fields, 6)
+
+	otherPCEID := TeePCEID("PCEID002")
+	ref = testTDXMval(&MValExtensions{
+		TeePCEID:      &otherPCEID,
+		TeeMiscSelect: &TeeMiscSelect{0x00},
+	})
+
+	res = claim.CompareAgainstReference(ref)
+	assert.False(t, res.Matched())
+	assert.EqualError(t, res.Err(), `measurement values mismatch: `+
+		`pceid: claimed "PCEID001" does not match reference "PCEID002"; `+
+		`miscselect: not present in claim`)
+}
+
+func TestMValExtensions_CompareMval_CBOR(t *testing.T) {
+	// values decoded from CBOR are held as uint64
+	var svn, minSVN TeeSVN
+	require.NoError(t, svn.UnmarshalCBOR([]byte{0x0a}))
+	// 60010([2, 9])
+	require.NoError(t, minSVN.UnmarshalCBOR([]byte{0xd9, 0xea, 0x6a, 0x82, 0x02, 0x09}))
+	require.True(t, minSVN.IsExpression())
+
+	res := testTDXMval(&MValExtensions{TeeISVSVN: &svn}).
+		CompareAgainstReference(testTDXMval(&MValExtensions{TeeISVSVN: &minSVN}))
+	assert.True(t, res.Matched(), "%v", res.Err())
+
+	require.NoError(t, svn.UnmarshalCBOR([]byte{0x08}))
+	res = testTDXMval(&MValExtensions{TeeISVSVN: &svn}).
+		CompareAgainstReference(testTDXMval(&MValExtensions{TeeISVSVN: &minSVN}))
+	assert.EqualError(t, res.Err(),
+		"measurement values mismatch: isvsvn: 8 is not greater_or_equal 9")
+}
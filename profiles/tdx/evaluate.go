@@ -0,0 +1,215 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package tdx
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/veraison/corim/comid"
+)
+
+// Evaluate checks the supplied (evidence) value against the numeric
+// expression, i.e., it checks whether "val <operator> expression-value"
+// holds. The supported values are unsigned integers, signed integers, float64
+// and NumericType. If the expression is not satisfied, a reason is returned.
+func (o NumericExpression) Evaluate(val any) (bool, string) {
+	if nt, ok := val.(NumericType); ok {
+		val = nt.val
+	}
+
+	lhs, err := numericToRat(val)
+	if err != nil {
+		return false, fmt.Sprintf("claimed value: %v", err)
+	}
+
+	rhs, err := numericToRat(o.NumericType.val)
+	if err != nil {
+		return false, fmt.Sprintf("reference value: %v", err)
+	}
+
+	cmp := lhs.Cmp(rhs)
+
+	var ok bool
+
+	switch o.NumericOperator {
+	case EQ:
+		ok = cmp == 0
+	case GT:
+		ok = cmp > 0
+	case GE:
+		ok = cmp >= 0
+	case LT:
+		ok = cmp < 0
+	case LE:
+		ok = cmp <= 0
+	default:
+		return false, fmt.Sprintf("invalid numeric operator %d", o.NumericOperator)
+	}
+
+	if !ok {
+		return false, fmt.Sprintf("%v is not %s %v",
+			val, NumericOperatorToString[o.NumericOperator], o.NumericType.val)
+	}
+
+	return true, ""
+}
+
+// Evaluate checks the supplied value against the numeric expression. See
+// NumericExpression.Evaluate
+func (o TaggedNumericExpression) Evaluate(val any) (bool, string) {
+	return NumericExpression(o).Evaluate(val)
+}
+
+// Evaluate checks the supplied (evidence) set of strings against the set
+// expression. The operators are interpreted as follows:
+//   - MEM: every supplied string is a member of the expression set
+//   - NMEM: none of the supplied strings is a member of the expression set
+//   - SUB: the supplied strings are a subset of the expression set
+//   - SUP: the supplied strings are a superset of the expression set
+//   - DIS: the supplied strings and the expression set are disjoint
+//   - EQ: the supplied strings and the expression set are the same set
+//
+// If the expression is not satisfied, a reason is returned.
+func (o SetStringExpression) Evaluate(vals []string) (bool, string) {
+	set := make(map[string]bool, len(o.SetString))
+	for _, s := range o.SetString {
+		set[s] = true
+	}
+
+	switch o.SetOperator {
+	case MEM:
+		if len(vals) == 0 {
+			return false, "no value to check for membership"
+		}
+		for _, v := range vals {
+			if !set[v] {
+				return false, fmt.Sprintf("%q is not a member of %v", v, []string(o.SetString))
+			}
+		}
+	case NMEM, DIS:
+		for _, v := range vals {
+			if set[v] {
+				return false, fmt.Sprintf("%q is a member of %v", v, []string(o.SetString))
+			}
+		}
+	case SUB:
+		if !isSubset(vals, set) {
+			return false, fmt.Sprintf("%v is not a subset of %v", vals, []string(o.SetString))
+		}
+	case SUP:
+		if !isSubset(o.SetString, stringSet(vals)) {
+			return false, fmt.Sprintf("%v is not a superset of %v", vals, []string(o.SetString))
+		}
+	case EQ:
+		if !stringSetsEqual(vals, o.SetString) {
+			return false, fmt.Sprintf("%v is not equal to %v", vals, []string(o.SetString))
+		}
+	default:
+		return false, fmt.Sprintf("invalid set operator %d", o.SetOperator)
+	}
+
+	return true, ""
+}
+
+// Evaluate checks the supplied set of strings against the set expression. See
+// SetStringExpression.Evaluate
+func (o TaggedSetStringExpression) Evaluate(vals []string) (bool, string) {
+	return SetStringExpression(o).Evaluate(vals)
+}
+
+// Evaluate checks the supplied (evidence) digests against the set expression.
+// The supplied digests are alternative representations (using different
+// algorithms) of the same measurement, therefore:
+//   - MEM: at least one of the supplied digests is in the expression set
+//   - NMEM: none of the supplied digests is in the expression set
+//
+// If the expression is not satisfied, a reason is returned.
+func (o SetDigestExpression) Evaluate(claim comid.Digests) (bool, string) {
+	if len(claim) == 0 {
+		return false, "no digests to evaluate"
+	}
+
+	found := -1
+	for i, d := range claim {
+		if digestInSet(d, comid.Digests(o.SetDigest)) {
+			found = i
+			break
+		}
+	}
+
+	switch o.SetOperator {
+	case MEM:
+		if found == -1 {
+			return false, "none of the claimed digests is a member of the reference set"
+		}
+	case NMEM:
+		if found != -1 {
+			return false, fmt.Sprintf(
+				"claimed digest at index %d is a member of the reference set", found)
+		}
+	default:
+		return false, fmt.Sprintf("invalid set operator %d", o.SetOperator)
+	}
+
+	return true, ""
+}
+
+// Evaluate checks the supplied digests against the set expression. See
+// SetDigestExpression.Evaluate
+func (o TaggedSetDigestExpression) Evaluate(claim comid.Digests) (bool, string) {
+	return SetDigestExpression(o).Evaluate(claim)
+}
+
+func numericToRat(val any) (*big.Rat, error) {
+	switch t := val.(type) {
+	case uint:
+		return new(big.Rat).SetUint64(uint64(t)), nil
+	case uint64:
+		return new(big.Rat).SetUint64(t), nil
+	case int:
+		return new(big.Rat).SetInt64(int64(t)), nil
+	case int64:
+		return new(big.Rat).SetInt64(t), nil
+	case float64:
+		r := new(big.Rat).SetFloat64(t)
+		if r == nil {
+			return nil, fmt.Errorf("non-finite float %v", t)
+		}
+		return r, nil
+	default:
+		return nil, fmt.Errorf("unsupported numeric type %T", t)
+	}
+}
+
+func stringSet(vals []string) map[string]bool {
+	set := make(map[string]bool, len(vals))
+	for _, v := range vals {
+		set[v] = true
+	}
+	return set
+}
+
+func isSubset(vals []string, set map[string]bool) bool {
+	for _, v := range vals {
+		if !set[v] {
+			return false
+		}
+	}
+	return true
+}
+
+func stringSetsEqual(lhs, rhs []string) bool {
+	return isSubset(lhs, stringSet(rhs)) && isSubset(rhs, stringSet(lhs))
+}
+
+func digestInSet(d comid.Digest, set comid.Digests) bool {
+	for _, s := range set {
+		if s.Algorithm == d.Algorithm && bytes.Equal(s.Value, d.Value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package tdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/comid"
+)
+
+func TestNumericExpression_Evaluate(t *testing.T) {
+	testCases := []struct {
+		op       uint
+		ref      any
+		val      any
+		expected bool
+	}{
+		{GE, uint(10), uint(10), true},
+		{GE, uint(10), uint64(11), true},
+		{GE, uint(10), uint(9), false},
+		{GT, uint(10), uint(10), false},
+		{GT, 10, uint64(11), true},
+		{LT, uint64(10), -1, true},
+		{LT, 2.5, uint(3), false},
+		{LE, 2.5, 2.5, true},
+		{LE, -3, int64(-4), true},
+	}
+
+	for _, tc := range testCases {
+		expr, err := NewTaggedNumericExpression(tc.op, tc.ref)
+		require.NoError(t, err)
+
+		ok, reason := expr.Evaluate(tc.val)
+		assert.Equal(t, tc.expected, ok, "%v %s %v", tc.val, NumericOperatorToString[Operator(tc.op)], tc.ref)
+		if ok {
+			assert.Empty(t, reason)
+		} else {
+			assert.NotEmpty(t, reason)
+		}
+	}
+}
+
+func TestNumericExpression_Evaluate_NOK(t *testing.T) {
+	expr, err := NewTaggedNumericExpression(GE, uint(10))
+	require.NoError(t, err)
+
+	ok, reason := expr.Evaluate(uint(9))
+	assert.False(t, ok)
+	assert.Equal(t, "9 is not greater_or_equal 10", reason)
+
+	ok, reason = expr.Evaluate("10")
+	assert.False(t, ok)
+	assert.Equal(t, "claimed value: unsupported numeric type string", reason)
+
+	expr.NumericOperator = MEM
+	ok, reason = expr.Evaluate(uint(10))
+	assert.False(t, ok)
+	assert.Equal(t, "invalid numeric operator 6", reason)
+
+	nt, err := NewNumericType(uint(11))
+	require.NoError(t, err)
+	expr.NumericOperator = EQ
+	ok, _ = expr.Evaluate(*nt)
+	assert.False(t, ok)
+}
+
+func TestSetStringExpression_Evaluate(t *testing.T) {
+	set := SetString{"UpToDate", "SWHardeningNeeded"}
+
+	testCases := []struct {
+		op       Operator
+		vals     []string
+		expected bool
+	}{
+		{MEM, []string{"UpToDate"}, true},
+		{MEM, []string{"OutOfDate"}, false},
+		{MEM, nil, false},
+		{NMEM, []string{"OutOfDate"}, true},
+		{NMEM, []string{"OutOfDate", "UpToDate"}, false},
+		{NMEM, nil, true},
+		{SUB, []string{"SWHardeningNeeded"}, true},
+		{SUB, []string{"SWHardeningNeeded", "OutOfDate"}, false},
+		{SUP, []string{"SWHardeningNeeded", "UpToDate", "OutOfDate"}, true},
+		{SUP, []string{"UpToDate"}, false},
+		{DIS, []string{"OutOfDate"}, true},
+		{DIS, []string{"UpToDate"}, false},
+		{EQ, []string{"SWHardeningNeeded", "UpToDate"}, true},
+		{EQ, []string{"UpToDate"}, false},
+		{GE, []string{"UpToDate"}, false},
+	}
+
+	for _, tc := range testCases {
+		expr := TaggedSetStringExpression{SetOperator: tc.op, SetString: set}
+		ok, reason := expr.Evaluate(tc.vals)
+		assert.Equal(t, tc.expected, ok, "%v %s %v", tc.vals, NumericOperatorToString[tc.op], set)
+		if !ok {
+			assert.NotEmpty(t, reason)
+		}
+	}
+}
+
+func TestSetStringExpression_Evaluate_reason(t *testing.T) {
+	expr, err := NewTaggedSetStringExpression(NMEM, []string{"INTEL-SA-00079", "INTEL-SA-00099"})
+	require.NoError(t, err)
+
+	ok, reason := expr.Evaluate([]string{"INTEL-SA-00001", "INTEL-SA-00099"})
+	assert.False(t, ok)
+	assert.Equal(t, `"INTEL-SA-00099" is a member of [INTEL-SA-00079 INTEL-SA-00099]`, reason)
+}
+
+func TestSetDigestExpression_Evaluate(t *testing.T) {
+	ref := getNewDigests()
+	other := comid.NewDigests().AddDigest(comid.Sha256, make([]byte, 32))
+
+	mem, err := NewTaggedSetDigestExpression(MEM, ref)
+	require.NoError(t, err)
+	nmem, err := NewTaggedSetDigestExpression(NMEM, ref)
+	require.NoError(t, err)
+
+	// the claim holds the same measurement computed with different algorithms
+	claim := append(Digests{}, (*other)[0], ref[0])
+
+	ok, reason := mem.Evaluate(claim)
+	assert.True(t, ok)
+	assert.Empty(t, reason)
+
+	ok, reason = nmem.Evaluate(claim)
+	assert.False(t, ok)
+	assert.Equal(t, "claimed digest at index 1 is a member of the reference set", reason)
+
+	ok, reason = mem.Evaluate(*other)
+	assert.False(t, ok)
+	assert.Equal(t, "none of the claimed digests is a member of the reference set", reason)
+
+	ok, _ = nmem.Evaluate(*other)
+	assert.True(t, ok)
+
+	ok, reason = mem.Evaluate(nil)
+	assert.False(t, ok)
+	assert.Equal(t, "no digests to evaluate", reason)
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package spdm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/veraison/corim/coev"
+	"github.com/veraison/corim/comid"
+)
+
+// ToEvTriples maps the supplied measurement blocks to an evidence triple for
+// the environment of the SPDM responder, with one measurement per block (see
+// MeasurementBlock.Measurement). hashAlg is the negotiated measurement hash
+// algorithm, as a comid.Sha* algorithm.
+func ToEvTriples(env comid.Environment, blocks []MeasurementBlock, hashAlg int) (*coev.EvTriples, error) {
+	vt, err := valueTriple(env, blocks, hashAlg, false)
+	if err != nil {
+		return nil, err
+	}
+
+	evTriples := coev.NewEvTriples().AddEvidenceTriple(vt)
+
+	if err := evTriples.Valid(); err != nil {
+		return nil, fmt.Errorf("invalid evidence triples: %w", err)
+	}
+
+	return evTriples, nil
+}
+
+func valueTriple(env comid.Environment, blocks []MeasurementBlock, hashAlg int, minSVN bool) (*comid.ValueTriple, error) {
+	if len(blocks) == 0 {
+		return nil, errors.New("no measurement blocks")
+	}
+
+	vt := &comid.ValueTriple{Environment: env}
+
+	for _, b := range blocks {
+		m, err := b.Measurement(hashAlg, minSVN)
+		if err != nil {
+			return nil, fmt.Errorf("measurement block %d: %w", b.Index, err)
+		}
+
+		vt.Measurements.Add(m)
+	}
+
+	return vt, nil
+}
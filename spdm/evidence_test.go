@@ -0,0 +1,64 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package spdm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/coev"
+	"github.com/veraison/corim/comid"
+)
+
+func testEnvironment() comid.Environment {
+	return comid.Environment{
+		Class: (&comid.Class{}).SetVendor("ACME").SetModel("PCIe NIC"),
+	}
+}
+
+func TestToEvTriples(t *testing.T) {
+	blocks, err := ParseMeasurementRecord(testRecord())
+	require.NoError(t, err)
+
+	evTriples, err := ToEvTriples(testEnvironment(), blocks, comid.Sha384)
+	require.NoError(t, err)
+
+	require.NotNil(t, evTriples.EvidenceTriples)
+	require.Len(t, evTriples.EvidenceTriples.Values, 1)
+
+	vt := evTriples.EvidenceTriples.Values[0]
+	assert.Equal(t, testEnvironment(), vt.Environment)
+	require.Len(t, vt.Measurements.Values, len(blocks))
+
+	for i, m := range vt.Measurements.Values {
+		index, err := m.Key.GetKeyUint()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(blocks[i].Index), index)
+	}
+
+	ce := coev.NewConciseEvidence()
+	require.NoError(t, ce.AddTriples(evTriples))
+
+	data, err := ce.ToCBOR()
+	require.NoError(t, err)
+
+	var out coev.ConciseEvidence
+	require.NoError(t, out.FromCBOR(data))
+	assert.Len(t, out.EvTriples.EvidenceTriples.Values[0].Measurements.Values, len(blocks))
+}
+
+func TestToEvTriples_NOK(t *testing.T) {
+	_, err := ToEvTriples(testEnvironment(), nil, comid.Sha384)
+	assert.EqualError(t, err, "no measurement blocks")
+
+	blocks, err := ParseMeasurementRecord(testRecord())
+	require.NoError(t, err)
+
+	_, err = ToEvTriples(testEnvironment(), blocks, comid.Sha256)
+	assert.ErrorContains(t, err, "measurement block 1: digest: ")
+
+	_, err = ToEvTriples(comid.Environment{}, blocks, comid.Sha384)
+	assert.ErrorContains(t, err, "invalid evidence triples: ")
+}
@@ -0,0 +1,260 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package spdm decodes SPDM measurement records, as returned in GET_MEASUREMENTS
+// responses, and maps their DMTF measurement blocks to Concise Evidence, or to
+// CoMID reference values when the measurements are golden ones.
+package spdm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/veraison/corim/comid"
+)
+
+// MeasurementSpecDMTF is the bit of the MeasurementSpecification field of a
+// measurement block selecting the DMTF measurement specification
+const MeasurementSpecDMTF = 0x01
+
+// DMTF measurement value types (DSP0274, DMTFSpecMeasurementValueType bits
+// [6:0])
+const (
+	ValueTypeImmutableROM        = 0x00
+	ValueTypeMutableFirmware     = 0x01
+	ValueTypeHardwareConfig      = 0x02
+	ValueTypeFirmwareConfig      = 0x03
+	ValueTypeMeasurementManifest = 0x04
+	ValueTypeDeviceMode          = 0x05
+	ValueTypeVersion             = 0x06
+	ValueTypeSecurityVersion     = 0x07
+
+	// valueTypeRawBitStream is bit 7 of DMTFSpecMeasurementValueType: set if
+	// the value is a raw bit stream, clear if it is a digest
+	valueTypeRawBitStream = 0x80
+)
+
+// Bits of the operational and device mode fields of a device mode measurement
+const (
+	OperationalModeManufacturing  = 1 << 0
+	OperationalModeValidation     = 1 << 1
+	OperationalModeNormal         = 1 << 2
+	OperationalModeRecovery       = 1 << 3
+	OperationalModeRMA            = 1 << 4
+	OperationalModeDecommissioned = 1 << 5
+
+	DeviceModeNonInvasiveDebug = 1 << 0
+	DeviceModeInvasiveDebug    = 1 << 1
+)
+
+const (
+	blockHeaderSize     = 4
+	dmtfHeaderSize      = 3
+	deviceModeValueSize = 16
+)
+
+// MeasurementBlock is a DMTF measurement block of an SPDM measurement record
+type MeasurementBlock struct {
+	Index uint8
+	// ValueType is one of the ValueType* constants
+	ValueType uint8
+	// RawBitStream is true if Value is a raw bit stream, false if it is a
+	// digest computed with the negotiated measurement hash algorithm
+	RawBitStream bool
+	Value        []byte
+}
+
+// DeviceMode is a decoded device mode measurement value
+type DeviceMode struct {
+	OperationalModeCapabilities uint32
+	OperationalModeState        uint32
+	DeviceModeCapabilities      uint32
+	DeviceModeState             uint32
+}
+
+// ParseMeasurementRecord parses an SPDM measurement record, i.e., the
+// concatenation of measurement blocks found in a MEASUREMENTS response. Only
+// blocks using the DMTF measurement specification are supported. Block
+// indices must be unique.
+func ParseMeasurementRecord(data []byte) ([]MeasurementBlock, error) {
+	var (
+		ret  []MeasurementBlock
+		off  int
+		seen = make(map[uint8]bool)
+	)
+
+	for off < len(data) {
+		b, n, err := parseMeasurementBlock(data[off:])
+		if err != nil {
+			return nil, fmt.Errorf("measurement block at offset %d: %w", off, err)
+		}
+
+		if seen[b.Index] {
+			return nil, fmt.Errorf("measurement block at offset %d: duplicate index %d", off, b.Index)
+		}
+		seen[b.Index] = true
+
+		ret = append(ret, *b)
+		off += n
+	}
+
+	if len(ret) == 0 {
+		return nil, errors.New("empty measurement record")
+	}
+
+	return ret, nil
+}
+
+func parseMeasurementBlock(data []byte) (*MeasurementBlock, int, error) {
+	if len(data) < blockHeaderSize {
+		return nil, 0, fmt.Errorf("need %d header bytes, %d left", blockHeaderSize, len(data))
+	}
+
+	index, spec := data[0], data[1]
+	size := int(binary.LittleEndian.Uint16(data[2:4]))
+
+	if index == 0 || index == 0xff {
+		return nil, 0, fmt.Errorf("reserved index %d", index)
+	}
+
+	if spec&MeasurementSpecDMTF == 0 {
+		return nil, 0, fmt.Errorf("index %d: unsupported measurement specification 0x%02x", index, spec)
+	}
+
+	if len(data)-blockHeaderSize < size {
+		return nil, 0, fmt.Errorf("index %d: need %d measurement bytes, %d left",
+			index, size, len(data)-blockHeaderSize)
+	}
+
+	m := data[blockHeaderSize : blockHeaderSize+size]
+
+	if len(m) < dmtfHeaderSize {
+		return nil, 0, fmt.Errorf("index %d: measurement too short for a DMTF measurement", index)
+	}
+
+	valueSize := int(binary.LittleEndian.Uint16(m[1:3]))
+
+	if valueSize != len(m)-dmtfHeaderSize {
+		return nil, 0, fmt.Errorf("index %d: DMTF measurement value size %d, expected %d",
+			index, valueSize, len(m)-dmtfHeaderSize)
+	}
+
+	b := &MeasurementBlock{
+		Index:        index,
+		ValueType:    m[0] &^ valueTypeRawBitStream,
+		RawBitStream: m[0]&valueTypeRawBitStream != 0,
+		Value:        append([]byte(nil), m[dmtfHeaderSize:]...),
+	}
+
+	return b, blockHeaderSize + size, nil
+}
+
+// DeviceMode decodes the value of a raw bit stream device mode measurement
+func (o MeasurementBlock) DeviceMode() (*DeviceMode, error) {
+	if o.ValueType != ValueTypeDeviceMode || !o.RawBitStream {
+		return nil, errors.New("not a raw bit stream device mode measurement")
+	}
+
+	if len(o.Value) != deviceModeValueSize {
+		return nil, fmt.Errorf("device mode: expected %d bytes, got %d", deviceModeValueSize, len(o.Value))
+	}
+
+	return &DeviceMode{
+		OperationalModeCapabilities: binary.LittleEndian.Uint32(o.Value[0:4]),
+		OperationalModeState:        binary.LittleEndian.Uint32(o.Value[4:8]),
+		DeviceModeCapabilities:      binary.LittleEndian.Uint32(o.Value[8:12]),
+		DeviceModeState:             binary.LittleEndian.Uint32(o.Value[12:16]),
+	}, nil
+}
+
+// FlagsMap maps the target DeviceMode to a comid.FlagsMap: normal operational
+// mode to is-configured, recovery mode to is-recovery and (invasive or
+// non-invasive) debug mode to is-debug. Flags are only set for the modes that
+// the device declares in its capabilities. Nil is returned if none is.
+func (o DeviceMode) FlagsMap() *comid.FlagsMap {
+	flags := comid.NewFlagsMap()
+
+	set := func(flag comid.Flag, caps, state, mask uint32) {
+		if caps&mask == 0 {
+			return
+		}
+
+		if state&caps&mask != 0 {
+			flags.SetTrue(flag)
+		} else {
+			flags.SetFalse(flag)
+		}
+	}
+
+	set(comid.FlagIsConfigured, o.OperationalModeCapabilities, o.OperationalModeState,
+		OperationalModeNormal)
+	set(comid.FlagIsRecovery, o.OperationalModeCapabilities, o.OperationalModeState,
+		OperationalModeRecovery)
+	set(comid.FlagIsDebug, o.DeviceModeCapabilities, o.DeviceModeState,
+		DeviceModeNonInvasiveDebug|DeviceModeInvasiveDebug)
+
+	if !flags.AnySet() {
+		return nil
+	}
+
+	return flags
+}
+
+// Measurement maps the target MeasurementBlock to a measurement keyed by the
+// block index (as a uint mkey):
+//
+//   - a digest is set as a digest using hashAlg, the measurement hash
+//     algorithm negotiated in the SPDM session (a comid.Sha* algorithm)
+//   - a raw bit stream device mode is set as flags (see DeviceMode.FlagsMap)
+//   - a raw bit stream security version number (up to 8 bytes, little-endian)
+//     is set as a svn, or as a min-svn if minSVN is true
+//   - any other raw bit stream is set as a raw value
+func (o MeasurementBlock) Measurement(hashAlg int, minSVN bool) (*comid.Measurement, error) {
+	m, err := comid.NewUintMeasurement(uint64(o.Index))
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !o.RawBitStream:
+		if err := comid.NewDigestIntAlg(hashAlg, o.Value).Valid(); err != nil {
+			return nil, fmt.Errorf("digest: %w", err)
+		}
+
+		m.AddDigest(hashAlg, o.Value)
+	case o.ValueType == ValueTypeDeviceMode:
+		dm, err := o.DeviceMode()
+		if err != nil {
+			return nil, err
+		}
+
+		if flags := dm.FlagsMap(); flags != nil {
+			m.Val.Flags = flags
+		} else {
+			m.SetRawValueBytes(o.Value, nil)
+		}
+	case o.ValueType == ValueTypeSecurityVersion:
+		if len(o.Value) == 0 || len(o.Value) > 8 {
+			return nil, fmt.Errorf("security version number: unexpected size %d", len(o.Value))
+		}
+
+		var buf [8]byte
+		copy(buf[:], o.Value)
+		svn := binary.LittleEndian.Uint64(buf[:])
+
+		if minSVN {
+			m.SetMinSVN(svn)
+		} else {
+			m.SetSVN(svn)
+		}
+	default:
+		if len(o.Value) == 0 {
+			return nil, errors.New("empty raw bit stream")
+		}
+
+		m.SetRawValueBytes(o.Value, nil)
+	}
+
+	return m, nil
+}
@@ -0,0 +1,227 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package spdm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/comid"
+)
+
+var (
+	testROMDigest = bytes.Repeat([]byte{0xaa}, 48)
+	testFWDigest  = bytes.Repeat([]byte{0xbb}, 48)
+	testVersion   = []byte("1.4.2")
+	testSVN       = []byte{0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+)
+
+// testDeviceMode returns a device mode value for a device that supports the
+// normal and recovery operational modes and invasive debug, and is in normal
+// mode with debug disabled
+func testDeviceMode() []byte {
+	v := make([]byte, deviceModeValueSize)
+
+	binary.LittleEndian.PutUint32(v[0:], OperationalModeNormal|OperationalModeRecovery)
+	binary.LittleEndian.PutUint32(v[4:], OperationalModeNormal)
+	binary.LittleEndian.PutUint32(v[8:], DeviceModeInvasiveDebug)
+	binary.LittleEndian.PutUint32(v[12:], 0)
+
+	return v
+}
+
+func testBlock(index, valueType uint8, value []byte) []byte {
+	var b bytes.Buffer
+
+	b.WriteByte(index)
+	b.WriteByte(MeasurementSpecDMTF)
+	_ = binary.Write(&b, binary.LittleEndian, uint16(dmtfHeaderSize+len(value)))
+	b.WriteByte(valueType)
+	_ = binary.Write(&b, binary.LittleEndian, uint16(len(value)))
+	b.Write(value)
+
+	return b.Bytes()
+}
+
+// testRecord returns a measurement record with a digest of the immutable ROM
+// and of the mutable firmware (SHA-384), a device mode, a version and a
+// security version number
+func testRecord() []byte {
+	return bytes.Join([][]byte{
+		testBlock(1, ValueTypeImmutableROM, testROMDigest),
+		testBlock(2, ValueTypeMutableFirmware, testFWDigest),
+		testBlock(3, ValueTypeDeviceMode|valueTypeRawBitStream, testDeviceMode()),
+		testBlock(4, ValueTypeVersion|valueTypeRawBitStream, testVersion),
+		testBlock(5, ValueTypeSecurityVersion|valueTypeRawBitStream, testSVN),
+	}, nil)
+}
+
+func TestMeasurementBlock_ParseMeasurementRecord(t *testing.T) {
+	blocks, err := ParseMeasurementRecord(testRecord())
+	require.NoError(t, err)
+
+	assert.Equal(t, []MeasurementBlock{
+		{Index: 1, ValueType: ValueTypeImmutableROM, Value: testROMDigest},
+		{Index: 2, ValueType: ValueTypeMutableFirmware, Value: testFWDigest},
+		{Index: 3, ValueType: ValueTypeDeviceMode, RawBitStream: true, Value: testDeviceMode()},
+		{Index: 4, ValueType: ValueTypeVersion, RawBitStream: true, Value: testVersion},
+		{Index: 5, ValueType: ValueTypeSecurityVersion, RawBitStream: true, Value: testSVN},
+	}, blocks)
+}
+
+func TestMeasurementBlock_ParseMeasurementRecord_NOK(t *testing.T) {
+	block := testBlock(1, ValueTypeImmutableROM, testROMDigest)
+
+	patch := func(off int, b ...byte) []byte {
+		out := bytes.Clone(block)
+		copy(out[off:], b)
+		return out
+	}
+
+	testCases := []struct {
+		name string
+		data []byte
+		err  string
+	}{
+		{
+			name: "empty",
+			data: nil,
+			err:  "empty measurement record",
+		},
+		{
+			name: "truncated header",
+			data: append(bytes.Clone(block), 0x02, 0x01),
+			err:  "measurement block at offset 55: need 4 header bytes, 2 left",
+		},
+		{
+			name: "reserved index",
+			data: patch(0, 0xff),
+			err:  "measurement block at offset 0: reserved index 255",
+		},
+		{
+			name: "not DMTF",
+			data: patch(1, 0x02),
+			err:  "measurement block at offset 0: index 1: unsupported measurement specification 0x02",
+		},
+		{
+			name: "truncated measurement",
+			data: block[:20],
+			err:  "measurement block at offset 0: index 1: need 51 measurement bytes, 16 left",
+		},
+		{
+			name: "inconsistent value size",
+			data: patch(5, 0x10),
+			err:  "measurement block at offset 0: index 1: DMTF measurement value size 16, expected 48",
+		},
+		{
+			name: "no DMTF header",
+			data: []byte{0x01, 0x01, 0x00, 0x00},
+			err:  "measurement block at offset 0: index 1: measurement too short for a DMTF measurement",
+		},
+		{
+			name: "duplicate index",
+			data: append(bytes.Clone(block), block...),
+			err:  "measurement block at offset 55: duplicate index 1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseMeasurementRecord(tc.data)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestMeasurementBlock_DeviceMode(t *testing.T) {
+	b := MeasurementBlock{Index: 3, ValueType: ValueTypeDeviceMode, RawBitStream: true, Value: testDeviceMode()}
+
+	dm, err := b.DeviceMode()
+	require.NoError(t, err)
+	assert.Equal(t, &DeviceMode{
+		OperationalModeCapabilities: OperationalModeNormal | OperationalModeRecovery,
+		OperationalModeState:        OperationalModeNormal,
+		DeviceModeCapabilities:      DeviceModeInvasiveDebug,
+	}, dm)
+
+	expected := comid.NewFlagsMap()
+	expected.SetTrue(comid.FlagIsConfigured)
+	expected.SetFalse(comid.FlagIsRecovery, comid.FlagIsDebug)
+	assert.Equal(t, expected, dm.FlagsMap())
+
+	// nothing declared in the capabilities
+	assert.Nil(t, DeviceMode{OperationalModeState: OperationalModeNormal}.FlagsMap())
+
+	b.Value = b.Value[:8]
+	_, err = b.DeviceMode()
+	assert.EqualError(t, err, "device mode: expected 16 bytes, got 8")
+
+	b.RawBitStream = false
+	_, err = b.DeviceMode()
+	assert.EqualError(t, err, "not a raw bit stream device mode measurement")
+}
+
+func TestMeasurementBlock_Measurement(t *testing.T) {
+	blocks, err := ParseMeasurementRecord(testRecord())
+	require.NoError(t, err)
+
+	for _, b := range blocks {
+		m, err := b.Measurement(comid.Sha384, false)
+		require.NoError(t, err)
+		require.NoError(t, m.Valid())
+
+		expectedKey, err := comid.NewMkeyUint(uint64(b.Index))
+		require.NoError(t, err)
+		assert.Equal(t, expectedKey, m.Key)
+	}
+
+	m, err := blocks[0].Measurement(comid.Sha384, false)
+	require.NoError(t, err)
+	assert.Equal(t, comid.Digests{*comid.NewDigestIntAlg(comid.Sha384, testROMDigest)}, *m.Val.Digests)
+
+	m, err = blocks[2].Measurement(comid.Sha384, false)
+	require.NoError(t, err)
+	require.NotNil(t, m.Val.Flags)
+	assert.Equal(t, &comid.True, m.Val.Flags.IsConfigured)
+	assert.Nil(t, m.Val.RawValue)
+
+	m, err = blocks[3].Measurement(comid.Sha384, false)
+	require.NoError(t, err)
+	assert.Equal(t, comid.NewRawValueFromBytes(testVersion), m.Val.RawValue)
+
+	m, err = blocks[4].Measurement(comid.Sha384, false)
+	require.NoError(t, err)
+	assert.Equal(t, comid.MustNewTaggedSVN(uint64(5)), m.Val.SVN)
+
+	m, err = blocks[4].Measurement(comid.Sha384, true)
+	require.NoError(t, err)
+	assert.Equal(t, comid.MustNewTaggedMinSVN(uint64(5)), m.Val.SVN)
+
+	// a device mode with no declared capabilities is kept as a raw value
+	noCaps := MeasurementBlock{
+		Index: 3, ValueType: ValueTypeDeviceMode, RawBitStream: true, Value: make([]byte, deviceModeValueSize),
+	}
+	m, err = noCaps.Measurement(comid.Sha384, false)
+	require.NoError(t, err)
+	assert.Nil(t, m.Val.Flags)
+	assert.Equal(t, comid.NewRawValueFromBytes(noCaps.Value), m.Val.RawValue)
+}
+
+func TestMeasurementBlock_Measurement_NOK(t *testing.T) {
+	_, err := MeasurementBlock{Index: 1, Value: testROMDigest}.Measurement(comid.Sha256, false)
+	assert.ErrorContains(t, err, "digest: ")
+
+	_, err = MeasurementBlock{
+		Index: 5, ValueType: ValueTypeSecurityVersion, RawBitStream: true, Value: make([]byte, 9),
+	}.Measurement(comid.Sha384, false)
+	assert.EqualError(t, err, "security version number: unexpected size 9")
+
+	_, err = MeasurementBlock{
+		Index: 4, ValueType: ValueTypeVersion, RawBitStream: true,
+	}.Measurement(comid.Sha384, false)
+	assert.EqualError(t, err, "empty raw bit stream")
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package spdm
+
+import (
+	"fmt"
+
+	"github.com/veraison/corim/comid"
+)
+
+// ReferenceOptions controls the generation of CoMID reference values from
+// golden SPDM measurements. With MinSVN, the SVNs carried by the measurement
+// blocks are set as min-svn.
+type ReferenceOptions = comid.ReferenceOptions
+
+// ToComid generates a CoMID with a reference value triple for the environment
+// of the SPDM responder, built from the supplied golden measurement blocks in
+// the same way as ToEvTriples does for evidence
+func ToComid(
+	env comid.Environment, blocks []MeasurementBlock, hashAlg int, opts ReferenceOptions,
+) (*comid.Comid, error) {
+	c, err := opts.NewComid()
+	if err != nil {
+		return nil, err
+	}
+
+	vt, err := valueTriple(env, blocks, hashAlg, opts.MinSVN)
+	if err != nil {
+		return nil, err
+	}
+
+	c.AddReferenceValue(vt)
+
+	if err := c.Valid(); err != nil {
+		return nil, fmt.Errorf("generated CoMID is not valid: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package spdm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/comid"
+)
+
+func TestToComid(t *testing.T) {
+	blocks, err := ParseMeasurementRecord(testRecord())
+	require.NoError(t, err)
+
+	c, err := ToComid(testEnvironment(), blocks, comid.Sha384,
+		ReferenceOptions{TagID: "spdm-golden", TagVersion: 2, MinSVN: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "spdm-golden", c.TagIdentity.TagID.String())
+	assert.Equal(t, uint(2), c.TagIdentity.TagVersion)
+
+	require.NotNil(t, c.Triples.ReferenceValues)
+	require.Len(t, c.Triples.ReferenceValues.Values, 1)
+
+	vt := c.Triples.ReferenceValues.Values[0]
+	assert.Equal(t, testEnvironment(), vt.Environment)
+	require.Len(t, vt.Measurements.Values, len(blocks))
+	assert.Equal(t, comid.MustNewTaggedMinSVN(uint64(5)), vt.Measurements.Values[4].Val.SVN)
+
+	data, err := c.ToCBOR()
+	require.NoError(t, err)
+
+	var out comid.Comid
+	require.NoError(t, out.FromCBOR(data))
+	require.NoError(t, out.Valid())
+}
+
+func TestToComid_NOK(t *testing.T) {
+	blocks, err := ParseMeasurementRecord(testRecord())
+	require.NoError(t, err)
+
+	_, err = ToComid(testEnvironment(), blocks, comid.Sha384, ReferenceOptions{TagID: 1})
+	assert.EqualError(t, err, "invalid tag ID: 1 (int)")
+
+	_, err = ToComid(testEnvironment(), nil, comid.Sha384, ReferenceOptions{TagID: "spdm-golden"})
+	assert.EqualError(t, err, "no measurement blocks")
+
+	_, err = ToComid(comid.Environment{}, blocks, comid.Sha384, ReferenceOptions{TagID: "spdm-golden"})
+	assert.ErrorContains(t, err, "generated CoMID is not valid: ")
+}
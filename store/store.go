@@ -0,0 +1,515 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package store implements an in-memory repository of CoRIMs, indexing the
+// CoMIDs they carry by CoRIM id, tag identity, environment and measurement
+// key. The linked-tag relations of the CoMIDs are taken into account when
+// deciding which of them are effective:
+//
+//   - a CoMID is superseded by any CoMID with the same tag-id and a higher
+//     tag-version;
+//   - a CoMID that "replaces" another retires every version of the linked
+//     tag-id;
+//   - a CoMID that "supplements" another is attached to it, and is only
+//     effective while all of its base tags are.
+//
+// See https://ietf-rats-wg.github.io/draft-ietf-rats-corim/draft-ietf-rats-corim.html#section-5.1.3
+package store
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/veraison/corim/comid"
+	"github.com/veraison/corim/corim"
+)
+
+var (
+	// ErrDuplicateCorim is returned when adding a CoRIM whose id is already
+	// in the Store.
+	ErrDuplicateCorim = errors.New("duplicate CoRIM")
+	// ErrDuplicateTag is returned when adding a CoMID whose tag identity
+	// (tag-id and tag-version) is already in the Store.
+	ErrDuplicateTag = errors.New("duplicate CoMID tag identity")
+	// ErrCorimNotFound is returned when removing a CoRIM that is not in the
+	// Store.
+	ErrCorimNotFound = errors.New("CoRIM not found")
+)
+
+// Tag is a CoMID held in a Store
+type Tag struct {
+	Comid *comid.Comid
+	// CorimID is the id of the CoRIM the CoMID was added with
+	CorimID string
+}
+
+// ID returns the tag-id of the CoMID as a string
+func (o Tag) ID() string {
+	return o.Comid.TagIdentity.TagID.String()
+}
+
+// Version returns the tag-version of the CoMID
+func (o Tag) Version() uint {
+	return o.Comid.TagIdentity.TagVersion
+}
+
+// links returns the tag-ids of the tags linked to the CoMID with the
+// specified relation
+func (o Tag) links(rel comid.Rel) []string {
+	var ret []string
+
+	if o.Comid.LinkedTags == nil {
+		return ret
+	}
+
+	for _, lt := range *o.Comid.LinkedTags {
+		if lt.Rel == rel {
+			ret = append(ret, lt.LinkedTagID.String())
+		}
+	}
+
+	return ret
+}
+
+// Value is a single measurement, together with its environment, taken from a
+// reference or endorsed value triple of a stored CoMID.
+type Value struct {
+	Tag         *Tag
+	Environment comid.Environment
+	Measurement comid.Measurement
+
+	// seq records the order in which values were added to the Store
+	seq uint64
+}
+
+// valueIndex indexes the values of one kind of triple
+type valueIndex struct {
+	// byEnv holds values keyed by the CBOR encoding of their environment
+	byEnv map[string][]*Value
+	// byMkey holds values keyed by the type and value of their mkey. Values
+	// without an mkey are not indexed here.
+	byMkey map[string][]*Value
+}
+
+func newValueIndex() valueIndex {
+	return valueIndex{
+		byEnv:  make(map[string][]*Value),
+		byMkey: make(map[string][]*Value),
+	}
+}
+
+func (o valueIndex) add(v *Value, envKey string) {
+	o.byEnv[envKey] = append(o.byEnv[envKey], v)
+
+	if k, ok := mkeyIndexKey(v.Measurement.Key); ok {
+		o.byMkey[k] = append(o.byMkey[k], v)
+	}
+}
+
+func (o valueIndex) removeCorim(id string) {
+	fromCorim := func(v *Value) bool { return v.Tag.CorimID == id }
+
+	for _, m := range []map[string][]*Value{o.byEnv, o.byMkey} {
+		for k, vs := range m {
+			if vs = slices.DeleteFunc(vs, fromCorim); len(vs) == 0 {
+				delete(m, k)
+			} else {
+				m[k] = vs
+			}
+		}
+	}
+}
+
+func mkeyIndexKey(k *comid.Mkey) (string, bool) {
+	if k == nil || !k.IsSet() {
+		return "", false
+	}
+
+	return k.Type() + ":" + k.Value.String(), true
+}
+
+// Store is an in-memory repository of CoRIMs. It is safe for concurrent use.
+type Store struct {
+	mu sync.RWMutex
+
+	corims map[string]*corim.UnsignedCorim
+	// corimTags holds the CoMIDs of each CoRIM, in the order they appear in
+	corimTags map[string][]*Tag
+	// tags holds all the versions of each tag-id, by ascending tag-version
+	tags map[string][]*Tag
+	// replacedBy holds, for each tag-id, the CoMIDs that replace it
+	replacedBy map[string][]*Tag
+	// supplementedBy holds, for each tag-id, the CoMIDs that supplement it
+	supplementedBy map[string][]*Tag
+
+	refVals valueIndex
+	endVals valueIndex
+	seq     uint64
+}
+
+// NewStore instantiates an empty Store
+func NewStore() *Store {
+	return &Store{
+		corims:         make(map[string]*corim.UnsignedCorim),
+		corimTags:      make(map[string][]*Tag),
+		tags:           make(map[string][]*Tag),
+		replacedBy:     make(map[string][]*Tag),
+		supplementedBy: make(map[string][]*Tag),
+		refVals:        newValueIndex(),
+		endVals:        newValueIndex(),
+	}
+}
+
+// AddCorim adds the supplied CoRIM and the CoMIDs it carries to the Store.
+// The CoRIM must have an id that is not already in the Store, and each of its
+// CoMIDs must be valid and have a tag identity that is not already in the
+// Store. Either the whole CoRIM is added, or (on error) nothing is.
+func (o *Store) AddCorim(uc *corim.UnsignedCorim) error {
+	if uc == nil {
+		return errors.New("nil CoRIM")
+	}
+
+	if err := uc.ID.Valid(); err != nil {
+		return fmt.Errorf("invalid CoRIM id: %w", err)
+	}
+
+	id := uc.GetID()
+
+	var tags []*Tag
+
+	seq, errFunc := uc.IterComidsSkipOthers()
+	for cm := range seq {
+		if err := cm.Valid(); err != nil {
+			return fmt.Errorf("CoMID at index %d: %w", len(tags), err)
+		}
+
+		tags = append(tags, &Tag{Comid: cm, CorimID: id})
+	}
+
+	if err := errFunc(); err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.corims[id]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateCorim, id)
+	}
+
+	for i, t := range tags {
+		if o.tag(t.ID(), t.Version()) != nil || slices.ContainsFunc(tags[:i], func(u *Tag) bool {
+			return u.ID() == t.ID() && u.Version() == t.Version()
+		}) {
+			return fmt.Errorf("%w: %s (version %d)", ErrDuplicateTag, t.ID(), t.Version())
+		}
+	}
+
+	o.corims[id] = uc
+	o.corimTags[id] = tags
+
+	for _, t := range tags {
+		o.addTag(t)
+	}
+
+	return nil
+}
+
+func (o *Store) addTag(t *Tag) {
+	versions := append(o.tags[t.ID()], t)
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Version() < versions[j].Version()
+	})
+	o.tags[t.ID()] = versions
+
+	for _, target := range t.links(comid.RelReplaces) {
+		o.replacedBy[target] = append(o.replacedBy[target], t)
+	}
+
+	for _, target := range t.links(comid.RelSupplements) {
+		o.supplementedBy[target] = append(o.supplementedBy[target], t)
+	}
+
+	for vt := range t.Comid.IterRefVals() {
+		o.addValues(o.refVals, t, vt)
+	}
+
+	for vt := range t.Comid.IterEndVals() {
+		o.addValues(o.endVals, t, vt)
+	}
+}
+
+func (o *Store) addValues(idx valueIndex, t *Tag, vt *comid.ValueTriple) {
+	// the CoMID has been validated, so the environment can be encoded
+	envCBOR, _ := vt.Environment.ToCBOR()
+
+	for _, m := range vt.Measurements.Values {
+		o.seq++
+		idx.add(&Value{
+			Tag:         t,
+			Environment: vt.Environment,
+			Measurement: m,
+			seq:         o.seq,
+		}, string(envCBOR))
+	}
+}
+
+// RemoveCorim removes the CoRIM with the supplied id, and the CoMIDs it
+// carries, from the Store.
+func (o *Store) RemoveCorim(id string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.corims[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrCorimNotFound, id)
+	}
+
+	fromCorim := func(t *Tag) bool { return t.CorimID == id }
+
+	for _, m := range []map[string][]*Tag{o.tags, o.replacedBy, o.supplementedBy} {
+		for k, ts := range m {
+			if ts = slices.DeleteFunc(ts, fromCorim); len(ts) == 0 {
+				delete(m, k)
+			} else {
+				m[k] = ts
+			}
+		}
+	}
+
+	o.refVals.removeCorim(id)
+	o.endVals.removeCorim(id)
+
+	delete(o.corims, id)
+	delete(o.corimTags, id)
+
+	return nil
+}
+
+// Corim returns the CoRIM with the supplied id, or false if there is no such
+// CoRIM in the Store.
+func (o *Store) Corim(id string) (*corim.UnsignedCorim, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	uc, ok := o.corims[id]
+	return uc, ok
+}
+
+// CorimIDs returns the ids of the CoRIMs in the Store, in lexical order
+func (o *Store) CorimIDs() []string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	ret := make([]string, 0, len(o.corims))
+	for id := range o.corims {
+		ret = append(ret, id)
+	}
+	sort.Strings(ret)
+
+	return ret
+}
+
+// CorimTags returns the CoMIDs carried by the CoRIM with the supplied id
+func (o *Store) CorimTags(id string) []*Tag {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return slices.Clone(o.corimTags[id])
+}
+
+// Tag returns the CoMID with the supplied tag identity, or false if there is
+// no such CoMID in the Store.
+func (o *Store) Tag(ti comid.TagIdentity) (*Tag, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	t := o.tag(ti.TagID.String(), ti.TagVersion)
+	return t, t != nil
+}
+
+func (o *Store) tag(id string, version uint) *Tag {
+	for _, t := range o.tags[id] {
+		if t.Version() == version {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// LatestTag returns the version of the CoMID with the supplied tag-id that
+// has the highest tag-version, or false if there is no such CoMID in the
+// Store. Note that the returned CoMID may not be effective, e.g., if it has
+// been replaced.
+func (o *Store) LatestTag(tagID string) (*Tag, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	t := o.latest(tagID)
+	return t, t != nil
+}
+
+func (o *Store) latest(tagID string) *Tag {
+	versions := o.tags[tagID]
+	if len(versions) == 0 {
+		return nil
+	}
+
+	return versions[len(versions)-1]
+}
+
+// IsEffective returns true if the supplied CoMID is in the Store and is
+// effective, i.e., it is the latest version of its tag-id, it has not been
+// replaced, and all the tags it supplements (if any) are effective.
+func (o *Store) IsEffective(t *Tag) bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return o.effective(t, make(map[*Tag]bool))
+}
+
+// effective implements IsEffective. The supplied map memoizes the result for
+// the tags visited so far; a tag that is being visited is recorded as not
+// effective, so that supplements cycles are not effective.
+func (o *Store) effective(t *Tag, memo map[*Tag]bool) bool {
+	if ret, ok := memo[t]; ok {
+		return ret
+	}
+
+	memo[t] = false
+
+	if o.latest(t.ID()) != t {
+		return false
+	}
+
+	// a replacing tag retires its target as long as it is itself the latest
+	// version of its tag-id, even if it has been replaced in turn
+	for _, r := range o.replacedBy[t.ID()] {
+		if o.latest(r.ID()) == r {
+			return false
+		}
+	}
+
+	for _, target := range t.links(comid.RelSupplements) {
+		base := o.latest(target)
+		if base == nil || !o.effective(base, memo) {
+			return false
+		}
+	}
+
+	memo[t] = true
+
+	return true
+}
+
+// EffectiveTags returns the effective CoMIDs in the Store (see IsEffective),
+// ordered by tag-id.
+func (o *Store) EffectiveTags() []*Tag {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	var ret []*Tag
+
+	memo := make(map[*Tag]bool)
+
+	for id := range o.tags {
+		if t := o.latest(id); o.effective(t, memo) {
+			ret = append(ret, t)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool { return ret[i].ID() < ret[j].ID() })
+
+	return ret
+}
+
+// Supplements returns the effective CoMIDs that supplement the supplied one,
+// in the order they were added.
+func (o *Store) Supplements(t *Tag) []*Tag {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	var ret []*Tag
+
+	memo := make(map[*Tag]bool)
+
+	for _, s := range o.supplementedBy[t.ID()] {
+		if o.effective(s, memo) {
+			ret = append(ret, s)
+		}
+	}
+
+	return ret
+}
+
+// ReferenceValues returns the reference values from effective CoMIDs whose
+// environment covers the supplied one (see comid.Environment.Match), in the
+// order they were added.
+func (o *Store) ReferenceValues(env comid.Environment) []*Value {
+	return o.values(o.refVals, env, nil)
+}
+
+// ReferenceValuesByMkey is like ReferenceValues, but only returns the
+// reference values for measurements with the supplied mkey.
+func (o *Store) ReferenceValuesByMkey(env comid.Environment, key comid.Mkey) []*Value {
+	return o.values(o.refVals, env, &key)
+}
+
+// EndorsedValues returns the endorsed values from effective CoMIDs whose
+// environment covers the supplied one (see comid.Environment.Match), in the
+// order they were added.
+func (o *Store) EndorsedValues(env comid.Environment) []*Value {
+	return o.values(o.endVals, env, nil)
+}
+
+// EndorsedValuesByMkey is like EndorsedValues, but only returns the endorsed
+// values for measurements with the supplied mkey.
+func (o *Store) EndorsedValuesByMkey(env comid.Environment, key comid.Mkey) []*Value {
+	return o.values(o.endVals, env, &key)
+}
+
+func (o *Store) values(idx valueIndex, env comid.Environment, key *comid.Mkey) []*Value {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	var ret []*Value
+
+	memo := make(map[*Tag]bool)
+
+	keep := func(v *Value) {
+		if o.effective(v.Tag, memo) {
+			ret = append(ret, v)
+		}
+	}
+
+	if key != nil {
+		k, ok := mkeyIndexKey(key)
+		if !ok {
+			return nil
+		}
+
+		for _, v := range idx.byMkey[k] {
+			if env.Match(v.Environment) == nil {
+				keep(v)
+			}
+		}
+	} else {
+		// values sharing an environment are matched against it once
+		for _, vs := range idx.byEnv {
+			if env.Match(vs[0].Environment) != nil {
+				continue
+			}
+
+			for _, v := range vs {
+				keep(v)
+			}
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool { return ret[i].seq < ret[j].seq })
+
+	return ret
+}
@@ -0,0 +1,267 @@
+// Copyright 2026 Contributors to the Veraison project.
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/veraison/corim/comid"
+	"github.com/veraison/corim/corim"
+)
+
+var (
+	testEnvA = comid.Environment{
+		Class: comid.NewClassOID("2.5.2.8192").SetVendor("ACME").SetModel("A"),
+	}
+	testEnvB = comid.Environment{
+		Class: comid.NewClassOID("2.5.2.8193").SetVendor("ACME").SetModel("B"),
+	}
+)
+
+func testComid(tagID string, version uint, env comid.Environment, svns ...uint64) *comid.Comid {
+	ms := comid.NewMeasurements()
+	for i, svn := range svns {
+		ms.Add(comid.MustNewUintMeasurement(uint64(i)).SetSVN(svn))
+	}
+
+	return comid.NewComid().
+		SetTagIdentity(tagID, version).
+		AddReferenceValue(&comid.ValueTriple{Environment: env, Measurements: *ms})
+}
+
+func testCorim(id string, comids ...*comid.Comid) *corim.UnsignedCorim {
+	uc := corim.NewUnsignedCorim().SetID(id)
+	for _, c := range comids {
+		uc.AddComid(c)
+	}
+	return uc
+}
+
+func tagIDs(tags []*Tag) []string {
+	ret := make([]string, 0, len(tags))
+	for _, t := range tags {
+		ret = append(ret, t.ID())
+	}
+	return ret
+}
+
+func valueSVNs(t *testing.T, vals []*Value) []uint64 {
+	ret := make([]uint64, 0, len(vals))
+	for _, v := range vals {
+		require.NotNil(t, v.Measurement.Val.SVN)
+		svn, ok := v.Measurement.Val.SVN.Value.(*comid.TaggedSVN)
+		require.True(t, ok)
+		ret = append(ret, uint64(*svn))
+	}
+	return ret
+}
+
+func TestStore_AddCorim(t *testing.T) {
+	s := NewStore()
+
+	require.NoError(t, s.AddCorim(testCorim("corim-1",
+		testComid("tag-a", 0, testEnvA, 1, 2),
+		testComid("tag-b", 0, testEnvB, 3),
+	)))
+	require.NoError(t, s.AddCorim(testCorim("corim-2",
+		comid.NewComid().SetTagIdentity("tag-c", 0).
+			AddEndorsedValue(&comid.ValueTriple{
+				Environment:  testEnvA,
+				Measurements: *comid.NewMeasurements().Add(comid.MustNewUintMeasurement(uint64(0)).SetSVN(7)),
+			}),
+	)))
+
+	assert.Equal(t, []string{"corim-1", "corim-2"}, s.CorimIDs())
+
+	uc, ok := s.Corim("corim-1")
+	require.True(t, ok)
+	assert.Equal(t, "corim-1", uc.GetID())
+	assert.Equal(t, []string{"tag-a", "tag-b"}, tagIDs(s.CorimTags("corim-1")))
+
+	tag, ok := s.Tag(comid.NewComid().SetTagIdentity("tag-b", 0).TagIdentity)
+	require.True(t, ok)
+	assert.Equal(t, "corim-1", tag.CorimID)
+	assert.True(t, s.IsEffective(tag))
+
+	assert.Equal(t, []string{"tag-a", "tag-b", "tag-c"}, tagIDs(s.EffectiveTags()))
+
+	assert.Equal(t, []uint64{1, 2}, valueSVNs(t, s.ReferenceValues(testEnvA)))
+	assert.Equal(t, []uint64{3}, valueSVNs(t, s.ReferenceValues(testEnvB)))
+	assert.Equal(t, []uint64{7}, valueSVNs(t, s.EndorsedValues(testEnvA)))
+	assert.Empty(t, s.EndorsedValues(testEnvB))
+
+	mkey := comid.MustNewMkey(uint64(1), comid.UintType)
+	assert.Equal(t, []uint64{2}, valueSVNs(t, s.ReferenceValuesByMkey(testEnvA, *mkey)))
+	assert.Empty(t, s.ReferenceValuesByMkey(testEnvB, *mkey))
+	assert.Empty(t, s.EndorsedValuesByMkey(testEnvA, *mkey))
+}
+
+func TestStore_ReferenceValues_environment_match(t *testing.T) {
+	s := NewStore()
+
+	// a reference that only specifies the vendor covers both environments
+	vendorOnly := comid.Environment{Class: comid.NewClassOID("2.5.2.8192").SetVendor("ACME")}
+	require.NoError(t, s.AddCorim(testCorim("corim-1",
+		testComid("tag-a", 0, vendorOnly, 1),
+		testComid("tag-b", 0, testEnvA, 2),
+	)))
+
+	assert.Equal(t, []uint64{1, 2}, valueSVNs(t, s.ReferenceValues(testEnvA)))
+	assert.Empty(t, s.ReferenceValues(testEnvB))
+}
+
+func TestStore_AddCorim_NOK(t *testing.T) {
+	s := NewStore()
+
+	assert.EqualError(t, s.AddCorim(nil), "nil CoRIM")
+	assert.ErrorContains(t, s.AddCorim(corim.NewUnsignedCorim()), "invalid CoRIM id")
+
+	require.NoError(t, s.AddCorim(testCorim("corim-1", testComid("tag-a", 0, testEnvA, 1))))
+
+	err := s.AddCorim(testCorim("corim-1", testComid("tag-b", 0, testEnvA, 1)))
+	assert.ErrorIs(t, err, ErrDuplicateCorim)
+
+	err = s.AddCorim(testCorim("corim-2",
+		testComid("tag-b", 0, testEnvA, 1),
+		testComid("tag-a", 0, testEnvA, 1),
+	))
+	assert.ErrorIs(t, err, ErrDuplicateTag)
+	assert.EqualError(t, err, "duplicate CoMID tag identity: tag-a (version 0)")
+
+	err = s.AddCorim(testCorim("corim-2",
+		testComid("tag-b", 0, testEnvA, 1),
+		testComid("tag-b", 0, testEnvA, 2),
+	))
+	assert.ErrorIs(t, err, ErrDuplicateTag)
+
+	// nothing from the failed CoRIMs has been added
+	assert.Equal(t, []string{"corim-1"}, s.CorimIDs())
+	_, ok := s.LatestTag("tag-b")
+	assert.False(t, ok)
+
+	uc := testCorim("corim-3")
+	uc.Tags = append(uc.Tags, corim.Tag{Number: 1234, Content: []byte{0xa0}})
+	assert.EqualError(t, s.AddCorim(uc), "unknown CBOR tag 4d2 detected at index 0")
+}
+
+func TestStore_tag_versions(t *testing.T) {
+	s := NewStore()
+
+	require.NoError(t, s.AddCorim(testCorim("corim-v2", testComid("tag-a", 2, testEnvA, 2))))
+	require.NoError(t, s.AddCorim(testCorim("corim-v1", testComid("tag-a", 1, testEnvA, 1))))
+
+	latest, ok := s.LatestTag("tag-a")
+	require.True(t, ok)
+	assert.Equal(t, uint(2), latest.Version())
+
+	v1, ok := s.Tag(comid.NewComid().SetTagIdentity("tag-a", 1).TagIdentity)
+	require.True(t, ok)
+	assert.False(t, s.IsEffective(v1))
+	assert.True(t, s.IsEffective(latest))
+
+	assert.Equal(t, []uint64{2}, valueSVNs(t, s.ReferenceValues(testEnvA)))
+
+	require.NoError(t, s.RemoveCorim("corim-v2"))
+	assert.True(t, s.IsEffective(v1))
+	assert.Equal(t, []uint64{1}, valueSVNs(t, s.ReferenceValues(testEnvA)))
+}
+
+func TestStore_replaces(t *testing.T) {
+	s := NewStore()
+
+	require.NoError(t, s.AddCorim(testCorim("old", testComid("tag-old", 0, testEnvA, 1))))
+	require.NoError(t, s.AddCorim(testCorim("new",
+		testComid("tag-new", 0, testEnvA, 2).AddLinkedTag("tag-old", comid.RelReplaces),
+	)))
+
+	old, ok := s.LatestTag("tag-old")
+	require.True(t, ok)
+	assert.False(t, s.IsEffective(old))
+	assert.Equal(t, []string{"tag-new"}, tagIDs(s.EffectiveTags()))
+	assert.Equal(t, []uint64{2}, valueSVNs(t, s.ReferenceValues(testEnvA)))
+
+	// a newer version of the replaced tag is retired too
+	require.NoError(t, s.AddCorim(testCorim("old-v1", testComid("tag-old", 1, testEnvA, 3))))
+	assert.Equal(t, []uint64{2}, valueSVNs(t, s.ReferenceValues(testEnvA)))
+
+	// the replacement retires its target even if it is replaced in turn
+	require.NoError(t, s.AddCorim(testCorim("newer",
+		testComid("tag-newer", 0, testEnvA, 4).AddLinkedTag("tag-new", comid.RelReplaces),
+	)))
+	assert.Equal(t, []uint64{4}, valueSVNs(t, s.ReferenceValues(testEnvA)))
+
+	// removing the replacements brings the old tag back
+	require.NoError(t, s.RemoveCorim("newer"))
+	require.NoError(t, s.RemoveCorim("new"))
+	assert.Equal(t, []uint64{3}, valueSVNs(t, s.ReferenceValues(testEnvA)))
+}
+
+func TestStore_supplements(t *testing.T) {
+	s := NewStore()
+
+	require.NoError(t, s.AddCorim(testCorim("supp",
+		testComid("tag-supp", 0, testEnvA, 2).AddLinkedTag("tag-base", comid.RelSupplements),
+	)))
+
+	// no base yet: the supplement is dangling
+	supp, ok := s.LatestTag("tag-supp")
+	require.True(t, ok)
+	assert.False(t, s.IsEffective(supp))
+	assert.Empty(t, s.ReferenceValues(testEnvA))
+
+	require.NoError(t, s.AddCorim(testCorim("base", testComid("tag-base", 0, testEnvA, 1))))
+
+	base, ok := s.LatestTag("tag-base")
+	require.True(t, ok)
+	assert.True(t, s.IsEffective(supp))
+	assert.Equal(t, []string{"tag-supp"}, tagIDs(s.Supplements(base)))
+	assert.Equal(t, []uint64{2, 1}, valueSVNs(t, s.ReferenceValues(testEnvA)))
+
+	// retiring the base retires the supplement
+	require.NoError(t, s.AddCorim(testCorim("repl",
+		testComid("tag-repl", 0, testEnvA, 3).AddLinkedTag("tag-base", comid.RelReplaces),
+	)))
+	assert.False(t, s.IsEffective(supp))
+	assert.Empty(t, s.Supplements(base))
+	assert.Equal(t, []uint64{3}, valueSVNs(t, s.ReferenceValues(testEnvA)))
+}
+
+func TestStore_supplements_cycle(t *testing.T) {
+	s := NewStore()
+
+	require.NoError(t, s.AddCorim(testCorim("cycle",
+		testComid("tag-a", 0, testEnvA, 1).AddLinkedTag("tag-b", comid.RelSupplements),
+		testComid("tag-b", 0, testEnvA, 2).AddLinkedTag("tag-a", comid.RelSupplements),
+	)))
+
+	assert.Empty(t, s.EffectiveTags())
+	assert.Empty(t, s.ReferenceValues(testEnvA))
+}
+
+func TestStore_RemoveCorim(t *testing.T) {
+	s := NewStore()
+
+	require.NoError(t, s.AddCorim(testCorim("corim-1", testComid("tag-a", 0, testEnvA, 1))))
+	require.NoError(t, s.AddCorim(testCorim("corim-2", testComid("tag-b", 0, testEnvA, 2))))
+
+	require.NoError(t, s.RemoveCorim("corim-1"))
+
+	assert.Equal(t, []string{"corim-2"}, s.CorimIDs())
+	_, ok := s.Corim("corim-1")
+	assert.False(t, ok)
+	assert.Empty(t, s.CorimTags("corim-1"))
+	assert.Equal(t, []uint64{2}, valueSVNs(t, s.ReferenceValues(testEnvA)))
+
+	mkey := comid.MustNewMkey(uint64(0), comid.UintType)
+	assert.Equal(t, []uint64{2}, valueSVNs(t, s.ReferenceValuesByMkey(testEnvA, *mkey)))
+
+	err := s.RemoveCorim("corim-1")
+	assert.ErrorIs(t, err, ErrCorimNotFound)
+	assert.EqualError(t, err, "CoRIM not found: corim-1")
+
+	// the tag identities of a removed CoRIM can be reused
+	assert.NoError(t, s.AddCorim(testCorim("corim-1", testComid("tag-a", 0, testEnvA, 1))))
+}